@@ -0,0 +1,209 @@
+// Package appendmergecheck defines an analyzer that detects consecutive
+// appends to the same slice that can be a single append.
+//
+// # Analyzer appendmergecheck
+//
+// appendmergecheck: detect consecutive appends to the same slice
+//
+// This analyzer flags runs of two or more statements that each append
+// elements to the same slice variable:
+//
+//	s = append(s, a)
+//	s = append(s, b)
+//	s = append(s, c)
+//
+// append is variadic, so one call does the same and grows the slice at most
+// once:
+//
+//	s = append(s, a, b, c)
+//
+// An append that spreads another slice, as in append(s, t...), cannot be
+// given further elements, since Go allows no elements alongside a spread
+// argument; it ends the run, as does an append whose elements read
+// the slice, since merging would change what they see. The fix collapses the
+// run into its first statement; it is withheld when comments other than at
+// the end of the first line sit inside the run.
+package appendmergecheck
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "appendmergecheck",
+	Doc:      "detect consecutive appends to the same slice",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i < len(list); {
+			first, s, ok := appendTo(pass, list[i])
+			if !ok {
+				i++
+				continue
+			}
+
+			run := []*ast.CallExpr{first}
+			j := i + 1
+			for j < len(list) {
+				next, obj, ok := appendTo(pass, list[j])
+				if !ok || obj != s || mentions(pass, next.Args[1:], s) {
+					break
+				}
+				run = append(run, next)
+				j++
+			}
+
+			if len(run) >= 2 {
+				report(pass, list[i:j], run, s)
+			}
+			i = j
+		}
+	})
+
+	return nil, nil
+}
+
+// appendTo matches s = append(s, a, b, ...) where s is a variable, and
+// returns the append call and s. Appends with no elements or spreading a
+// slice are not matched, since neither can take more elements.
+func appendTo(pass *analysis.Pass, stmt ast.Stmt) (*ast.CallExpr, types.Object, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	lhs, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return nil, nil, false
+	}
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "append" {
+		return nil, nil, false
+	}
+	if _, ok := pass.TypesInfo.ObjectOf(fun).(*types.Builtin); !ok {
+		return nil, nil, false
+	}
+	arg, ok := call.Args[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Uses[lhs]
+	if obj == nil || pass.TypesInfo.Uses[arg] != obj {
+		return nil, nil, false
+	}
+	return call, obj, true
+}
+
+// report reports the run of appends in stmts, with a fix when the run can
+// be collapsed without losing comments.
+func report(pass *analysis.Pass, stmts []ast.Stmt, run []*ast.CallExpr, s types.Object) {
+	msg := fmt.Sprintf("%d consecutive appends to %s can be a single append", len(run), s.Name())
+	diag := analysis.Diagnostic{Pos: stmts[0].Pos(), Message: msg}
+	if edits := buildFix(pass, stmts, run); edits != nil {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{Message: "merge the appends", TextEdits: edits},
+		}
+	}
+	pass.Report(diag)
+}
+
+// buildFix returns TextEdits moving the elements of every append in run into
+// the first one and deleting the other statements. It returns nil if a
+// comment other than one ending the first statement would be deleted.
+func buildFix(pass *analysis.Pass, stmts []ast.Stmt, run []*ast.CallExpr) []analysis.TextEdit {
+	tokFile := pass.Fset.File(stmts[0].Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	first, last := stmts[0], stmts[len(stmts)-1]
+
+	// Delete from the end of the line the first statement ends on, keeping
+	// its comment, unless the whole run shares that line.
+	del := first.End()
+	if line := tokFile.Line(first.End()); line != tokFile.Line(last.End()) {
+		del = tokFile.LineStart(line+1) - 1
+	}
+	lastArg := run[0].Args[len(run[0].Args)-1]
+
+	file := importutil.FindFileForPos(pass, first.Pos())
+	if file == nil {
+		return nil
+	}
+	for _, cg := range file.Comments {
+		if cg.Pos() > del && cg.Pos() < last.End() {
+			return nil
+		}
+	}
+
+	// When the first call ends with its closing parenthesis on a line of its
+	// own, each new element gets a line too, before the trailing comma;
+	// otherwise the elements follow the last one.
+	sep := []byte(", ")
+	if tokFile.Line(run[0].Rparen) != tokFile.Line(lastArg.End()) {
+		lineStart := tokFile.LineStart(tokFile.Line(lastArg.End()))
+		indent := src[tokFile.Offset(lineStart):tokFile.Offset(lastArg.End())]
+		indent = indent[:len(indent)-len(bytes.TrimLeft(indent, " \t"))]
+		sep = append([]byte(",\n"), indent...)
+	}
+
+	var buf bytes.Buffer
+	for _, call := range run[1:] {
+		for _, arg := range call.Args[1:] {
+			buf.Write(sep)
+			buf.Write(src[tokFile.Offset(arg.Pos()):tokFile.Offset(arg.End())])
+		}
+	}
+
+	return []analysis.TextEdit{
+		{Pos: lastArg.End(), End: lastArg.End(), NewText: buf.Bytes()},
+		{Pos: del, End: last.End()},
+	}
+}
+
+// mentions reports whether any of exprs uses obj.
+func mentions(pass *analysis.Pass, exprs []ast.Expr, obj types.Object) bool {
+	found := false
+	for _, expr := range exprs {
+		ast.Inspect(expr, func(n ast.Node) bool {
+			if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+				found = true
+			}
+			return !found
+		})
+	}
+	return found
+}
@@ -0,0 +1,13 @@
+package appendmergecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/appendmergecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAppendMergeCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, appendmergecheck.Analyzer, "appendmergetest")
+}
@@ -0,0 +1,77 @@
+package appendmergetest
+
+func three(s []int, a, b, c int) []int {
+	s = append(s, a) // want `3 consecutive appends to s can be a single append`
+	s = append(s, b)
+	s = append(s, c)
+	return s
+}
+
+func multiElement(names []string) []string {
+	names = append(names, "a", "b") // want `2 consecutive appends to names can be a single append`
+	names = append(names, "c")
+	return names
+}
+
+func multiLine(s []string) []string {
+	s = append(s, // want `2 consecutive appends to s can be a single append`
+		"first",
+		"second",
+	)
+	s = append(s, "third")
+	return s
+}
+
+func inCase(s []int, n int) []int {
+	switch n {
+	case 0:
+		s = append(s, 0) // want `2 consecutive appends to s can be a single append`
+		s = append(s, 0)
+	}
+	return s
+}
+
+// A comment inside the run would be lost, so there is no fix.
+func commented(s []int) []int {
+	s = append(s, 1) // want `2 consecutive appends to s can be a single append`
+	// the second element
+	s = append(s, 2)
+	return s
+}
+
+// Another statement breaks the run.
+func interrupted(s []int, n int) []int {
+	s = append(s, 1)
+	n++
+	s = append(s, n)
+	return s
+}
+
+// Different slices do not merge.
+func different(s, t []int) ([]int, []int) {
+	s = append(s, 1)
+	t = append(t, 2)
+	return s, t
+}
+
+// A spread cannot be combined with other elements.
+func spread(s, t []int) []int {
+	s = append(s, 1)
+	s = append(s, t...)
+	s = append(s, 2)
+	return s
+}
+
+// The second element reads s, which has changed by then.
+func readsSlice(s []int) []int {
+	s = append(s, 1)
+	s = append(s, len(s))
+	return s
+}
+
+// Appending to another slice is not a self-append.
+func otherSource(s, t []int) []int {
+	s = append(t, 1)
+	s = append(s, 2)
+	return s
+}
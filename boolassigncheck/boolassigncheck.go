@@ -0,0 +1,241 @@
+// Package boolassigncheck defines an analyzer that detects boolean variables
+// declared and then set by an if statement, where the condition could be
+// assigned directly.
+//
+// # Analyzer boolassigncheck
+//
+// boolassigncheck: detect bool set by an if statement that can be assigned directly
+//
+// This analyzer flags a bool declaration immediately followed by an if
+// statement that only sets the variable:
+//
+//	var ok bool
+//	if x > 0 {
+//	    ok = true
+//	}
+//
+//	ok := false
+//	if x > 0 {
+//	    ok = true
+//	} else {
+//	    ok = false
+//	}
+//
+// Both can be replaced with:
+//
+//	ok := x > 0
+//
+// When the branches assign the opposite values, the condition is negated.
+// The variable must have type bool (not a named bool type, whose type
+// would change), the declaration and the if must be adjacent, and the
+// condition must not refer to the variable itself.
+package boolassigncheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "boolassigncheck",
+	Doc:      "detect bool set by an if statement that can be assigned directly",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i := 0; i < len(block.List)-1; i++ {
+			checkPair(pass, block.List[i], block.List[i+1])
+		}
+	})
+
+	return nil, nil
+}
+
+// checkPair checks whether decl declares a bool that ifStmt then sets.
+func checkPair(pass *analysis.Pass, decl, next ast.Stmt) {
+	name, initVal, ok := boolDecl(pass, decl)
+	if !ok {
+		return
+	}
+	obj := pass.TypesInfo.ObjectOf(name)
+	if obj == nil || !types.Identical(obj.Type(), types.Typ[types.Bool]) {
+		return
+	}
+
+	ifStmt, ok := next.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil {
+		return
+	}
+
+	thenVal, ok := boolAssign(pass, ifStmt.Body, obj)
+	if !ok {
+		return
+	}
+
+	switch elseStmt := ifStmt.Else.(type) {
+	case nil:
+		// if-only: the branch must flip the initial value.
+		if thenVal == initVal {
+			return
+		}
+	case *ast.BlockStmt:
+		elseVal, ok := boolAssign(pass, elseStmt, obj)
+		if !ok || elseVal == thenVal {
+			return
+		}
+	default:
+		return // else if
+	}
+
+	if usesObject(pass, ifStmt.Cond, obj) {
+		return
+	}
+
+	condSrc, err := nodeText(pass, ifStmt.Cond)
+	if err != nil {
+		return
+	}
+	if !thenVal {
+		condSrc = negate(ifStmt.Cond, condSrc)
+	}
+
+	newText := fmt.Sprintf("%s := %s", name.Name, condSrc)
+	msg := fmt.Sprintf("%s can be assigned directly: %s", name.Name, newText)
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     decl.Pos(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     decl.Pos(),
+						End:     ifStmt.End(),
+						NewText: []byte(newText),
+					},
+				},
+			},
+		},
+	})
+}
+
+// boolDecl matches a declaration of a single variable with a constant bool
+// initial value:
+//
+//	var ok bool
+//	var ok = false
+//	ok := true
+func boolDecl(pass *analysis.Pass, stmt ast.Stmt) (name *ast.Ident, initVal bool, ok bool) {
+	switch stmt := stmt.(type) {
+	case *ast.DeclStmt:
+		gd, isGen := stmt.Decl.(*ast.GenDecl)
+		if !isGen || gd.Tok != token.VAR || len(gd.Specs) != 1 {
+			return nil, false, false
+		}
+		spec := gd.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 {
+			return nil, false, false
+		}
+		switch len(spec.Values) {
+		case 0:
+			return spec.Names[0], false, true
+		case 1:
+			v, isConst := boolConst(pass, spec.Values[0])
+			return spec.Names[0], v, isConst
+		}
+
+	case *ast.AssignStmt:
+		if stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return nil, false, false
+		}
+		ident, isIdent := stmt.Lhs[0].(*ast.Ident)
+		if !isIdent {
+			return nil, false, false
+		}
+		v, isConst := boolConst(pass, stmt.Rhs[0])
+		return ident, v, isConst
+	}
+	return nil, false, false
+}
+
+// boolAssign matches a block consisting only of obj = true or obj = false.
+func boolAssign(pass *analysis.Pass, block *ast.BlockStmt, obj types.Object) (val bool, ok bool) {
+	if len(block.List) != 1 {
+		return false, false
+	}
+	assign, isAssign := block.List[0].(*ast.AssignStmt)
+	if !isAssign || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false, false
+	}
+	lhs, isIdent := assign.Lhs[0].(*ast.Ident)
+	if !isIdent || pass.TypesInfo.ObjectOf(lhs) != obj {
+		return false, false
+	}
+	return boolConst(pass, assign.Rhs[0])
+}
+
+// boolConst reports the value of expr if it is the predeclared true or false.
+func boolConst(pass *analysis.Pass, expr ast.Expr) (val bool, ok bool) {
+	ident, isIdent := expr.(*ast.Ident)
+	if !isIdent {
+		return false, false
+	}
+	switch pass.TypesInfo.ObjectOf(ident) {
+	case types.Universe.Lookup("true"):
+		return true, true
+	case types.Universe.Lookup("false"):
+		return false, true
+	}
+	return false, false
+}
+
+// usesObject reports whether expr refers to obj.
+func usesObject(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// negate returns the source of !expr, dropping an existing negation or
+// adding parentheses as needed.
+func negate(expr ast.Expr, src string) string {
+	if unary, ok := expr.(*ast.UnaryExpr); ok && unary.Op == token.NOT {
+		return src[1:]
+	}
+	switch expr.(type) {
+	case *ast.Ident, *ast.CallExpr, *ast.SelectorExpr, *ast.IndexExpr, *ast.ParenExpr:
+		return "!" + src
+	}
+	return "!(" + src + ")"
+}
+
+// nodeText returns the source text of node as written in the file.
+func nodeText(pass *analysis.Pass, node ast.Node) (string, error) {
+	tokFile := pass.Fset.File(node.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return "", err
+	}
+	return string(src[tokFile.Offset(node.Pos()):tokFile.Offset(node.End())]), nil
+}
@@ -0,0 +1,13 @@
+package boolassigncheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/boolassigncheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestBoolAssignCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, boolassigncheck.Analyzer, "boolassigntest")
+}
@@ -0,0 +1,114 @@
+package boolassigntest
+
+func ready() bool { return true }
+
+func ifOnly(x int) {
+	// Should be flagged: var declaration, if-only.
+	var ok bool // want `ok can be assigned directly: ok := x > 0`
+	if x > 0 {
+		ok = true
+	}
+	_ = ok
+
+	// Should be flagged: := false, if-only.
+	found := false // want `found can be assigned directly: found := x == 3 \|\| x == 4`
+	if x == 3 || x == 4 {
+		found = true
+	}
+	_ = found
+
+	// Should be flagged: starts true, cleared by the if — negated.
+	valid := true // want `valid can be assigned directly: valid := !ready\(\)`
+	if ready() {
+		valid = false
+	}
+	_ = valid
+}
+
+func ifElse(x int) {
+	// Should be flagged: if/else form.
+	var big = false // want `big can be assigned directly: big := x > 100`
+	if x > 100 {
+		big = true
+	} else {
+		big = false
+	}
+	_ = big
+
+	// Should be flagged: inverted if/else form.
+	small := true // want `small can be assigned directly: small := !\(x > 100\)`
+	if x > 100 {
+		small = false
+	} else {
+		small = true
+	}
+	_ = small
+
+	// Should be flagged: negated condition loses its !.
+	var idle bool // want `idle can be assigned directly: idle := ready\(\)`
+	if !ready() {
+		idle = false
+	} else {
+		idle = true
+	}
+	_ = idle
+}
+
+type flag bool
+
+func noMatch(x int) {
+	// Not adjacent — should NOT be flagged.
+	var a bool
+	x++
+	if x > 0 {
+		a = true
+	}
+	_ = a
+
+	// Assigns the initial value again — should NOT be flagged.
+	b := false
+	if x > 0 {
+		b = false
+	}
+	_ = b
+
+	// Extra statement in the branch — should NOT be flagged.
+	c := false
+	if x > 0 {
+		c = true
+		x--
+	}
+	_ = c
+
+	// Condition refers to the variable — should NOT be flagged.
+	d := false
+	if d || x > 0 {
+		d = true
+	}
+	_ = d
+
+	// Named bool type — := would change the type, should NOT be flagged.
+	var e flag
+	if x > 0 {
+		e = true
+	}
+	_ = e
+
+	// else-if chain — should NOT be flagged.
+	f := false
+	if x > 0 {
+		f = true
+	} else if x < -10 {
+		f = false
+	}
+	_ = f
+
+	// Both branches assign the same value — should NOT be flagged.
+	g := false
+	if x > 0 {
+		g = true
+	} else {
+		g = true
+	}
+	_ = g
+}
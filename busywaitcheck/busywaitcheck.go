@@ -0,0 +1,128 @@
+// Package busywaitcheck defines an analyzer that detects sleep-based polling
+// loops.
+//
+// # Analyzer busywaitcheck
+//
+// busywaitcheck: detect busy-wait loops that poll a condition with time.Sleep
+//
+// This analyzer flags for loops whose body does nothing but check a
+// condition and sleep:
+//
+//	for {
+//	    if ready() {
+//	        break
+//	    }
+//	    time.Sleep(10 * time.Millisecond)
+//	}
+//
+//	for !ready() {
+//	    time.Sleep(10 * time.Millisecond)
+//	}
+//
+// Polling with time.Sleep wastes wakeups and adds latency. Waiting on a
+// channel, a sync.Cond, or a context is usually better. The right
+// replacement depends on where the condition is set, so no auto-fix is
+// provided.
+package busywaitcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "busywaitcheck",
+	Doc:      "detect busy-wait loops that poll a condition with time.Sleep",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ForStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		loop := n.(*ast.ForStmt)
+		if !isBusyWait(pass, loop) {
+			return
+		}
+
+		pass.Reportf(loop.Pos(),
+			"busy-wait loop polls with time.Sleep; consider waiting on a channel, sync.Cond, or context instead")
+	})
+
+	return nil, nil
+}
+
+// isBusyWait reports whether loop has one of the forms:
+//
+//	for { if cond { break }; time.Sleep(d) }
+//	for { time.Sleep(d); if cond { break } }
+//	for cond { time.Sleep(d) }
+func isBusyWait(pass *analysis.Pass, loop *ast.ForStmt) bool {
+	body := loop.Body.List
+
+	// A counted loop like for i := 0; i < n; i++ { time.Sleep(d) } is a
+	// fixed delay, not a poll.
+	if loop.Init != nil || loop.Post != nil {
+		return false
+	}
+
+	if loop.Cond != nil {
+		return len(body) == 1 && isTimeSleep(pass, body[0])
+	}
+
+	if len(body) != 2 {
+		return false
+	}
+	return (isConditionalBreak(body[0]) && isTimeSleep(pass, body[1])) ||
+		(isTimeSleep(pass, body[0]) && isConditionalBreak(body[1]))
+}
+
+// isConditionalBreak reports whether stmt is if cond { break } with no init
+// statement, no else branch, and an unlabeled break.
+func isConditionalBreak(stmt ast.Stmt) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) != 1 {
+		return false
+	}
+	br, ok := ifStmt.Body.List[0].(*ast.BranchStmt)
+	return ok && br.Tok == token.BREAK && br.Label == nil
+}
+
+// isTimeSleep reports whether stmt is a call to time.Sleep from the standard
+// library.
+func isTimeSleep(pass *analysis.Pass, stmt ast.Stmt) bool {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Sleep" {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return false
+	}
+
+	return pkgName.Imported().Path() == "time"
+}
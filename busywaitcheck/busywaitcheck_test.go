@@ -0,0 +1,13 @@
+package busywaitcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/busywaitcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestBusyWaitCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, busywaitcheck.Analyzer, "busywaittest")
+}
@@ -0,0 +1,84 @@
+package busywaittest
+
+import (
+	"context"
+	"time"
+)
+
+func ready() bool { return true }
+
+func pollBreak() {
+	// Should be flagged: conditional break then sleep.
+	for { // want "busy-wait loop polls with time.Sleep"
+		if ready() {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func pollSleepFirst() {
+	// Should be flagged: sleep then conditional break.
+	for { // want "busy-wait loop polls with time.Sleep"
+		time.Sleep(time.Millisecond)
+		if ready() {
+			break
+		}
+	}
+}
+
+func pollCondition() {
+	// Should be flagged: loop condition with a sleeping body.
+	for !ready() { // want "busy-wait loop polls with time.Sleep"
+		time.Sleep(time.Millisecond)
+	}
+}
+
+type clock struct{}
+
+func (clock) Sleep(time.Duration) {}
+
+func noMatch(ctx context.Context) {
+	// Ticker-based loop — should NOT be flagged.
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if ready() {
+			break
+		}
+	}
+
+	// Loop does real work besides polling — should NOT be flagged.
+	for i := 0; i < 3; i++ {
+		_ = i
+		time.Sleep(time.Millisecond)
+	}
+
+	// Counted loop — a fixed delay, not a poll. Should NOT be flagged.
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Sleep on a non-stdlib type — should NOT be flagged.
+	var c clock
+	for {
+		if ready() {
+			break
+		}
+		c.Sleep(time.Millisecond)
+	}
+
+	// Conditional return instead of break, with extra work — should NOT be flagged.
+	for {
+		if ready() {
+			return
+		}
+		_ = ctx.Err()
+		time.Sleep(time.Millisecond)
+	}
+}
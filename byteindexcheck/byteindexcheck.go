@@ -0,0 +1,244 @@
+// Package byteindexcheck defines an analyzer that detects strings copied to
+// byte slices only to be indexed byte by byte.
+//
+// # Analyzer byteindexcheck
+//
+// byteindexcheck: detect []byte(s) used only for indexing in a loop
+//
+// This analyzer flags a string converted to a byte slice and then indexed
+// in the C-style loop that follows:
+//
+//	b := []byte(s)
+//	for i := 0; i < len(b); i++ {
+//	    use(b[i])
+//	}
+//
+// Indexing a string yields its bytes too, so the conversion, which copies
+// the string, is unnecessary:
+//
+//	for i := 0; i < len(s); i++ {
+//	    use(s[i])
+//	}
+//
+// The slice may only be read with b[x] and len(b). Writing an element,
+// taking its address, slicing, ranging over it, or passing it anywhere
+// keeps the conversion, as does assigning to s after it. Ranging over s
+// instead would yield runes, not bytes, so diagnostics are report-only.
+package byteindexcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "byteindexcheck",
+	Doc:      "detect []byte(s) used only for indexing in a loop",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i+1 < len(list); i++ {
+			b, s, conv := conversion(pass, list[i])
+			if b == nil {
+				continue
+			}
+			loop, ok := list[i+1].(*ast.ForStmt)
+			if !ok || !boundedBy(pass, loop, b) {
+				continue
+			}
+			rest := list[i+1:]
+			if !onlyIndexed(pass, rest, b) || assigned(pass, rest, s) {
+				continue
+			}
+			pass.Reportf(conv.Pos(),
+				"%s is only indexed byte by byte; index the string %s directly to avoid copying it",
+				types.ExprString(conv), s.Name())
+		}
+	})
+
+	return nil, nil
+}
+
+// conversion matches b := []byte(s) or var b = []byte(s), where s is a
+// string variable, and returns the objects for b and s with the conversion.
+func conversion(pass *analysis.Pass, stmt ast.Stmt) (b, s types.Object, conv *ast.CallExpr) {
+	var name *ast.Ident
+	var value ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return nil, nil, nil
+		}
+		name, _ = stmt.Lhs[0].(*ast.Ident)
+		value = stmt.Rhs[0]
+	case *ast.DeclStmt:
+		decl, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.VAR || len(decl.Specs) != 1 {
+			return nil, nil, nil
+		}
+		spec := decl.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) != 1 || spec.Type != nil {
+			return nil, nil, nil
+		}
+		name, value = spec.Names[0], spec.Values[0]
+	default:
+		return nil, nil, nil
+	}
+	if name == nil {
+		return nil, nil, nil
+	}
+	b = pass.TypesInfo.Defs[name]
+	if b == nil {
+		return nil, nil, nil
+	}
+
+	conv, ok := ast.Unparen(value).(*ast.CallExpr)
+	if !ok || len(conv.Args) != 1 || !pass.TypesInfo.Types[conv.Fun].IsType() {
+		return nil, nil, nil
+	}
+	slice, ok := pass.TypesInfo.TypeOf(conv.Fun).(*types.Slice)
+	if !ok || !types.Identical(slice.Elem(), types.Typ[types.Byte]) {
+		return nil, nil, nil
+	}
+	arg, ok := ast.Unparen(conv.Args[0]).(*ast.Ident)
+	if !ok {
+		return nil, nil, nil
+	}
+	s, ok = pass.TypesInfo.Uses[arg].(*types.Var)
+	if !ok {
+		return nil, nil, nil
+	}
+	basic, ok := s.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsString == 0 {
+		return nil, nil, nil
+	}
+	return b, s, conv
+}
+
+// boundedBy reports whether loop runs while its index is below len(b).
+func boundedBy(pass *analysis.Pass, loop *ast.ForStmt, b types.Object) bool {
+	cond, ok := loop.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.LSS {
+		return false
+	}
+	call, ok := ast.Unparen(cond.Y).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isObj(pass, call.Args[0], b) {
+		return false
+	}
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "len" {
+		return false
+	}
+	_, builtin := pass.TypesInfo.Uses[fun].(*types.Builtin)
+	return builtin
+}
+
+// onlyIndexed reports whether every reference to b in stmts reads an
+// element, b[x], or its length, len(b), and at least one reads an element.
+func onlyIndexed(pass *analysis.Pass, stmts []ast.Stmt, b types.Object) bool {
+	allowed := map[*ast.Ident]bool{}
+	indexed, ok := false, true
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.IndexExpr:
+				if ident, isIdent := ast.Unparen(n.X).(*ast.Ident); isIdent && pass.TypesInfo.Uses[ident] == b {
+					allowed[ident] = true
+					indexed = true
+				}
+			case *ast.CallExpr:
+				if fun, isIdent := n.Fun.(*ast.Ident); isIdent && fun.Name == "len" && len(n.Args) == 1 {
+					if ident, isIdent := ast.Unparen(n.Args[0]).(*ast.Ident); isIdent {
+						if _, builtin := pass.TypesInfo.Uses[fun].(*types.Builtin); builtin {
+							allowed[ident] = true
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if isElem(pass, lhs, b) {
+						ok = false
+					}
+				}
+			case *ast.IncDecStmt:
+				if isElem(pass, n.X, b) {
+					ok = false
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND && isElem(pass, n.X, b) {
+					ok = false
+				}
+			case *ast.Ident:
+				if pass.TypesInfo.Uses[n] == b && !allowed[n] {
+					ok = false
+				}
+			}
+			return ok
+		})
+	}
+	return ok && indexed
+}
+
+// isElem reports whether expr is an element b[x] of b.
+func isElem(pass *analysis.Pass, expr ast.Expr, b types.Object) bool {
+	index, ok := ast.Unparen(expr).(*ast.IndexExpr)
+	return ok && isObj(pass, index.X, b)
+}
+
+// assigned reports whether stmts assign to s or take its address.
+func assigned(pass *analysis.Pass, stmts []ast.Stmt, s types.Object) bool {
+	found := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if isObj(pass, lhs, s) {
+						found = true
+					}
+				}
+			case *ast.UnaryExpr:
+				if n.Op == token.AND && isObj(pass, n.X, s) {
+					found = true
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	if expr == nil {
+		return false
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == obj
+}
@@ -0,0 +1,13 @@
+package byteindexcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/byteindexcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestByteIndexCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, byteindexcheck.Analyzer, "byteindextest")
+}
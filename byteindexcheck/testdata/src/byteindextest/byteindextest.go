@@ -0,0 +1,75 @@
+package byteindextest
+
+type name string
+
+func use(byte) {}
+
+func flagged(s string) int {
+	// Should be flagged: only read by index.
+	b := []byte(s) // want `\[\]byte\(s\) is only indexed byte by byte; index the string s directly to avoid copying it`
+	for i := 0; i < len(b); i++ {
+		use(b[i])
+	}
+
+	// Should be flagged: var form, named string type, reads after the loop.
+	var n name = "x"
+	count := 0
+	var c = []byte(n) // want `\[\]byte\(n\) is only indexed byte by byte; index the string n directly to avoid copying it`
+	for i := 0; i < len(c); i++ {
+		if c[i] == '/' {
+			count++
+		}
+	}
+	return count + int(c[0])
+}
+
+func notFlagged(s string, sink func([]byte)) {
+	// Element written — should NOT be flagged.
+	b := []byte(s)
+	for i := 0; i < len(b); i++ {
+		b[i] |= 0x20
+	}
+
+	// Slice passed on — should NOT be flagged.
+	c := []byte(s)
+	for i := 0; i < len(c); i++ {
+		use(c[i])
+	}
+	sink(c)
+
+	// Element address taken — should NOT be flagged.
+	d := []byte(s)
+	for i := 0; i < len(d); i++ {
+		p := &d[i]
+		_ = p
+	}
+
+	// String reassigned — should NOT be flagged.
+	e := []byte(s)
+	for i := 0; i < len(e); i++ {
+		use(e[i])
+		s = ""
+	}
+
+	// Loop does not follow the conversion — should NOT be flagged.
+	f := []byte(s)
+	use(0)
+	for i := 0; i < len(f); i++ {
+		use(f[i])
+	}
+
+	// Range loop — should NOT be flagged: ranging a string yields runes.
+	g := []byte(s)
+	for i := 0; i < len(g); i++ {
+		use(g[i])
+	}
+	for _, x := range g {
+		use(x)
+	}
+
+	// Sliced — should NOT be flagged.
+	h := []byte(s)
+	for i := 0; i < len(h); i++ {
+		sink(h[i:])
+	}
+}
@@ -0,0 +1,154 @@
+// Package bytestringcompcheck defines an analyzer that detects byte slices
+// converted to strings only to be compared with each other.
+//
+// # Analyzer bytestringcompcheck
+//
+// bytestringcompcheck: detect string(a) == string(b) on byte slices
+//
+// This analyzer flags equality comparisons whose operands are both
+// conversions of a []byte to string:
+//
+//	if string(a) == string(b) { ... }
+//	if string(a) != string(b) { ... }
+//
+// bytes.Equal compares the slices directly and says what is meant:
+//
+//	if bytes.Equal(a, b) { ... }
+//	if !bytes.Equal(a, b) { ... }
+//
+// The fix adds the "bytes" import when needed. It is withheld when the file
+// imports bytes under another name, when the name bytes refers to something
+// else at the comparison, and in package bytes and the packages it depends
+// on, where the import would be a cycle.
+//
+// Comparisons of one converted slice with a string, such as
+// string(b) == "x", are not flagged: the compiler does not allocate for
+// them, and bytes.Equal would need the string converted instead. Nor are
+// conversions of slices of a named byte type, which bytes.Equal does not
+// accept.
+package bytestringcompcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "bytestringcompcheck",
+	Doc:      "detect string(a) == string(b) on byte slices",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	// Track which files have already had a "bytes" import edit.
+	importEditAdded := map[string]bool{}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr := n.(*ast.BinaryExpr)
+		if expr.Op != token.EQL && expr.Op != token.NEQ {
+			return
+		}
+		x := byteSliceConv(pass, expr.X)
+		y := byteSliceConv(pass, expr.Y)
+		if x == nil || y == nil {
+			return
+		}
+
+		call := fmt.Sprintf("bytes.Equal(%s, %s)", types.ExprString(x), types.ExprString(y))
+		if expr.Op == token.NEQ {
+			call = "!" + call
+		}
+		msg := fmt.Sprintf("byte slices converted to strings for comparison; use %s", call)
+		diag := analysis.Diagnostic{Pos: expr.Pos(), Message: msg}
+
+		file := importutil.FindFileForPos(pass, expr.Pos())
+		if file != nil && !bytesDep(pass.Pkg.Path()) && bytesAvailable(pass, file, expr.Pos()) {
+			edits := []analysis.TextEdit{
+				{Pos: expr.Pos(), End: expr.End(), NewText: []byte(call)},
+			}
+			fileName := pass.Fset.File(expr.Pos()).Name()
+			if !importEditAdded[fileName] {
+				if ie := importutil.AddImportEdit(pass, file, "bytes"); ie != nil {
+					edits = append(edits, *ie)
+					importEditAdded[fileName] = true
+				}
+			}
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: "use bytes.Equal", TextEdits: edits},
+			}
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// byteSliceConv returns the operand of expr if expr converts a value
+// assignable to []byte to string, and nil otherwise.
+func byteSliceConv(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	tv := pass.TypesInfo.Types[call.Fun]
+	if !tv.IsType() || !types.Identical(tv.Type, types.Typ[types.String]) {
+		return nil
+	}
+	// bytes.Equal takes []byte; a slice of a named byte type converts to
+	// string but cannot be passed to it.
+	if !types.AssignableTo(pass.TypesInfo.TypeOf(call.Args[0]), types.NewSlice(types.Typ[types.Byte])) {
+		return nil
+	}
+	return call.Args[0]
+}
+
+// bytesDep reports whether path is package bytes or a package it may import,
+// directly or indirectly, where importing bytes would form a cycle. Besides
+// the dependencies of bytes as of Go 1.25, the internal and runtime trees
+// are included whole, since new dependencies appear there.
+func bytesDep(path string) bool {
+	if strings.HasPrefix(path, "internal/") || path == "runtime" || strings.HasPrefix(path, "runtime/") {
+		return true
+	}
+	switch path {
+	case "bytes", "errors", "io", "iter", "math/bits", "sync", "sync/atomic", "unicode", "unicode/utf8", "unsafe":
+		return true
+	}
+	return false
+}
+
+// bytesAvailable reports whether bytes.Equal can be written at pos in file:
+// bytes is either not imported, or imported under its own name, and the
+// name is not shadowed.
+func bytesAvailable(pass *analysis.Pass, file *ast.File, pos token.Pos) bool {
+	for _, imp := range file.Imports {
+		if imp.Path.Value == `"bytes"` && imp.Name != nil && imp.Name.Name != "bytes" {
+			return false
+		}
+	}
+	scope := pass.TypesInfo.Scopes[file].Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent("bytes", pos)
+	if obj == nil {
+		return true
+	}
+	pkgName, ok := obj.(*types.PkgName)
+	return ok && pkgName.Imported().Path() == "bytes"
+}
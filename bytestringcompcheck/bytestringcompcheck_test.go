@@ -0,0 +1,13 @@
+package bytestringcompcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/bytestringcompcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestByteStringCompCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, bytestringcompcheck.Analyzer, "bytestringcomptest", "internal/bytestringcompdep")
+}
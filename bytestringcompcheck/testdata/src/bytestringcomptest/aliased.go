@@ -0,0 +1,10 @@
+package bytestringcomptest
+
+import by "bytes"
+
+var _ = by.Equal
+
+// Should be flagged without a fix: bytes is imported as by.
+func aliased(a, b []byte) bool {
+	return string(a) == string(b) // want `byte slices converted to strings for comparison; use bytes\.Equal\(a, b\)`
+}
@@ -0,0 +1,54 @@
+package bytestringcomptest
+
+import "fmt"
+
+type raw []byte
+
+type octet byte
+
+func equal(a, b []byte) bool {
+	return string(a) == string(b) // want `byte slices converted to strings for comparison; use bytes\.Equal\(a, b\)`
+}
+
+func notEqual(a, b []byte) {
+	if string(a) != string(b) { // want `byte slices converted to strings for comparison; use !bytes\.Equal\(a, b\)`
+		fmt.Println("differ")
+	}
+}
+
+func expressions(m map[string][]byte, p *struct{ data []byte }) bool {
+	return string(m["k"]) == string(p.data[1:]) // want `byte slices converted to strings for comparison; use bytes\.Equal\(m\["k"\], p\.data\[1:\]\)`
+}
+
+func named(a raw, b []byte) bool {
+	return string(a) == string(b) // want `byte slices converted to strings for comparison; use bytes\.Equal\(a, b\)`
+}
+
+func notFlagged(a []byte, r []rune, o []octet, s string) bool {
+	// Comparison with a string — should NOT be flagged.
+	if string(a) == s || string(a) == "x" {
+		return true
+	}
+
+	// Rune slices — should NOT be flagged.
+	if string(r) == string(r[1:]) {
+		return true
+	}
+
+	// Named byte element type, not accepted by bytes.Equal — should NOT be
+	// flagged.
+	if string(o) == string(o[1:]) {
+		return true
+	}
+
+	// Ordering comparison — should NOT be flagged.
+	return string(a) < string(a[1:])
+}
+
+func shadowed(a, b []byte) bool {
+	bytes := 0
+	_ = bytes
+
+	// Should be flagged without a fix: bytes is a local variable here.
+	return string(a) == string(b) // want `byte slices converted to strings for comparison; use bytes\.Equal\(a, b\)`
+}
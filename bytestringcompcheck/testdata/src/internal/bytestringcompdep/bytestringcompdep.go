@@ -0,0 +1,8 @@
+// Package bytestringcompdep stands in for a package that bytes depends on,
+// such as internal/bytealg: importing bytes here would be a cycle, so the
+// comparison is reported without a fix.
+package bytestringcompdep
+
+func Equal(a, b []byte) bool {
+	return string(a) == string(b) // want `byte slices converted to strings for comparison; use bytes\.Equal\(a, b\)`
+}
@@ -0,0 +1,130 @@
+// Package chanlencheck defines an analyzer that detects control flow
+// decided by the length of a channel.
+//
+// # Analyzer chanlencheck
+//
+// chanlencheck: detect len(ch) comparisons in conditions
+//
+// This analyzer flags if, for, and switch conditions that compare the
+// length of a channel:
+//
+//	if len(ch) == cap(ch) { ... } // full?
+//	if len(ch) == 0 { ... }       // empty?
+//	for len(ch) > 0 { v := <-ch; ... }
+//
+// The length is stale as soon as it is read: another goroutine may send or
+// receive before the branch runs, so a channel found not full can block the
+// send that follows, and one found non-empty can block the receive. A
+// select with a default case makes the check and the operation one step:
+//
+//	select {
+//	case ch <- v:
+//	default:
+//	    // full
+//	}
+//
+// Comparisons of cap(ch) alone are not flagged, since the capacity of a
+// channel never changes, and neither are uses of len(ch) outside conditions,
+// such as metrics. Diagnostics are report-only.
+package chanlencheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "chanlencheck",
+	Doc:      "detect len(ch) comparisons in conditions",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+		(*ast.ForStmt)(nil),
+		(*ast.SwitchStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var conds []ast.Expr
+		switch n := n.(type) {
+		case *ast.IfStmt:
+			conds = append(conds, n.Cond)
+		case *ast.ForStmt:
+			conds = append(conds, n.Cond)
+		case *ast.SwitchStmt:
+			if n.Tag != nil {
+				return
+			}
+			for _, stmt := range n.Body.List {
+				conds = append(conds, stmt.(*ast.CaseClause).List...)
+			}
+		}
+
+		for _, cond := range conds {
+			if cond == nil {
+				continue
+			}
+			ast.Inspect(cond, func(n ast.Node) bool {
+				if _, ok := n.(*ast.FuncLit); ok {
+					return false
+				}
+				expr, ok := n.(*ast.BinaryExpr)
+				if !ok || !isComparison(expr.Op) {
+					return true
+				}
+				for _, operand := range []ast.Expr{expr.X, expr.Y} {
+					if ch := chanLen(pass, operand); ch != nil {
+						pass.Reportf(expr.Pos(),
+							"len(%s) may change before the branch runs; use select with a default case to send or receive without blocking",
+							types.ExprString(ch))
+						return false
+					}
+				}
+				return true
+			})
+		}
+	})
+
+	return nil, nil
+}
+
+func isComparison(op token.Token) bool {
+	switch op {
+	case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		return true
+	}
+	return false
+}
+
+// chanLen returns ch if expr is a call of the builtin len on a channel.
+func chanLen(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	fun, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || fun.Name != "len" {
+		return nil
+	}
+	if _, ok := pass.TypesInfo.Uses[fun].(*types.Builtin); !ok {
+		return nil
+	}
+	t := pass.TypesInfo.TypeOf(call.Args[0])
+	if t == nil {
+		return nil
+	}
+	if _, ok := t.Underlying().(*types.Chan); !ok {
+		return nil
+	}
+	return call.Args[0]
+}
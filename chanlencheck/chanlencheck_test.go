@@ -0,0 +1,13 @@
+package chanlencheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/chanlencheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestChanLenCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, chanlencheck.Analyzer, "chanlentest")
+}
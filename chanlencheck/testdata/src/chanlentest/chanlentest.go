@@ -0,0 +1,47 @@
+package chanlentest
+
+import "log"
+
+type jobs chan int
+
+func full(ch chan int, v int) {
+	if len(ch) == cap(ch) { // want `len\(ch\) may change before the branch runs; use select with a default case to send or receive without blocking`
+		return
+	}
+	ch <- v
+}
+
+func drain(ch <-chan int, q jobs) (sum int) {
+	if len(q) != 0 && sum == 0 { // want `len\(q\) may change before the branch runs`
+		sum = <-q
+	}
+	for len(ch) > 0 { // want `len\(ch\) may change before the branch runs`
+		sum += <-ch
+	}
+	switch {
+	case 0 < len(ch): // want `len\(ch\) may change before the branch runs`
+		sum++
+	}
+	return sum
+}
+
+func notFlagged(ch chan int, s []int) {
+	// Slice length — should NOT be flagged.
+	if len(s) == cap(s) {
+		return
+	}
+
+	// Capacity alone never changes — should NOT be flagged.
+	if cap(ch) == 0 {
+		return
+	}
+
+	// Outside a condition — should NOT be flagged.
+	log.Printf("queue depth %d", len(ch))
+
+	// Non-blocking send — should NOT be flagged.
+	select {
+	case ch <- 1:
+	default:
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/albertocavalcante/go-analyzers/internal/severity"
+)
+
+// jsonDiagnostic is a diagnostic in the layout of go vet -json, with the
+// severity of its analyzer added.
+type jsonDiagnostic struct {
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// writeJSON writes findings to w as a tree keyed by package path and then
+// analyzer name, as go vet -json does. Each diagnostic carries its
+// analyzer's severity.
+func writeJSON(w io.Writer, findings []finding) error {
+	tree := make(map[string]map[string][]jsonDiagnostic)
+	for _, f := range findings {
+		byAnalyzer := tree[f.pkgPath]
+		if byAnalyzer == nil {
+			byAnalyzer = make(map[string][]jsonDiagnostic)
+			tree[f.pkgPath] = byAnalyzer
+		}
+		byAnalyzer[f.Analyzer] = append(byAnalyzer[f.Analyzer], jsonDiagnostic{
+			Posn:     f.Position.String(),
+			Message:  f.Message,
+			Severity: severity.Of(f.Analyzer).String(),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(tree)
+}
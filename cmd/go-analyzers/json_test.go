@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/albertocavalcante/go-analyzers/deferloopcheck"
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"github.com/albertocavalcante/go-analyzers/sortmigrate"
+)
+
+func TestJSON(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/mixed\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "mixed.go"), mixedSrc)
+	t.Chdir(dir)
+
+	analyzers := []*analysis.Analyzer{deferloopcheck.Analyzer, redundantbreakcheck.Analyzer, sortmigrate.Analyzer}
+
+	var stdout, stderr bytes.Buffer
+	if code := runDirect(&stdout, &stderr, analyzers, []string{"-json", "./..."}); code != 0 {
+		t.Fatalf("exit code %d, want 0, stderr:\n%s", code, stderr.String())
+	}
+
+	var tree map[string]map[string][]jsonDiagnostic
+	if err := json.Unmarshal(stdout.Bytes(), &tree); err != nil {
+		t.Fatalf("decoding JSON: %v\n%s", err, stdout.String())
+	}
+	byAnalyzer, ok := tree["example.com/mixed"]
+	if !ok || len(tree) != 1 {
+		t.Fatalf("got packages %v, want only example.com/mixed", tree)
+	}
+
+	want := map[string]string{
+		"deferloopcheck":      "warning",
+		"sortmigrate":         "info",
+		"redundantbreakcheck": "hint",
+	}
+	for name, level := range want {
+		diags := byAnalyzer[name]
+		if len(diags) != 1 {
+			t.Errorf("%s: got %d diagnostics, want 1", name, len(diags))
+			continue
+		}
+		if diags[0].Severity != level {
+			t.Errorf("%s: severity %q, want %q", name, diags[0].Severity, level)
+		}
+		if !strings.Contains(diags[0].Posn, "mixed.go:") {
+			t.Errorf("%s: position %q, want a line in mixed.go", name, diags[0].Posn)
+		}
+	}
+}
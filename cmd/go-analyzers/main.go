@@ -3,22 +3,171 @@
 // Usage:
 //
 //	go vet -vettool=$(which go-analyzers) ./...
+//
+// To adopt the analyzers on an existing codebase without fixing every
+// pre-existing finding first, record a baseline once and then report only
+// findings that are not in it:
+//
+//	go-analyzers -baseline=baseline.json -write-baseline ./...
+//	go-analyzers -baseline=baseline.json ./...
+//
+// To feed findings to code scanning tools, write them as SARIF, optionally
+// combined with -baseline:
+//
+//	go-analyzers -sarif ./... > results.sarif
+//
+// The -json output lists each finding with the severity of its analyzer:
+//
+//	go-analyzers -json ./...
+//
+// To review suggested fixes before applying them, write them as a patch:
+//
+//	go-analyzers -patch ./... > fixes.patch
+//	git apply fixes.patch
 package main
 
 import (
+	"os"
+
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/multichecker"
 
+	"github.com/albertocavalcante/go-analyzers/appendmergecheck"
+	"github.com/albertocavalcante/go-analyzers/boolassigncheck"
+	"github.com/albertocavalcante/go-analyzers/busywaitcheck"
+	"github.com/albertocavalcante/go-analyzers/byteindexcheck"
+	"github.com/albertocavalcante/go-analyzers/bytestringcompcheck"
+	"github.com/albertocavalcante/go-analyzers/chanlencheck"
 	"github.com/albertocavalcante/go-analyzers/clampcheck"
+	"github.com/albertocavalcante/go-analyzers/compactcheck"
+	"github.com/albertocavalcante/go-analyzers/comparatorhint"
+	"github.com/albertocavalcante/go-analyzers/constraintcheck"
+	"github.com/albertocavalcante/go-analyzers/countcheck"
+	"github.com/albertocavalcante/go-analyzers/ctxkeycheck"
+	"github.com/albertocavalcante/go-analyzers/deferloopcheck"
+	"github.com/albertocavalcante/go-analyzers/doublelookupcheck"
+	"github.com/albertocavalcante/go-analyzers/durationmethodcheck"
+	"github.com/albertocavalcante/go-analyzers/errgroupcheck"
+	"github.com/albertocavalcante/go-analyzers/errjoincheck"
+	"github.com/albertocavalcante/go-analyzers/errorfwrapcheck"
+	"github.com/albertocavalcante/go-analyzers/errorsascheck"
+	"github.com/albertocavalcante/go-analyzers/errstringcheck"
+	"github.com/albertocavalcante/go-analyzers/fprintfcheck"
+	"github.com/albertocavalcante/go-analyzers/fullslicecheck"
+	"github.com/albertocavalcante/go-analyzers/growcheck"
+	"github.com/albertocavalcante/go-analyzers/guardinvertcheck"
+	"github.com/albertocavalcante/go-analyzers/ignorederrcheck"
+	"github.com/albertocavalcante/go-analyzers/jsoncopycheck"
+	"github.com/albertocavalcante/go-analyzers/lockcopycheck"
+	"github.com/albertocavalcante/go-analyzers/logsprintfcheck"
 	"github.com/albertocavalcante/go-analyzers/makecopy"
+	"github.com/albertocavalcante/go-analyzers/mapkeyscancheck"
+	"github.com/albertocavalcante/go-analyzers/mapliteralcheck"
+	"github.com/albertocavalcante/go-analyzers/mapordercheck"
+	"github.com/albertocavalcante/go-analyzers/minmaxcheck"
+	"github.com/albertocavalcante/go-analyzers/nilmapwritecheck"
+	"github.com/albertocavalcante/go-analyzers/nilsliceinitcheck"
+	"github.com/albertocavalcante/go-analyzers/niltruecheck"
+	"github.com/albertocavalcante/go-analyzers/noopdefercheck"
+	"github.com/albertocavalcante/go-analyzers/panicstringcheck"
+	"github.com/albertocavalcante/go-analyzers/parencheck"
+	"github.com/albertocavalcante/go-analyzers/prealloccheck"
+	"github.com/albertocavalcante/go-analyzers/printlnsprintfcheck"
+	"github.com/albertocavalcante/go-analyzers/rangeblankcheck"
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"github.com/albertocavalcante/go-analyzers/redundantcontinuecheck"
+	"github.com/albertocavalcante/go-analyzers/redundantconvcheck"
+	"github.com/albertocavalcante/go-analyzers/redundantzerocheck"
+	"github.com/albertocavalcante/go-analyzers/regexpcompilecheck"
+	"github.com/albertocavalcante/go-analyzers/repeatsearchcheck"
+	"github.com/albertocavalcante/go-analyzers/respbodycheck"
+	"github.com/albertocavalcante/go-analyzers/runecountcheck"
+	"github.com/albertocavalcante/go-analyzers/scannererrcheck"
 	"github.com/albertocavalcante/go-analyzers/searchmigrate"
+	"github.com/albertocavalcante/go-analyzers/shiftoverflowcheck"
+	"github.com/albertocavalcante/go-analyzers/singleselectcheck"
 	"github.com/albertocavalcante/go-analyzers/sortmigrate"
+	"github.com/albertocavalcante/go-analyzers/splitindexcheck"
+	"github.com/albertocavalcante/go-analyzers/synconcecheck"
+	"github.com/albertocavalcante/go-analyzers/tickcheck"
+	"github.com/albertocavalcante/go-analyzers/tickerstopcheck"
+	"github.com/albertocavalcante/go-analyzers/timelayoutcheck"
+	"github.com/albertocavalcante/go-analyzers/truncatecheck"
+	"github.com/albertocavalcante/go-analyzers/uselessclonecheck"
+	"github.com/albertocavalcante/go-analyzers/valrecvappendcheck"
+	"github.com/albertocavalcante/go-analyzers/valuesrangecheck"
 )
 
+var analyzers = []*analysis.Analyzer{
+	makecopy.Analyzer,
+	searchmigrate.Analyzer,
+	clampcheck.Analyzer,
+	sortmigrate.Analyzer,
+	fullslicecheck.Analyzer,
+	deferloopcheck.Analyzer,
+	redundantconvcheck.Analyzer,
+	singleselectcheck.Analyzer,
+	busywaitcheck.Analyzer,
+	panicstringcheck.Analyzer,
+	redundantbreakcheck.Analyzer,
+	compactcheck.Analyzer,
+	errorsascheck.Analyzer,
+	respbodycheck.Analyzer,
+	parencheck.Analyzer,
+	boolassigncheck.Analyzer,
+	comparatorhint.Analyzer,
+	mapkeyscancheck.Analyzer,
+	redundantcontinuecheck.Analyzer,
+	minmaxcheck.Analyzer,
+	regexpcompilecheck.Analyzer,
+	logsprintfcheck.Analyzer,
+	valuesrangecheck.Analyzer,
+	redundantzerocheck.Analyzer,
+	shiftoverflowcheck.Analyzer,
+	errorfwrapcheck.Analyzer,
+	tickcheck.Analyzer,
+	growcheck.Analyzer,
+	lockcopycheck.Analyzer,
+	ignorederrcheck.Analyzer,
+	tickerstopcheck.Analyzer,
+	valrecvappendcheck.Analyzer,
+	niltruecheck.Analyzer,
+	mapliteralcheck.Analyzer,
+	fprintfcheck.Analyzer,
+	noopdefercheck.Analyzer,
+	rangeblankcheck.Analyzer,
+	guardinvertcheck.Analyzer,
+	appendmergecheck.Analyzer,
+	nilsliceinitcheck.Analyzer,
+	doublelookupcheck.Analyzer,
+	timelayoutcheck.Analyzer,
+	countcheck.Analyzer,
+	nilmapwritecheck.Analyzer,
+	printlnsprintfcheck.Analyzer,
+	truncatecheck.Analyzer,
+	repeatsearchcheck.Analyzer,
+	constraintcheck.Analyzer,
+	bytestringcompcheck.Analyzer,
+	byteindexcheck.Analyzer,
+	synconcecheck.Analyzer,
+	uselessclonecheck.Analyzer,
+	errstringcheck.Analyzer,
+	errgroupcheck.Analyzer,
+	chanlencheck.Analyzer,
+	runecountcheck.Analyzer,
+	prealloccheck.Analyzer,
+	durationmethodcheck.Analyzer,
+	mapordercheck.Analyzer,
+	ctxkeycheck.Analyzer,
+	jsoncopycheck.Analyzer,
+	errjoincheck.Analyzer,
+	splitindexcheck.Analyzer,
+	scannererrcheck.Analyzer,
+}
+
 func main() {
-	multichecker.Main(
-		makecopy.Analyzer,
-		searchmigrate.Analyzer,
-		clampcheck.Analyzer,
-		sortmigrate.Analyzer,
-	)
+	if directRequested(os.Args[1:]) {
+		os.Exit(runDirect(os.Stdout, os.Stderr, analyzers, os.Args[1:]))
+	}
+	multichecker.Main(analyzers...)
 }
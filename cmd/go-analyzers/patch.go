@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change,
+// as in diff -u.
+const contextLines = 3
+
+// writePatch writes the suggested fixes of findings to w as a unified diff
+// that git apply accepts. Fixes are taken in order; a fix with an edit that
+// overlaps an edit already taken is skipped whole, as go vet -fix does.
+// Edits repeated by several fixes, such as the same import, are applied once.
+func writePatch(w io.Writer, findings []finding) error {
+	byFile := make(map[string][]edit)
+	for _, f := range findings {
+		if len(f.fix) == 0 || conflicts(byFile, f.fix) {
+			continue
+		}
+		for _, e := range f.fix {
+			if !contains(byFile[e.file], e) {
+				byFile[e.file] = append(byFile[e.file], e)
+			}
+		}
+	}
+
+	files := make([]string, 0, len(byFile))
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	wd, _ := os.Getwd()
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		name := file
+		if rel, err := filepath.Rel(wd, file); err == nil {
+			name = rel
+		}
+		name = filepath.ToSlash(name)
+
+		edits := byFile[file]
+		sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+		if _, err := io.WriteString(w, unifiedDiff(name, src, edits)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conflicts reports whether any edit in fix overlaps an edit in byFile
+// without being identical to it. Insertions at the same offset conflict too,
+// since their order would be arbitrary.
+func conflicts(byFile map[string][]edit, fix []edit) bool {
+	for _, e := range fix {
+		for _, prev := range byFile[e.file] {
+			if e == prev {
+				continue
+			}
+			if e.start < prev.end && prev.start < e.end || e.start == prev.start {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contains reports whether edits holds e.
+func contains(edits []edit, e edit) bool {
+	for _, prev := range edits {
+		if prev == e {
+			return true
+		}
+	}
+	return false
+}
+
+// change replaces the old lines [oldStart, oldEnd) with newLines.
+type change struct {
+	oldStart, oldEnd int
+	newLines         []string
+}
+
+// unifiedDiff returns the diff between src and src with edits applied, with
+// a/ and b/ prefixes on name. edits must be sorted and must not overlap.
+func unifiedDiff(name string, src []byte, edits []edit) string {
+	oldLines := splitLines(string(src))
+	lineStarts := make([]int, len(oldLines)+1)
+	for i, line := range oldLines {
+		lineStarts[i+1] = lineStarts[i] + len(line)
+	}
+	// lineOf returns the index of the line holding offset; an offset at the
+	// end of a file ending in a newline is on the empty line after it.
+	lineOf := func(offset int) int {
+		return sort.Search(len(oldLines), func(i int) bool { return lineStarts[i+1] > offset })
+	}
+
+	// Widen each edit to the whole lines it touches, merging edits that
+	// share a line, and compute the new text of those lines.
+	var changes []change
+	for i := 0; i < len(edits); {
+		first := lineOf(edits[i].start)
+		last := first
+		if edits[i].end > edits[i].start {
+			last = max(last, lineOf(edits[i].end-1))
+		}
+		j := i + 1
+		for j < len(edits) && lineOf(edits[j].start) <= last {
+			if edits[j].end > edits[j].start {
+				last = max(last, lineOf(edits[j].end-1))
+			}
+			j++
+		}
+		end := min(last+1, len(oldLines))
+
+		var buf strings.Builder
+		pos := lineStarts[first]
+		for _, e := range edits[i:j] {
+			buf.Write(src[pos:e.start])
+			buf.WriteString(e.text)
+			pos = e.end
+		}
+		buf.Write(src[pos:lineStarts[end]])
+		changes = append(changes, change{first, end, splitLines(buf.String())})
+		i = j
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", name, name, name, name)
+
+	// delta is the difference between new and old line numbers before the
+	// current hunk.
+	delta := 0
+	for i := 0; i < len(changes); {
+		// Changes closer than twice the context share a hunk.
+		j := i + 1
+		for j < len(changes) && changes[j].oldStart-changes[j-1].oldEnd <= 2*contextLines {
+			j++
+		}
+		start := max(changes[i].oldStart-contextLines, 0)
+		end := min(changes[j-1].oldEnd+contextLines, len(oldLines))
+
+		var body strings.Builder
+		oldCount, newCount := 0, 0
+		pos := start
+		for _, c := range changes[i:j] {
+			for ; pos < c.oldStart; pos++ {
+				writeLine(&body, ' ', oldLines[pos])
+				oldCount++
+				newCount++
+			}
+			for ; pos < c.oldEnd; pos++ {
+				writeLine(&body, '-', oldLines[pos])
+				oldCount++
+			}
+			for _, line := range c.newLines {
+				writeLine(&body, '+', line)
+				newCount++
+			}
+		}
+		for ; pos < end; pos++ {
+			writeLine(&body, ' ', oldLines[pos])
+			oldCount++
+			newCount++
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(start, oldCount), hunkRange(start+delta, newCount))
+		out.WriteString(body.String())
+		for _, c := range changes[i:j] {
+			delta += len(c.newLines) - (c.oldEnd - c.oldStart)
+		}
+		i = j
+	}
+	return out.String()
+}
+
+// hunkRange formats the start and length of a hunk side. start is the
+// 0-based index of its first line; an empty side names the line before it.
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// writeLine writes line to b with the given prefix, marking a missing final
+// newline the way diff does.
+func writeLine(b *strings.Builder, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
+
+// splitLines splits s after each newline. The last line lacks a newline only
+// if s does not end in one.
+func splitLines(s string) []string {
+	var lines []string
+	for s != "" {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			lines = append(lines, s)
+			break
+		}
+		lines = append(lines, s[:i+1])
+		s = s[i+1:]
+	}
+	return lines
+}
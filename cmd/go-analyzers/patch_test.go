@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/albertocavalcante/go-analyzers/makecopy"
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"github.com/albertocavalcante/go-analyzers/sortmigrate"
+)
+
+const otherSrc = `package mixed
+
+import "sort"
+
+func Other(ids []int) {
+	sort.Ints(ids)
+}
+`
+
+func TestPatch(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/mixed\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "mixed.go"), mixedSrc)
+	writeFile(t, filepath.Join(dir, "other.go"), otherSrc)
+	t.Chdir(dir)
+
+	analyzers := []*analysis.Analyzer{redundantbreakcheck.Analyzer, sortmigrate.Analyzer}
+
+	var stdout, stderr bytes.Buffer
+	if code := runDirect(&stdout, &stderr, analyzers, []string{"-patch", "./..."}); code != 3 {
+		t.Fatalf("exit code %d, want 3, stderr:\n%s", code, stderr.String())
+	}
+	patch := stdout.String()
+	for _, header := range []string{"--- a/mixed.go\n+++ b/mixed.go\n", "--- a/other.go\n+++ b/other.go\n"} {
+		if !strings.Contains(patch, header) {
+			t.Errorf("patch lacks %q:\n%s", header, patch)
+		}
+	}
+	if !strings.Contains(stderr.String(), "can be replaced with slices.Sort") {
+		t.Errorf("findings not printed to stderr:\n%s", stderr.String())
+	}
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	writeFile(t, filepath.Join(dir, "fixes.patch"), patch)
+	for _, args := range [][]string{{"init", "-q"}, {"apply", "fixes.patch"}} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s\npatch:\n%s", args[0], err, out, patch)
+		}
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "other.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package mixed\n\nimport (\n\t\"slices\"\n\t\"sort\"\n)\n\nfunc Other(ids []int) {\n\tslices.Sort(ids)\n}\n"
+	if string(got) != want {
+		t.Errorf("other.go after git apply:\n%s\nwant:\n%s", got, want)
+	}
+	got, err = os.ReadFile(filepath.Join(dir, "mixed.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{"sort.Strings", "break"} {
+		if bytes.Contains(got, []byte(s)) {
+			t.Errorf("mixed.go after git apply still contains %s:\n%s", s, got)
+		}
+	}
+}
+
+// Both files need "slices" from sortmigrate and from makecopy. In one.go
+// the two import edits are identical and are applied once. In two.go
+// sortmigrate also adds "cmp", so the edits differ and the later fix is
+// skipped; running again picks it up against the updated imports. Each
+// file keeps a use of sort, since the fixes do not remove its import.
+const (
+	oneSrc = `package shared
+
+import "sort"
+
+var search = sort.SearchInts
+
+func One(a []int, names []string) []int {
+	out := make([]int, len(a))
+	copy(out, a)
+	sort.Strings(names)
+	return out
+}
+`
+	twoSrc = `package shared
+
+import "sort"
+
+var find = sort.SearchStrings
+
+type Item struct{ Age int }
+
+func Two(a []int, items []Item) []int {
+	sort.Slice(items, func(i, j int) bool { return items[i].Age > items[j].Age })
+	out := make([]int, len(a))
+	copy(out, a)
+	return out
+}
+`
+)
+
+func TestPatchSharedImports(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/shared\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "one.go"), oneSrc)
+	writeFile(t, filepath.Join(dir, "two.go"), twoSrc)
+	t.Chdir(dir)
+	if out, err := exec.Command("git", "init", "-q").CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+
+	analyzers := []*analysis.Analyzer{makecopy.Analyzer, sortmigrate.Analyzer}
+	rounds := 0
+	for ; rounds < 3; rounds++ {
+		var stdout, stderr bytes.Buffer
+		code := runDirect(&stdout, &stderr, analyzers, []string{"-patch", "./..."})
+		if code == 0 {
+			break
+		}
+		if code != 3 {
+			t.Fatalf("round %d: exit code %d, stderr:\n%s", rounds+1, code, stderr.String())
+		}
+		writeFile(t, filepath.Join(dir, "fixes.patch"), stdout.String())
+		if out, err := exec.Command("git", "apply", "fixes.patch").CombinedOutput(); err != nil {
+			t.Fatalf("round %d: git apply: %v\n%s\npatch:\n%s", rounds+1, err, out, stdout.String())
+		}
+	}
+	if rounds != 2 {
+		t.Errorf("fixes took %d rounds, want 2", rounds)
+	}
+
+	for _, name := range []string{"one.go", "two.go"} {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := strings.Count(string(got), `"slices"`); n != 1 {
+			t.Errorf("%s imports slices %d times, want 1:\n%s", name, n, got)
+		}
+		if !bytes.Contains(got, []byte("slices.Clone(a)")) {
+			t.Errorf("%s lacks the makecopy fix:\n%s", name, got)
+		}
+	}
+	if out, err := exec.Command("go", "vet", "./...").CombinedOutput(); err != nil {
+		t.Errorf("fixed module does not vet: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/albertocavalcante/go-analyzers/internal/baseline"
+)
+
+// directRequested reports whether args ask for a mode that multichecker
+// does not provide, which is handled by runDirect instead. -json is handled
+// directly, to add severities, except when go vet passes it along with the
+// .cfg file of a unit check.
+func directRequested(args []string) bool {
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name := strings.TrimLeft(arg, "-")
+		if name == arg {
+			continue
+		}
+		name, _, _ = strings.Cut(name, "=")
+		switch name {
+		case "baseline", "write-baseline", "sarif", "patch":
+			return true
+		case "json":
+			return len(args) == 0 || !strings.HasSuffix(args[len(args)-1], ".cfg")
+		}
+	}
+	return false
+}
+
+// runDirect loads the packages named by args and runs analyzers over them
+// without going through go vet. With -write-baseline it records every
+// finding in the -baseline file; otherwise it reports the findings missing
+// from the -baseline file, if any, as text on stderr or, with -sarif or
+// -json, as a SARIF log or a JSON tree on stdout. With -patch, the suggested fixes of the reported
+// findings are written to stdout as a patch for git apply, and the findings
+// are still listed on stderr. It returns the process exit code: 1 on error,
+// 3 when findings were reported other than as JSON, 0 otherwise.
+func runDirect(stdout, stderr io.Writer, analyzers []*analysis.Analyzer, args []string) int {
+	fs := flag.NewFlagSet("go-analyzers", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	path := fs.String("baseline", "", "baseline `file` of findings to suppress")
+	write := fs.Bool("write-baseline", false, "record all current findings in the -baseline file instead of reporting them")
+	sarif := fs.Bool("sarif", false, "report findings as a SARIF log on stdout")
+	jsonOut := fs.Bool("json", false, "report findings as JSON, with their severity, on stdout")
+	patch := fs.Bool("patch", false, "write suggested fixes to stdout as a patch for git apply instead of changing files")
+	for _, a := range analyzers {
+		a.Flags.VisitAll(func(f *flag.Flag) {
+			fs.Var(f.Value, a.Name+"."+f.Name, f.Usage)
+		})
+	}
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *write && *path == "" {
+		fmt.Fprintln(stderr, "go-analyzers: -write-baseline requires -baseline")
+		return 1
+	}
+	if n := countTrue(*sarif, *jsonOut, *patch); n > 1 {
+		fmt.Fprintln(stderr, "go-analyzers: only one of -sarif, -json, and -patch can write to stdout")
+		return 1
+	}
+
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	findings, err := analyze(analyzers, patterns)
+	if err != nil {
+		fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+		return 1
+	}
+
+	if *write {
+		if err := baseline.Write(*path, plain(findings)); err != nil {
+			fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if *path != "" {
+		known, err := baseline.Read(*path)
+		if err != nil {
+			fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+			return 1
+		}
+		findings = keep(findings, baseline.Filter(known, plain(findings)))
+	}
+
+	switch {
+	case *sarif:
+		if err := writeSARIF(stdout, analyzers, plain(findings)); err != nil {
+			fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+			return 1
+		}
+	case *jsonOut:
+		if err := writeJSON(stdout, findings); err != nil {
+			fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+			return 1
+		}
+	default:
+		if *patch {
+			if err := writePatch(stdout, findings); err != nil {
+				fmt.Fprintf(stderr, "go-analyzers: %v\n", err)
+				return 1
+			}
+		}
+		for _, f := range findings {
+			fmt.Fprintf(stderr, "%s: %s\n", f.Position, f.Message)
+		}
+	}
+	// As with the -json of multichecker, findings written as JSON are not
+	// a failure.
+	if len(findings) == 0 || *jsonOut {
+		return 0
+	}
+	return 3
+}
+
+// countTrue returns the number of true values in bs.
+func countTrue(bs ...bool) int {
+	n := 0
+	for _, b := range bs {
+		if b {
+			n++
+		}
+	}
+	return n
+}
+
+// finding is a baseline.Finding together with the path of its package and
+// the edits of its first suggested fix, if any.
+type finding struct {
+	baseline.Finding
+	pkgPath string
+	fix     []edit
+}
+
+// edit replaces the bytes [start, end) of file with text.
+type edit struct {
+	file       string
+	start, end int
+	text       string
+}
+
+// plain returns the baseline findings of findings.
+func plain(findings []finding) []baseline.Finding {
+	out := make([]baseline.Finding, len(findings))
+	for i, f := range findings {
+		out[i] = f.Finding
+	}
+	return out
+}
+
+// keep returns the findings whose baseline findings are in subset, which
+// must be a subsequence of plain(findings) as returned by baseline.Filter.
+func keep(findings []finding, subset []baseline.Finding) []finding {
+	var out []finding
+	for _, f := range findings {
+		if len(subset) > 0 && f.Finding == subset[0] {
+			out = append(out, f)
+			subset = subset[1:]
+		}
+	}
+	return out
+}
+
+// analyze runs analyzers over the packages matching patterns and returns
+// their findings ordered by position.
+func analyze(analyzers []*analysis.Analyzer, patterns []string) ([]finding, error) {
+	cfg := &packages.Config{Mode: packages.LoadAllSyntax, Tests: true}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	if n := packages.PrintErrors(pkgs); n > 0 {
+		return nil, fmt.Errorf("%d errors loading packages", n)
+	}
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	wd, _ := os.Getwd()
+	// Test variants of a package repeat its files; keep one copy of each
+	// diagnostic.
+	type seenKey struct {
+		analyzer, message string
+		posn              token.Position
+	}
+	seen := make(map[seenKey]bool)
+	var findings []finding
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			return nil, fmt.Errorf("%s: %v", act, act.Err)
+		}
+		for _, d := range act.Diagnostics {
+			posn := act.Package.Fset.Position(d.Pos)
+			key := seenKey{act.Analyzer.Name, d.Message, posn}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			file := syntaxFor(act.Package, posn.Filename)
+			if file == nil {
+				return nil, fmt.Errorf("%s: no syntax for %s", act, posn.Filename)
+			}
+			src, err := os.ReadFile(posn.Filename)
+			if err != nil {
+				return nil, err
+			}
+			name := posn.Filename
+			if rel, err := filepath.Rel(wd, name); err == nil {
+				name = filepath.ToSlash(rel)
+			}
+			f := finding{Finding: baseline.Finding{
+				File:        name,
+				Analyzer:    act.Analyzer.Name,
+				Fingerprint: baseline.Fingerprint(act.Analyzer.Name, d.Message, act.Package.Fset, file, src, d.Pos),
+				Message:     d.Message,
+				Position:    posn,
+			}, pkgPath: act.Package.PkgPath}
+			if len(d.SuggestedFixes) > 0 {
+				for _, te := range d.SuggestedFixes[0].TextEdits {
+					tf := act.Package.Fset.File(te.Pos)
+					end := te.End
+					if !end.IsValid() {
+						end = te.Pos
+					}
+					f.fix = append(f.fix, edit{
+						file:  tf.Name(),
+						start: tf.Offset(te.Pos),
+						end:   tf.Offset(end),
+						text:  string(te.NewText),
+					})
+				}
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		a, b := findings[i].Position, findings[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Offset != b.Offset {
+			return a.Offset < b.Offset
+		}
+		return findings[i].Analyzer < findings[j].Analyzer
+	})
+	return findings, nil
+}
+
+// syntaxFor returns the parsed file named filename in pkg.
+func syntaxFor(pkg *packages.Package, filename string) *ast.File {
+	for _, f := range pkg.Syntax {
+		if pkg.Fset.File(f.Pos()).Name() == filename {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"github.com/albertocavalcante/go-analyzers/searchmigrate"
+	"github.com/albertocavalcante/go-analyzers/sortmigrate"
+)
+
+const legacySrc = `package legacy
+
+func Legacy(x int) {
+	switch x {
+	case 1:
+		break
+	}
+}
+`
+
+// Lines are inserted above Legacy so its finding moves, and NewCode adds a
+// finding that is not in the baseline.
+const changedSrc = `package legacy
+
+// Legacy predates the analyzers.
+//
+// Its finding is in the baseline.
+func Legacy(x int) {
+	switch x {
+	case 1:
+		break
+	}
+}
+
+func NewCode(x int) {
+	switch x {
+	case 2:
+		break
+	}
+}
+`
+
+func TestBaseline(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/legacy\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "legacy.go"), legacySrc)
+	t.Chdir(dir)
+
+	analyzers := []*analysis.Analyzer{redundantbreakcheck.Analyzer}
+
+	var stderr bytes.Buffer
+	if code := runDirect(io.Discard, &stderr, analyzers, []string{"-baseline=baseline.json", "-write-baseline", "./..."}); code != 0 {
+		t.Fatalf("writing baseline: exit code %d, stderr:\n%s", code, stderr.String())
+	}
+
+	// Unchanged code: everything is in the baseline.
+	stderr.Reset()
+	if code := runDirect(io.Discard, &stderr, analyzers, []string{"-baseline=baseline.json", "./..."}); code != 0 {
+		t.Fatalf("unchanged code: exit code %d, stderr:\n%s", code, stderr.String())
+	}
+
+	writeFile(t, filepath.Join(dir, "legacy.go"), changedSrc)
+	stderr.Reset()
+	if code := runDirect(io.Discard, &stderr, analyzers, []string{"-baseline=baseline.json", "./..."}); code != 3 {
+		t.Fatalf("changed code: exit code %d, want 3, stderr:\n%s", code, stderr.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	if len(lines) != 1 || !strings.Contains(lines[0], "legacy.go:16:3: redundant break") {
+		t.Errorf("changed code: want only the new finding at legacy.go:16:3, got:\n%s", stderr.String())
+	}
+}
+
+// sortSearchSrc sorts and searches the same slice by a field, so the
+// callbacks of both calls index it the same way.
+const sortSearchSrc = `package owners
+
+import "sort"
+
+type entry struct {
+	key  int
+	name string
+}
+
+func Find(entries []entry, key int) int {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return sort.Search(len(entries), func(i int) bool { return entries[i].key >= key })
+}
+`
+
+// sortmigrate owns the sorting functions of package sort and searchmigrate
+// owns sort.Search: run together, each reports only its own call and the
+// sortmigrate fix leaves the sort.Search call alone.
+func TestSortAndSearchOwnership(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/owners\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "owners.go"), sortSearchSrc)
+	t.Chdir(dir)
+
+	findings, err := analyze([]*analysis.Analyzer{sortmigrate.Analyzer, searchmigrate.Analyzer}, []string{"./..."})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]struct {
+		line int
+		call string
+	}{
+		"sortmigrate":   {11, "sort.Slice"},
+		"searchmigrate": {12, "sort.Search"},
+	}
+	if len(findings) != len(want) {
+		t.Fatalf("got %d findings, want %d: %v", len(findings), len(want), plain(findings))
+	}
+	for _, f := range findings {
+		w, ok := want[f.Analyzer]
+		if !ok {
+			t.Errorf("unexpected finding from %s: %s", f.Analyzer, f.Message)
+			continue
+		}
+		if f.Position.Line != w.line || !strings.HasPrefix(f.Message, w.call+" ") {
+			t.Errorf("%s: got %q at line %d, want a %s finding at line %d", f.Analyzer, f.Message, f.Position.Line, w.call, w.line)
+		}
+		delete(want, f.Analyzer)
+	}
+
+	start := strings.Index(sortSearchSrc, "\treturn sort.Search")
+	end := start + strings.Index(sortSearchSrc[start:], "\n")
+	for _, f := range findings {
+		for _, e := range f.fix {
+			if e.start < end && e.end > start {
+				t.Errorf("%s fix edits the sort.Search line: %q", f.Analyzer, e.text)
+			}
+		}
+	}
+}
+
+func TestDirectRequested(t *testing.T) {
+	for _, tt := range []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"./..."}, false},
+		{[]string{"-baseline=b.json", "./..."}, true},
+		{[]string{"--baseline", "b.json", "./..."}, true},
+		{[]string{"-write-baseline", "-baseline=b.json"}, true},
+		{[]string{"-sarif", "./..."}, true},
+		{[]string{"-patch", "./..."}, true},
+		{[]string{"-json", "./..."}, true},
+		{[]string{"-json", "-flags", "/tmp/vet.cfg"}, false},
+		{[]string{"-fix", "./..."}, false},
+		{[]string{"--", "-baseline"}, false},
+	} {
+		if got := directRequested(tt.args); got != tt.want {
+			t.Errorf("directRequested(%q) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
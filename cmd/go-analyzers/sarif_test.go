@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/albertocavalcante/go-analyzers/deferloopcheck"
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"github.com/albertocavalcante/go-analyzers/sortmigrate"
+)
+
+const mixedSrc = `package mixed
+
+import (
+	"os"
+	"sort"
+)
+
+func Mixed(x int, names []string) {
+	switch x {
+	case 1:
+		break
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f, _ := os.Open(name)
+		defer f.Close()
+	}
+}
+`
+
+func TestSARIF(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/mixed\n\ngo 1.25\n")
+	writeFile(t, filepath.Join(dir, "mixed.go"), mixedSrc)
+	t.Chdir(dir)
+
+	analyzers := []*analysis.Analyzer{deferloopcheck.Analyzer, redundantbreakcheck.Analyzer, sortmigrate.Analyzer}
+
+	var stdout, stderr bytes.Buffer
+	if code := runDirect(&stdout, &stderr, analyzers, []string{"-sarif", "./..."}); code != 3 {
+		t.Fatalf("exit code %d, want 3, stderr:\n%s", code, stderr.String())
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("decoding SARIF: %v\n%s", err, stdout.String())
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("got %d runs, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+
+	want := map[string]string{
+		"deferloopcheck":      "warning",
+		"sortmigrate":         "note",
+		"redundantbreakcheck": "none",
+	}
+	for _, rule := range run.Tool.Driver.Rules {
+		if rule.DefaultConfiguration.Level != want[rule.ID] {
+			t.Errorf("rule %s: default level %q, want %q", rule.ID, rule.DefaultConfiguration.Level, want[rule.ID])
+		}
+	}
+	got := make(map[string]string)
+	for _, r := range run.Results {
+		got[r.RuleID] = r.Level
+		if loc := r.Locations[0].PhysicalLocation; loc.ArtifactLocation.URI != "mixed.go" || loc.Region.StartLine == 0 {
+			t.Errorf("result %s: location %+v, want a line in mixed.go", r.RuleID, loc)
+		}
+	}
+	for id, level := range want {
+		if got[id] != level {
+			t.Errorf("result %s: level %q, want %q", id, got[id], level)
+		}
+	}
+}
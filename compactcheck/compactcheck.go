@@ -0,0 +1,168 @@
+// Package compactcheck defines an analyzer that detects slices.Compact calls
+// on slices that are not sorted first.
+//
+// # Analyzer compactcheck
+//
+// compactcheck: detect slices.Compact on a slice that is not sorted first
+//
+// slices.Compact only removes consecutive duplicates. Sorting first is the
+// usual way to de-duplicate a slice:
+//
+//	slices.Sort(s)
+//	s = slices.Compact(s)
+//
+// This analyzer flags slices.Compact(s) when no statement before it, in the
+// same block or an enclosing block of the same function, sorts s:
+//
+//	s = slices.Compact(s) // may leave duplicates
+//
+// Recognized sorting statements are calls to slices.Sort, slices.SortFunc,
+// slices.SortStableFunc, sort.Strings, sort.Ints, sort.Float64s, sort.Slice,
+// and sort.SliceStable with s as the first argument, and assignments of the
+// form s := slices.Sorted(...) or s := slices.SortedFunc(...).
+//
+// Compacting data that is already grouped (for example, consecutive runs
+// from a sorted source) is legitimate, so the diagnostic is report-only.
+package compactcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "compactcheck",
+	Doc:      "detect slices.Compact on a slice that is not sorted first",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// sortFuncs lists the functions, by package path, that sort their first argument in place.
+var sortFuncs = map[string]map[string]bool{
+	"slices": {"Sort": true, "SortFunc": true, "SortStableFunc": true},
+	"sort":   {"Strings": true, "Ints": true, "Float64s": true, "Slice": true, "SliceStable": true},
+}
+
+// sortedFuncs lists the slices functions that return a new sorted slice.
+var sortedFuncs = map[string]bool{
+	"Sorted":           true,
+	"SortedFunc":       true,
+	"SortedStableFunc": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+
+		pkg, name := pkgFunc(pass, call)
+		if pkg != "slices" || name != "Compact" || len(call.Args) != 1 {
+			return true
+		}
+
+		target := types.ExprString(call.Args[0])
+		if sortedBefore(pass, stack, target) {
+			return true
+		}
+
+		pass.Reportf(call.Pos(),
+			"slices.Compact only removes consecutive duplicates; %s is not sorted before this call", target)
+		return true
+	})
+
+	return nil, nil
+}
+
+// sortedBefore walks outward from the call at the top of stack and reports
+// whether any statement preceding it, in its block or an enclosing block of
+// the same function, sorts target.
+func sortedBefore(pass *analysis.Pass, stack []ast.Node, target string) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		var list []ast.Stmt
+		switch parent := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		case *ast.BlockStmt:
+			list = parent.List
+		case *ast.CaseClause:
+			list = parent.Body
+		case *ast.CommClause:
+			list = parent.Body
+		default:
+			continue
+		}
+
+		// stack[i+1] is the statement in list that contains the call.
+		for _, stmt := range list {
+			if stmt == stack[i+1] {
+				break
+			}
+			if sorts(pass, stmt, target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// sorts reports whether stmt sorts target in place or assigns it the result
+// of slices.Sorted and friends.
+func sorts(pass *analysis.Pass, stmt ast.Stmt, target string) bool {
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return false
+		}
+		pkg, name := pkgFunc(pass, call)
+		return sortFuncs[pkg][name] && types.ExprString(call.Args[0]) == target
+
+	case *ast.AssignStmt:
+		for i, lhs := range stmt.Lhs {
+			if types.ExprString(lhs) != target || i >= len(stmt.Rhs) {
+				continue
+			}
+			call, ok := stmt.Rhs[i].(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			if pkg, name := pkgFunc(pass, call); pkg == "slices" && sortedFuncs[name] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pkgFunc returns the import path and function name of a qualified call
+// like pkg.Func(...), or empty strings if call is not one.
+func pkgFunc(pass *analysis.Pass, call *ast.CallExpr) (path, name string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return "", ""
+	}
+
+	return pkgName.Imported().Path(), sel.Sel.Name
+}
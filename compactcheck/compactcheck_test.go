@@ -0,0 +1,13 @@
+package compactcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/compactcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestCompactCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, compactcheck.Analyzer, "compacttest")
+}
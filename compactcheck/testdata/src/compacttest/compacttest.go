@@ -0,0 +1,74 @@
+package compacttest
+
+import (
+	"maps"
+	"slices"
+	"sort"
+)
+
+type set struct {
+	names []string
+}
+
+func bareCompact(s []int) []int {
+	// Should be flagged: no sort before Compact.
+	return slices.Compact(s) // want `slices\.Compact only removes consecutive duplicates; s is not sorted before this call`
+}
+
+func sortedOtherSlice(s, t []int) {
+	// Should be flagged: a different slice was sorted.
+	slices.Sort(t)
+	s = slices.Compact(s) // want `slices\.Compact only removes consecutive duplicates; s is not sorted before this call`
+	_ = s
+}
+
+func sortedAfter(s []int) {
+	// Should be flagged: sorting after Compact is too late.
+	s = slices.Compact(s) // want `slices\.Compact only removes consecutive duplicates; s is not sorted before this call`
+	slices.Sort(s)
+}
+
+func fieldCompact(st *set) {
+	// Should be flagged: field slice with no sort.
+	st.names = slices.Compact(st.names) // want `slices\.Compact only removes consecutive duplicates; st\.names is not sorted before this call`
+}
+
+func sortedInClosure(s []int) {
+	// Should be flagged: the sort happens in the outer function, but the
+	// closure may run later on different contents.
+	slices.Sort(s)
+	f := func() []int {
+		return slices.Compact(s) // want `slices\.Compact only removes consecutive duplicates; s is not sorted before this call`
+	}
+	_ = f
+}
+
+func noMatch(s []int, strs []string, st *set, m map[string]int) {
+	// Sorted then compacted — should NOT be flagged.
+	slices.Sort(s)
+	s = slices.Compact(s)
+
+	// sort package — should NOT be flagged.
+	sort.Strings(strs)
+	strs = slices.Compact(strs)
+
+	// sort.Slice on a field — should NOT be flagged.
+	sort.Slice(st.names, func(i, j int) bool { return st.names[i] < st.names[j] })
+	st.names = slices.Compact(st.names)
+
+	// SortFunc — should NOT be flagged.
+	slices.SortFunc(s, func(a, b int) int { return a - b })
+	s = slices.Compact(s)
+
+	// Sorted in an enclosing block — should NOT be flagged.
+	slices.Sort(s)
+	if len(s) > 1 {
+		s = slices.Compact(s)
+	}
+
+	// Assigned from slices.Sorted — should NOT be flagged.
+	keys := slices.Sorted(maps.Keys(m))
+	keys = slices.Compact(keys)
+
+	_, _, _ = s, strs, keys
+}
@@ -0,0 +1,344 @@
+// Package comparatorhint defines an analyzer that finds sort.Slice callbacks
+// delegating to a less-style comparator function, including comparators
+// declared in other packages.
+//
+// # Analyzer comparatorhint
+//
+// comparatorhint: suggest slices.SortFunc for sort.Slice callbacks that delegate to a comparator
+//
+// A comparator here is a function whose only purpose is to order two values:
+//
+//	func byName(a, b Item) bool { return a.Name < b.Name }
+//
+// The analyzer records such functions with a ComparatorFact, which is
+// exported so that packages importing the comparator see it too. It then
+// flags sort.Slice, sort.SliceStable, and sort.SliceIsSorted callbacks that
+// only call a comparator on two elements of the slice being sorted:
+//
+//	sort.Slice(items, func(i, j int) bool { return byName(items[i], items[j]) })
+//
+// and suggests the equivalent slices call with an inline cmp.Compare:
+//
+//	slices.SortFunc(items, func(a, b Item) int { return cmp.Compare(a.Name, b.Name) })
+//
+// Comparators from other packages that compare unexported fields or
+// methods, or take an unexported type, are not suggested, since the inline
+// comparison could not be written at the call site. No auto-fix is
+// provided; the comparator may have other callers.
+package comparatorhint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "comparatorhint",
+	Doc:       "suggest slices.SortFunc for sort.Slice callbacks that delegate to a comparator",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(ComparatorFact)},
+}
+
+// ComparatorFact marks a function of the form func(a, b T) bool whose body
+// is a single comparison of the same field or method chain on a and b.
+type ComparatorFact struct {
+	// Chain is the access path compared on each argument, e.g. ".Name" or
+	// ".Key()". It is empty when the arguments are compared directly.
+	Chain string
+	// Descending is true when the comparison orders b before a.
+	Descending bool
+}
+
+// AFact implements analysis.Fact.
+func (*ComparatorFact) AFact() {}
+
+func (f *ComparatorFact) String() string {
+	if f.Descending {
+		return fmt.Sprintf("comparator(%s, descending)", f.Chain)
+	}
+	return fmt.Sprintf("comparator(%s)", f.Chain)
+}
+
+// migrations maps sort functions taking a less callback to their slices
+// equivalents.
+var migrations = map[string]string{
+	"Slice":         "slices.SortFunc",
+	"SliceStable":   "slices.SortStableFunc",
+	"SliceIsSorted": "slices.IsSortedFunc",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// First export facts for comparators declared in this package, so that
+	// callbacks below can use them regardless of declaration order.
+	declFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	inspect.Preorder(declFilter, func(n ast.Node) {
+		decl := n.(*ast.FuncDecl)
+		fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return
+		}
+		if fact := comparatorFact(decl); fact != nil {
+			pass.ExportObjectFact(fn, fact)
+		}
+	})
+
+	callFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(callFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		checkSortCall(pass, call)
+	})
+
+	return nil, nil
+}
+
+// comparatorFact returns a fact for decl if it is a comparator, or nil.
+func comparatorFact(decl *ast.FuncDecl) *ComparatorFact {
+	if decl.Recv != nil || decl.Body == nil || decl.Type.TypeParams != nil {
+		return nil
+	}
+
+	// Exactly two parameters of the same type, one bool result.
+	params := decl.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 2 {
+		return nil
+	}
+	a, b := params[0].Names[0].Name, params[0].Names[1].Name
+	results := decl.Type.Results
+	if results == nil || len(results.List) != 1 || len(results.List[0].Names) > 1 {
+		return nil
+	}
+	if ident, ok := results.List[0].Type.(*ast.Ident); !ok || ident.Name != "bool" {
+		return nil
+	}
+
+	if len(decl.Body.List) != 1 {
+		return nil
+	}
+	ret, ok := decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+	binExpr, ok := ret.Results[0].(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+
+	var opReversed bool
+	switch binExpr.Op {
+	case token.LSS, token.LEQ:
+	case token.GTR, token.GEQ:
+		opReversed = true
+	default:
+		return nil
+	}
+
+	lhsChain, lhsRoot, ok := paramChain(binExpr.X)
+	if !ok {
+		return nil
+	}
+	rhsChain, rhsRoot, ok := paramChain(binExpr.Y)
+	if !ok || lhsChain != rhsChain {
+		return nil
+	}
+
+	var swapped bool
+	switch {
+	case lhsRoot == a && rhsRoot == b:
+	case lhsRoot == b && rhsRoot == a:
+		swapped = true
+	default:
+		return nil
+	}
+
+	return &ComparatorFact{Chain: lhsChain, Descending: opReversed != swapped}
+}
+
+// paramChain returns the field/method chain applied to an identifier, and
+// the identifier's name:
+//
+//	a          → ("",         "a", true)
+//	a.Name     → (".Name",    "a", true)
+//	a.Key()    → (".Key()",   "a", true)
+func paramChain(expr ast.Expr) (chain, root string, ok bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return "", e.Name, true
+	case *ast.SelectorExpr:
+		chain, root, ok := paramChain(e.X)
+		if !ok {
+			return "", "", false
+		}
+		return chain + "." + e.Sel.Name, root, true
+	case *ast.CallExpr:
+		if len(e.Args) != 0 {
+			return "", "", false
+		}
+		chain, root, ok := paramChain(e.Fun)
+		if !ok {
+			return "", "", false
+		}
+		return chain + "()", root, true
+	}
+	return "", "", false
+}
+
+// checkSortCall reports sort.Slice-style calls whose callback is
+// func(i, j int) bool { return less(s[i], s[j]) } with less a comparator.
+func checkSortCall(pass *analysis.Pass, call *ast.CallExpr) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 2 {
+		return
+	}
+	replacement, ok := migrations[sel.Sel.Name]
+	if !ok {
+		return
+	}
+	pkgIdent, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return
+	}
+	pkgName, ok := pass.TypesInfo.ObjectOf(pkgIdent).(*types.PkgName)
+	if !ok || pkgName.Imported().Path() != "sort" {
+		return
+	}
+
+	sliceIdent, ok := call.Args[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	funcLit, ok := call.Args[1].(*ast.FuncLit)
+	if !ok || len(funcLit.Body.List) != 1 {
+		return
+	}
+	params := funcLit.Type.Params.List
+	if len(params) != 1 || len(params[0].Names) != 2 {
+		return
+	}
+	iParam, jParam := params[0].Names[0].Name, params[0].Names[1].Name
+
+	ret, ok := funcLit.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return
+	}
+	lessCall, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok || len(lessCall.Args) != 2 {
+		return
+	}
+
+	var lessIdent *ast.Ident
+	switch fun := lessCall.Fun.(type) {
+	case *ast.Ident:
+		lessIdent = fun
+	case *ast.SelectorExpr:
+		lessIdent = fun.Sel // pkg.Less
+	default:
+		return
+	}
+	less, ok := pass.TypesInfo.ObjectOf(lessIdent).(*types.Func)
+	if !ok {
+		return
+	}
+	var fact ComparatorFact
+	if !pass.ImportObjectFact(less, &fact) {
+		return
+	}
+
+	first, ok := indexParam(lessCall.Args[0], sliceIdent.Name)
+	if !ok {
+		return
+	}
+	second, ok := indexParam(lessCall.Args[1], sliceIdent.Name)
+	if !ok {
+		return
+	}
+	descending := fact.Descending
+	switch {
+	case first == iParam && second == jParam:
+	case first == jParam && second == iParam:
+		descending = !descending
+	default:
+		return
+	}
+
+	// The comparator's parameter type names the element type.
+	elemType := less.Signature().Params().At(0).Type()
+
+	// The suggested code is written at the call site, which cannot name
+	// unexported fields, methods, or types of the comparator's package.
+	if less.Pkg() != pass.Pkg && (!exportedChain(fact.Chain) || !exportedType(elemType)) {
+		return
+	}
+	elemStr := types.TypeString(elemType, func(pkg *types.Package) string {
+		if pkg == pass.Pkg {
+			return ""
+		}
+		return pkg.Name()
+	})
+
+	aExpr, bExpr := "a"+fact.Chain, "b"+fact.Chain
+	if descending {
+		aExpr, bExpr = bExpr, aExpr
+	}
+
+	pass.Reportf(call.Pos(),
+		"sort.%s callback only calls comparator %s; consider %s(%s, func(a, b %s) int { return cmp.Compare(%s, %s) })",
+		sel.Sel.Name, less.Name(), replacement, sliceIdent.Name, elemStr, aExpr, bExpr)
+}
+
+// indexParam returns the index identifier name if expr is sliceName[idx].
+func indexParam(expr ast.Expr, sliceName string) (string, bool) {
+	idxExpr, ok := expr.(*ast.IndexExpr)
+	if !ok {
+		return "", false
+	}
+	x, ok := idxExpr.X.(*ast.Ident)
+	if !ok || x.Name != sliceName {
+		return "", false
+	}
+	idx, ok := idxExpr.Index.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return idx.Name, true
+}
+
+// exportedChain reports whether every field or method in chain, such as
+// ".Name" or ".Key()", is exported.
+func exportedChain(chain string) bool {
+	for _, name := range strings.Split(chain, ".")[1:] {
+		if !token.IsExported(strings.TrimSuffix(name, "()")) {
+			return false
+		}
+	}
+	return true
+}
+
+// exportedType reports whether t, after removing pointers, is not a named
+// type whose name is unexported.
+func exportedType(t types.Type) bool {
+	for {
+		ptr, ok := t.(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	return !ok || named.Obj().Exported()
+}
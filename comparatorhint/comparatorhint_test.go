@@ -0,0 +1,13 @@
+package comparatorhint_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/comparatorhint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestComparatorHint(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, comparatorhint.Analyzer, "comparatorlib", "comparatortest")
+}
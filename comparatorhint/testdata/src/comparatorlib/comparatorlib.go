@@ -0,0 +1,32 @@
+package comparatorlib
+
+type Version struct {
+	Major, Minor int
+	build        int
+}
+
+type tag struct{ Name string }
+
+// Comparator over a field — exported fact.
+func ByMajor(a, b Version) bool { return a.Major < b.Major } // want ByMajor:`comparator\(\.Major\)`
+
+// Swapped arguments — descending.
+func ByMinorDesc(a, b Version) bool { return b.Minor < a.Minor } // want ByMinorDesc:`comparator\(\.Minor, descending\)`
+
+// Not a comparator: extra logic.
+func Newer(a, b Version) bool {
+	if a.Major != b.Major {
+		return a.Major > b.Major
+	}
+	return a.Minor > b.Minor
+}
+
+// Comparator over an unexported field — exported fact, but not usable
+// outside this package.
+func ByBuild(a, b Version) bool { return a.build < b.build } // want ByBuild:`comparator\(\.build\)`
+
+// Comparator over an unexported type.
+func ByTagName(a, b tag) bool { return a.Name < b.Name } // want ByTagName:`comparator\(\.Name\)`
+
+// Tags returns tags to sort.
+func Tags() []tag { return nil }
@@ -0,0 +1,24 @@
+package comparatortest
+
+type Item struct {
+	Name string
+	Size int
+}
+
+func (i Item) Key() string { return i.Name }
+
+func byName(a, b Item) bool { return a.Name < b.Name } // want byName:`comparator\(\.Name\)`
+
+func bySizeDesc(a, b Item) bool { return a.Size > b.Size } // want bySizeDesc:`comparator\(\.Size, descending\)`
+
+func byKey(x, y Item) bool { return x.Key() < y.Key() } // want byKey:`comparator\(\.Key\(\)\)`
+
+func ints(a, b int) bool { return a < b } // want ints:`comparator\(\)`
+
+// Not comparators.
+
+func mixed(a, b Item) bool { return a.Name < b.Key() }
+
+func equal(a, b Item) bool { return a.Name == b.Name }
+
+func (i Item) Less(o Item) bool { return i.Name < o.Name }
@@ -0,0 +1,62 @@
+package comparatortest
+
+import (
+	"sort"
+
+	"comparatorlib"
+)
+
+func sameFile() {
+	items := []Item{{Name: "b"}, {Name: "a"}}
+
+	// Should be flagged: comparator declared in another file of this package.
+	sort.Slice(items, func(i, j int) bool { return byName(items[i], items[j]) }) // want `sort\.Slice callback only calls comparator byName; consider slices\.SortFunc\(items, func\(a, b Item\) int \{ return cmp\.Compare\(a\.Name, b\.Name\) \}\)`
+
+	// Should be flagged: descending comparator.
+	sort.SliceStable(items, func(i, j int) bool { return bySizeDesc(items[i], items[j]) }) // want `sort\.SliceStable callback only calls comparator bySizeDesc; consider slices\.SortStableFunc\(items, func\(a, b Item\) int \{ return cmp\.Compare\(b\.Size, a\.Size\) \}\)`
+
+	// Should be flagged: swapped indices flip the direction.
+	_ = sort.SliceIsSorted(items, func(i, j int) bool { return byKey(items[j], items[i]) }) // want `sort\.SliceIsSorted callback only calls comparator byKey; consider slices\.IsSortedFunc\(items, func\(a, b Item\) int \{ return cmp\.Compare\(b\.Key\(\), a\.Key\(\)\) \}\)`
+
+	nums := []int{3, 1, 2}
+	sort.Slice(nums, func(i, j int) bool { return ints(nums[i], nums[j]) }) // want `consider slices\.SortFunc\(nums, func\(a, b int\) int \{ return cmp\.Compare\(a, b\) \}\)`
+}
+
+func crossPackage() {
+	versions := []comparatorlib.Version{{Major: 2}, {Major: 1}}
+
+	// Should be flagged: fact imported from comparatorlib.
+	sort.Slice(versions, func(i, j int) bool { return comparatorlib.ByMajor(versions[i], versions[j]) }) // want `sort\.Slice callback only calls comparator ByMajor; consider slices\.SortFunc\(versions, func\(a, b comparatorlib\.Version\) int \{ return cmp\.Compare\(a\.Major, b\.Major\) \}\)`
+
+	sort.Slice(versions, func(i, j int) bool { return comparatorlib.ByMinorDesc(versions[i], versions[j]) }) // want `cmp\.Compare\(b\.Minor, a\.Minor\)`
+}
+
+func crossPackageUnexported() {
+	versions := []comparatorlib.Version{{Major: 2}, {Major: 1}}
+
+	// Unexported field of another package — should NOT be flagged.
+	sort.Slice(versions, func(i, j int) bool { return comparatorlib.ByBuild(versions[i], versions[j]) })
+
+	// Unexported type of another package — should NOT be flagged.
+	tags := comparatorlib.Tags()
+	sort.Slice(tags, func(i, j int) bool { return comparatorlib.ByTagName(tags[i], tags[j]) })
+}
+
+func noMatch() {
+	items := []Item{{Name: "b"}, {Name: "a"}}
+	versions := []comparatorlib.Version{{Major: 2}, {Major: 1}}
+
+	// Not a comparator — should NOT be flagged.
+	sort.Slice(items, func(i, j int) bool { return mixed(items[i], items[j]) })
+	sort.Slice(versions, func(i, j int) bool { return comparatorlib.Newer(versions[i], versions[j]) })
+
+	// Method, not a comparator function — should NOT be flagged.
+	sort.Slice(items, func(i, j int) bool { return items[i].Less(items[j]) })
+
+	// Comparator applied to a different slice — should NOT be flagged.
+	other := []Item{{Name: "c"}, {Name: "d"}}
+	sort.Slice(items, func(i, j int) bool { return byName(other[i], other[j]) })
+
+	// Same index twice — should NOT be flagged.
+	sort.Slice(items, func(i, j int) bool { return byName(items[i], items[i]) })
+}
@@ -0,0 +1,272 @@
+// Package constraintcheck defines an analyzer that detects hand-written
+// constraints equivalent to cmp.Ordered.
+//
+// # Analyzer constraintcheck
+//
+// constraintcheck: detect local constraints that duplicate cmp.Ordered
+//
+// Code written before Go 1.21 often declares its own ordered constraint:
+//
+//	type ordered interface {
+//	    ~int | ~int8 | ~int16 | ~int32 | ~int64 |
+//	        ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+//	        ~float32 | ~float64 | ~string
+//	}
+//
+//	func Max[T ordered](a, b T) T
+//
+// This analyzer flags interfaces whose type set is exactly that of
+// cmp.Ordered, including ones built like constraints.Ordered from nested
+// Integer, Float, and similar unions. The fix uses cmp.Ordered at every
+// reference in the package, adding the cmp import where needed. An
+// unexported declaration is removed; an exported one becomes an alias,
+// type Ordered = cmp.Ordered, so other packages keep compiling.
+//
+// A constraint over the same types that drops a ~, as in int instead of
+// ~int, or misses one or two of the numeric types is reported without a
+// fix, since the difference may or may not be intended. The fix is also
+// withheld when cmp refers to something else at a reference or the
+// declaration cannot be removed cleanly.
+package constraintcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "constraintcheck",
+	Doc:      "detect local constraints that duplicate cmp.Ordered",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// ordered lists the types whose underlying types make up cmp.Ordered.
+var ordered = []string{
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+	"float32", "float64", "string",
+}
+
+// maxMissing is the most numeric types a constraint may lack from
+// cmp.Ordered and still be reported as a near match.
+const maxMissing = 2
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.GenDecl)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		decl := n.(*ast.GenDecl)
+		if decl.Tok != token.TYPE {
+			return
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if spec.Assign.IsValid() || spec.TypeParams != nil {
+				continue
+			}
+			obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName)
+			if !ok {
+				continue
+			}
+			iface, ok := obj.Type().Underlying().(*types.Interface)
+			if !ok {
+				continue
+			}
+			terms, ok := typeTerms(iface)
+			if !ok {
+				continue
+			}
+			check(pass, decl, spec, obj, terms)
+		}
+	})
+
+	return nil, nil
+}
+
+// typeTerms returns the terms of the single union making up iface, such as
+// "~int" or "string", flattening unions of other interfaces. It reports
+// false when iface has methods or its type set is not a single union of
+// basic types.
+func typeTerms(iface *types.Interface) (map[string]bool, bool) {
+	if iface.NumMethods() != 0 || iface.NumEmbeddeds() != 1 {
+		return nil, false
+	}
+	terms := make(map[string]bool)
+	var add func(t types.Type, tilde bool) bool
+	add = func(t types.Type, tilde bool) bool {
+		switch t := t.(type) {
+		case *types.Basic:
+			if tilde {
+				terms["~"+t.Name()] = true
+			} else {
+				terms[t.Name()] = true
+			}
+			return true
+		case *types.Union:
+			for i := range t.Len() {
+				if !add(t.Term(i).Type(), t.Term(i).Tilde()) {
+					return false
+				}
+			}
+			return true
+		}
+		if inner, ok := t.Underlying().(*types.Interface); ok && !tilde {
+			if inner.NumMethods() != 0 || inner.NumEmbeddeds() != 1 {
+				return false
+			}
+			return add(inner.EmbeddedType(0), false)
+		}
+		return false
+	}
+	if !add(iface.EmbeddedType(0), false) {
+		return nil, false
+	}
+	return terms, true
+}
+
+// check reports spec when terms match cmp.Ordered exactly, with a fix, or
+// nearly, without one.
+func check(pass *analysis.Pass, decl *ast.GenDecl, spec *ast.TypeSpec, obj *types.TypeName, terms map[string]bool) {
+	var missing, untilded []string
+	for _, name := range ordered {
+		switch {
+		case terms["~"+name]:
+		case terms[name]:
+			untilded = append(untilded, name)
+		default:
+			missing = append(missing, name)
+		}
+	}
+	extra := len(terms) - (len(ordered) - len(missing))
+	if extra > 0 {
+		return
+	}
+
+	name := spec.Name.Name
+	if len(missing) == 0 && len(untilded) == 0 {
+		diag := analysis.Diagnostic{
+			Pos:     spec.Name.Pos(),
+			Message: fmt.Sprintf("%s has the same type set as cmp.Ordered; use cmp.Ordered", name),
+		}
+		if edits := buildFix(pass, decl, spec, obj); edits != nil {
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: fmt.Sprintf("replace %s with cmp.Ordered", name), TextEdits: edits},
+			}
+		}
+		pass.Report(diag)
+		return
+	}
+	// Numeric constraints deliberately leave out string.
+	if len(missing) > maxMissing || slices.Contains(missing, "string") {
+		return
+	}
+
+	var diffs []string
+	if len(untilded) > 0 {
+		diffs = append(diffs, "lacks ~ on "+strings.Join(untilded, ", "))
+	}
+	if len(missing) > 0 {
+		diffs = append(diffs, "omits "+strings.Join(missing, ", "))
+	}
+	pass.Reportf(spec.Name.Pos(), "%s is close to cmp.Ordered but %s; use cmp.Ordered if the difference is not intended",
+		name, strings.Join(diffs, " and "))
+}
+
+// buildFix returns TextEdits replacing every reference to obj in the package
+// with cmp.Ordered, importing cmp where needed, and removing the declaration
+// or, if it is exported, turning it into an alias. It returns nil if cmp
+// means something else at any of those places.
+func buildFix(pass *analysis.Pass, decl *ast.GenDecl, spec *ast.TypeSpec, obj *types.TypeName) []analysis.TextEdit {
+	var edits []analysis.TextEdit
+	files := map[*ast.File]bool{}
+	for ident, use := range pass.TypesInfo.Uses {
+		if use != obj {
+			continue
+		}
+		if !cmpAvailable(pass, ident.Pos()) {
+			return nil
+		}
+		edits = append(edits, analysis.TextEdit{Pos: ident.Pos(), End: ident.End(), NewText: []byte("cmp.Ordered")})
+		files[importutil.FindFileForPos(pass, ident.Pos())] = true
+	}
+
+	if obj.Exported() {
+		if !cmpAvailable(pass, spec.Type.Pos()) {
+			return nil
+		}
+		edits = append(edits, analysis.TextEdit{Pos: spec.Name.End(), End: spec.Type.End(), NewText: []byte(" = cmp.Ordered")})
+		files[importutil.FindFileForPos(pass, spec.Pos())] = true
+	} else {
+		del, ok := declEdit(pass, decl, spec)
+		if !ok {
+			return nil
+		}
+		edits = append(edits, del)
+	}
+
+	// Map iteration order is random; keep the edits in source order.
+	slices.SortFunc(edits, func(a, b analysis.TextEdit) int { return int(a.Pos - b.Pos) })
+	for _, file := range slices.SortedFunc(maps.Keys(files), func(a, b *ast.File) int { return int(a.Pos() - b.Pos()) }) {
+		if file == nil {
+			return nil
+		}
+		if ie := importutil.AddImportEdit(pass, file, "cmp"); ie != nil {
+			edits = append(edits, *ie)
+		}
+	}
+	return edits
+}
+
+// cmpAvailable reports whether the name cmp at pos is free or already
+// refers to the cmp package.
+func cmpAvailable(pass *analysis.Pass, pos token.Pos) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent("cmp", pos)
+	if obj == nil {
+		return true
+	}
+	pkgName, ok := obj.(*types.PkgName)
+	return ok && pkgName.Imported().Path() == "cmp"
+}
+
+// declEdit returns a TextEdit deleting the declaration of spec together with
+// its doc comment, when spec is the only one in decl and nothing else shares
+// its lines.
+func declEdit(pass *analysis.Pass, decl *ast.GenDecl, spec *ast.TypeSpec) (analysis.TextEdit, bool) {
+	if len(decl.Specs) != 1 {
+		return analysis.TextEdit{}, false
+	}
+	start := decl.Pos()
+	if decl.Doc != nil {
+		start = decl.Doc.Pos()
+	}
+	del, ok := editutil.DeleteLines(pass, start, decl.End())
+	if !ok {
+		return analysis.TextEdit{}, false
+	}
+	// Take the blank line that separated the declaration from the code
+	// after it, so none are left doubled.
+	if blank, ok := editutil.DeleteLines(pass, del.End, del.End); ok {
+		del.End = blank.End
+	}
+	return del, true
+}
@@ -0,0 +1,13 @@
+package constraintcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/constraintcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, constraintcheck.Analyzer, "constrainttest")
+}
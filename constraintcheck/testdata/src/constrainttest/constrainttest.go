@@ -0,0 +1,87 @@
+package constrainttest
+
+// ordered predates cmp.Ordered.
+type ordered interface { // want `ordered has the same type set as cmp.Ordered; use cmp.Ordered`
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+func Max[T ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func Min[T ordered](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Signed, Unsigned, Integer, and Float mirror golang.org/x/exp/constraints.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+type Integer interface {
+	Signed | Unsigned
+}
+
+type Float interface {
+	~float32 | ~float64
+}
+
+// Ordered is exported, so it stays as an alias.
+type Ordered interface { // want `Ordered has the same type set as cmp.Ordered; use cmp.Ordered`
+	Integer | Float | ~string
+}
+
+func Clamp[T Ordered](v, lo, hi T) T {
+	return Max(lo, Min(v, hi))
+}
+
+// Near matches are reported without a fix.
+
+type exact interface { // want `exact is close to cmp.Ordered but lacks ~ on int, string; use cmp.Ordered if the difference is not intended`
+	int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | string
+}
+
+type noUintptr interface { // want `noUintptr is close to cmp.Ordered but omits uintptr; use cmp.Ordered if the difference is not intended`
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~string
+}
+
+func Sum[T exact | noUintptr](vs ...T) T {
+	var s T
+	for _, v := range vs {
+		s += v
+	}
+	return s
+}
+
+// Not flagged: different type sets, or methods.
+
+type Number interface {
+	Integer | Float
+}
+
+type withComplex interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string | ~complex128
+}
+
+type orderedStringer interface {
+	Ordered
+	String() string
+}
@@ -0,0 +1,9 @@
+package constrainttest
+
+import "sort"
+
+func sortedCopy[T ordered](s []T) []T {
+	out := append([]T(nil), s...)
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
@@ -0,0 +1,204 @@
+// Package countcheck defines an analyzer that detects range loops that only
+// count the elements of a collection.
+//
+// # Analyzer countcheck
+//
+// countcheck: detect range loops that count elements len can give
+//
+// This analyzer flags a counter initialized to zero and immediately followed
+// by a range loop whose body does nothing but increment it:
+//
+//	count := 0
+//	for range items {
+//	    count++
+//	}
+//
+// The loop computes the number of elements, which len returns directly:
+//
+//	count := len(items)
+//
+// Only slices, arrays, pointers to arrays, and maps are considered. Ranging
+// over a string visits runes rather than bytes, and ranging over a channel
+// receives until it is closed, so len gives a different answer for both;
+// runecountcheck handles strings.
+// Loops that increment conditionally are not flagged. The fix is withheld
+// when comments would be deleted with the loop.
+package countcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "countcheck",
+	Doc:      "detect range loops that count elements len can give",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i+1 < len(list); i++ {
+			decl, counter, ok := zeroCounter(pass, list[i])
+			if !ok {
+				continue
+			}
+			rng, ok := list[i+1].(*ast.RangeStmt)
+			if !ok || !countsElements(pass, rng, counter) {
+				continue
+			}
+			report(pass, decl, rng, counter)
+		}
+	})
+
+	return nil, nil
+}
+
+// zeroCounter matches count := 0 and returns the statement and the counter.
+func zeroCounter(pass *analysis.Pass, stmt ast.Stmt) (*ast.AssignStmt, types.Object, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	lit, ok := assign.Rhs[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT || lit.Value != "0" {
+		return nil, nil, false
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	if obj == nil {
+		return nil, nil, false
+	}
+	return assign, obj, true
+}
+
+// countsElements reports whether rng ranges over a collection that len
+// measures, binds no variables, and only increments counter by one.
+func countsElements(pass *analysis.Pass, rng *ast.RangeStmt, counter types.Object) bool {
+	if !isBlank(rng.Key) || !isBlank(rng.Value) || len(rng.Body.List) != 1 {
+		return false
+	}
+	t := pass.TypesInfo.TypeOf(rng.X)
+	if t == nil {
+		return false
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+	case *types.Pointer:
+		if _, ok := u.Elem().Underlying().(*types.Array); !ok {
+			return false
+		}
+	default:
+		return false
+	}
+
+	var target ast.Expr
+	switch stmt := rng.Body.List[0].(type) {
+	case *ast.IncDecStmt:
+		if stmt.Tok != token.INC {
+			return false
+		}
+		target = stmt.X
+	case *ast.AssignStmt:
+		if stmt.Tok != token.ADD_ASSIGN || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return false
+		}
+		lit, ok := stmt.Rhs[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT || lit.Value != "1" {
+			return false
+		}
+		target = stmt.Lhs[0]
+	default:
+		return false
+	}
+	ident, ok := target.(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == counter
+}
+
+// isBlank reports whether a range variable is absent or the blank
+// identifier.
+func isBlank(expr ast.Expr) bool {
+	if expr == nil {
+		return true
+	}
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
+// report reports the counting loop rng, with a fix initializing the counter
+// to len directly when no comments would be lost.
+func report(pass *analysis.Pass, decl *ast.AssignStmt, rng *ast.RangeStmt, counter types.Object) {
+	length := "len(" + types.ExprString(rng.X) + ")"
+	diag := analysis.Diagnostic{
+		Pos:     decl.Pos(),
+		Message: fmt.Sprintf("loop counting the elements of %s can be %s := %s", types.ExprString(rng.X), counter.Name(), length),
+	}
+	if edits := buildFix(pass, decl, rng); edits != nil {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{Message: fmt.Sprintf("replace the loop with %s := %s", counter.Name(), length), TextEdits: edits},
+		}
+	}
+	pass.Report(diag)
+}
+
+// buildFix returns TextEdits replacing the zero in decl with len of the
+// ranged expression and deleting rng. It returns nil if a comment other than
+// one ending the declaration's line would be deleted.
+func buildFix(pass *analysis.Pass, decl *ast.AssignStmt, rng *ast.RangeStmt) []analysis.TextEdit {
+	tokFile := pass.Fset.File(decl.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+
+	// Delete from the end of the declaration's line, keeping its comment.
+	del := decl.End()
+	if line := tokFile.Line(decl.End()); line != tokFile.Line(rng.Pos()) {
+		del = tokFile.LineStart(line+1) - 1
+	}
+
+	file := importutil.FindFileForPos(pass, decl.Pos())
+	if file == nil {
+		return nil
+	}
+	for _, cg := range file.Comments {
+		if cg.Pos() > del && cg.Pos() < rng.End() {
+			return nil
+		}
+	}
+
+	x := src[tokFile.Offset(rng.X.Pos()):tokFile.Offset(rng.X.End())]
+	return []analysis.TextEdit{
+		{Pos: decl.Rhs[0].Pos(), End: decl.Rhs[0].End(), NewText: []byte("len(" + string(x) + ")")},
+		{Pos: del, End: rng.End()},
+	}
+}
@@ -0,0 +1,13 @@
+package countcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/countcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestCountCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, countcheck.Analyzer, "counttest")
+}
@@ -0,0 +1,114 @@
+package counttest
+
+func countSlice(items []string) int {
+	count := 0 // want `loop counting the elements of items can be count := len\(items\)`
+	for range items {
+		count++
+	}
+	return count
+}
+
+func countMap(m map[string]int) int {
+	n := 0 // want `loop counting the elements of m can be n := len\(m\)`
+	for _ = range m {
+		n += 1
+	}
+	return n
+}
+
+func countArrayPointer(a *[4]int) int {
+	count := 0 // want `loop counting the elements of a can be count := len\(a\)`
+	for _, _ = range a {
+		count++
+	}
+	return count
+}
+
+type Batch struct {
+	Items []int
+}
+
+func countField(b Batch) int {
+	total := 0 // want `loop counting the elements of b.Items can be total := len\(b.Items\)`
+	for range b.Items {
+		total++
+	}
+	return total
+}
+
+// A comment inside the loop would be lost, so there is no fix.
+func countCommented(items []string) int {
+	count := 0 // want `loop counting the elements of items can be count := len\(items\)`
+	for range items {
+		// one per item
+		count++
+	}
+	return count
+}
+
+// Conditional counting is not len.
+func countMatching(items []string) int {
+	count := 0
+	for _, v := range items {
+		if v != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// Ranging over a string counts runes, not bytes.
+func countRunes(s string) int {
+	count := 0
+	for range s {
+		count++
+	}
+	return count
+}
+
+// Ranging over a channel drains it.
+func countReceived(ch chan int) int {
+	count := 0
+	for range ch {
+		count++
+	}
+	return count
+}
+
+// The loop does more than count.
+func countAndLog(items []string, log func(string)) int {
+	count := 0
+	for _, v := range items {
+		count++
+		log(v)
+	}
+	return count
+}
+
+// A counter that does not start at zero.
+func countFrom(items []string) int {
+	count := 1
+	for range items {
+		count++
+	}
+	return count
+}
+
+// Something runs between the declaration and the loop.
+func countAfter(items []string, f func()) int {
+	count := 0
+	f()
+	for range items {
+		count++
+	}
+	return count
+}
+
+// A different variable is incremented.
+func countOther(items []string, other *int) int {
+	count := 0
+	for range items {
+		*other++
+	}
+	return count
+}
@@ -0,0 +1,96 @@
+// Package ctxkeycheck defines an analyzer that detects context values keyed
+// by built-in types.
+//
+// # Analyzer ctxkeycheck
+//
+// ctxkeycheck: detect context.WithValue and Context.Value with built-in key types
+//
+// This analyzer flags context value keys whose type is a built-in type such
+// as string or int, both when storing and when retrieving:
+//
+//	ctx = context.WithValue(ctx, "userID", id)
+//	id, _ := ctx.Value("userID").(string)
+//
+// Keys are compared by type and value, so any package using the same string
+// reads or overwrites the same value. The context package documentation
+// asks for a key type of the package's own, usually unexported:
+//
+//	type userIDKey struct{}
+//
+//	ctx = context.WithValue(ctx, userIDKey{}, id)
+//	id, _ := ctx.Value(userIDKey{}).(string)
+//
+// Only calls of context.WithValue and of the Value method of the
+// context.Context interface are checked. Diagnostics are report-only, since
+// the key type has to be declared.
+package ctxkeycheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ctxkeycheck",
+	Doc:      "detect context.WithValue and Context.Value with built-in key types",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "context" {
+			return
+		}
+		var key ast.Expr
+		var what string
+		switch {
+		case fn.Name() == "WithValue" && fn.Signature().Recv() == nil && len(call.Args) == 3:
+			key, what = call.Args[1], "context.WithValue"
+		case fn.Name() == "Value" && isContextMethod(fn) && len(call.Args) == 1:
+			key, what = call.Args[0], "Context.Value"
+		default:
+			return
+		}
+
+		t := pass.TypesInfo.TypeOf(key)
+		basic, ok := t.(*types.Basic)
+		if !ok {
+			return
+		}
+		typeName := types.Default(basic).String()
+		pass.Report(analysis.Diagnostic{
+			Pos: key.Pos(),
+			End: key.End(),
+			Message: fmt.Sprintf("%s key of built-in type %s can collide with keys from other packages; use a key of an unexported type",
+				what, typeName),
+		})
+	})
+
+	return nil, nil
+}
+
+// isContextMethod reports whether fn is a method of the context.Context
+// interface.
+func isContextMethod(fn *types.Func) bool {
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	named, ok := types.Unalias(recv.Type()).(*types.Named)
+	return ok && named.Obj().Name() == "Context"
+}
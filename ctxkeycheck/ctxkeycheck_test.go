@@ -0,0 +1,13 @@
+package ctxkeycheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/ctxkeycheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestCtxKeyCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ctxkeycheck.Analyzer, "ctxkeytest")
+}
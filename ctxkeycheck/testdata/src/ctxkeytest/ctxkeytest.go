@@ -0,0 +1,44 @@
+package ctxkeytest
+
+import "context"
+
+type userIDKey struct{}
+
+type key int
+
+const requestIDKey key = 0
+
+const traceKey = "trace"
+
+func store(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, "userID", id) // want `context\.WithValue key of built-in type string can collide with keys from other packages; use a key of an unexported type`
+	ctx = context.WithValue(ctx, 42, id)       // want `context\.WithValue key of built-in type int can collide with keys from other packages`
+	ctx = context.WithValue(ctx, traceKey, id) // want `context\.WithValue key of built-in type string can collide with keys from other packages`
+
+	// Typed keys — should NOT be flagged.
+	ctx = context.WithValue(ctx, userIDKey{}, id)
+	ctx = context.WithValue(ctx, requestIDKey, id)
+	return ctx
+}
+
+func load(ctx context.Context) (string, bool) {
+	if v, ok := ctx.Value("userID").(string); ok { // want `Context\.Value key of built-in type string can collide with keys from other packages`
+		return v, true
+	}
+
+	// Typed key — should NOT be flagged.
+	v, ok := ctx.Value(userIDKey{}).(string)
+	return v, ok
+}
+
+type myCtx struct{ context.Context }
+
+// Value methods of other types — should NOT be flagged.
+type store2 struct{}
+
+func (store2) Value(key string) any { return nil }
+
+func other(c myCtx, s store2) {
+	_ = s.Value("userID")
+	_ = c.Value("userID") // want `Context\.Value key of built-in type string can collide`
+}
@@ -0,0 +1,76 @@
+// Package deferloopcheck defines an analyzer that detects defer statements
+// inside loop bodies.
+//
+// # Analyzer deferloopcheck
+//
+// deferloopcheck: detect defer inside a loop, which runs only when the function returns
+//
+// This analyzer flags defer statements that appear lexically inside a for
+// or range loop body:
+//
+//	for _, name := range files {
+//	    f, _ := os.Open(name)
+//	    defer f.Close()
+//	}
+//
+// Deferred calls run when the enclosing function returns, not at the end of
+// each iteration, so resources accumulate until then. The usual fix is to
+// move the loop body into a helper function. No auto-fix is provided.
+//
+// A defer inside a function literal in the loop body (for example an
+// immediately invoked func() { ... }()) runs when that literal returns, so
+// it is not flagged.
+package deferloopcheck
+
+import (
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "deferloopcheck",
+	Doc:      "detect defer inside a loop, which runs only when the function returns",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.DeferStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		if inLoop(stack) {
+			pass.Reportf(n.Pos(),
+				"defer inside a loop runs only when the function returns; move the loop body into a helper function")
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// inLoop reports whether the innermost enclosing function of the last node in
+// stack reaches it through a for or range statement. The search stops at the
+// first function boundary, since a defer in a function literal runs when that
+// literal returns.
+func inLoop(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return false
+		case *ast.ForStmt, *ast.RangeStmt:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package deferloopcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/deferloopcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDeferLoopCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, deferloopcheck.Analyzer, "deferlooptest")
+}
@@ -0,0 +1,75 @@
+package deferlooptest
+
+import "os"
+
+func leaky(files []string) {
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			continue
+		}
+		defer f.Close() // want "defer inside a loop runs only when the function returns"
+	}
+}
+
+func leakyFor(n int) {
+	for i := 0; i < n; i++ {
+		f, err := os.Create("out")
+		if err != nil {
+			return
+		}
+		defer f.Close() // want "defer inside a loop runs only when the function returns"
+	}
+}
+
+func leakyNested(files []string) {
+	for _, name := range files {
+		if name != "" {
+			f, err := os.Open(name)
+			if err != nil {
+				continue
+			}
+			defer f.Close() // want "defer inside a loop runs only when the function returns"
+		}
+	}
+}
+
+func leakyInsideClosureLoop() {
+	// The loop is inside the literal, so the defer still accumulates there.
+	func() {
+		for range 3 {
+			f, err := os.Open("x")
+			if err != nil {
+				return
+			}
+			defer f.Close() // want "defer inside a loop runs only when the function returns"
+		}
+	}()
+}
+
+func noMatch(files []string) {
+	// Defer outside any loop — should NOT be flagged.
+	f, err := os.Open("x")
+	if err == nil {
+		defer f.Close()
+	}
+
+	// Defer inside an immediately invoked function literal — runs every
+	// iteration, should NOT be flagged.
+	for _, name := range files {
+		func() {
+			f, err := os.Open(name)
+			if err != nil {
+				return
+			}
+			defer f.Close()
+		}()
+	}
+
+	// Defer inside a goroutine started from the loop — should NOT be flagged.
+	for range files {
+		go func() {
+			defer func() {}()
+		}()
+	}
+}
@@ -0,0 +1,239 @@
+// Package doublelookupcheck defines an analyzer that detects a map key
+// checked for presence and then looked up again for its value.
+//
+// # Analyzer doublelookupcheck
+//
+// doublelookupcheck: detect map existence checks followed by a second lookup
+//
+// This analyzer flags if statements that test a key with the comma-ok form
+// while discarding the value, and then index the map with the same key
+// inside the branch:
+//
+//	if _, ok := m[k]; ok {
+//	    v := m[k]
+//	    use(v)
+//	}
+//
+// The first lookup already produced the value:
+//
+//	if v, ok := m[k]; ok {
+//	    use(v)
+//	}
+//
+// A suggested fix is offered when the branch starts with v := m[k]; the
+// declaration moves into the if header. When the second lookup appears
+// elsewhere in the branch, the diagnostic is report-only.
+package doublelookupcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "doublelookupcheck",
+	Doc:      "detect map existence checks followed by a second lookup",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		ifStmt := n.(*ast.IfStmt)
+
+		// Init: _, ok := m[k]
+		init, ok := ifStmt.Init.(*ast.AssignStmt)
+		if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 2 || len(init.Rhs) != 1 {
+			return
+		}
+		blank, ok := init.Lhs[0].(*ast.Ident)
+		if !ok || blank.Name != "_" {
+			return
+		}
+		okIdent, ok := init.Lhs[1].(*ast.Ident)
+		if !ok {
+			return
+		}
+		check, ok := ast.Unparen(init.Rhs[0]).(*ast.IndexExpr)
+		if !ok {
+			return
+		}
+		if _, ok := pass.TypesInfo.TypeOf(check.X).Underlying().(*types.Map); !ok {
+			return
+		}
+		if !stable(pass, check.X) || !stable(pass, check.Index) {
+			return
+		}
+
+		// Cond: ok
+		cond, ok := ast.Unparen(ifStmt.Cond).(*ast.Ident)
+		if !ok || pass.TypesInfo.Uses[cond] == nil || pass.TypesInfo.Uses[cond] != pass.TypesInfo.Defs[okIdent] {
+			return
+		}
+
+		lookup := findLookup(pass, ifStmt.Body, check)
+		if lookup == nil {
+			return
+		}
+
+		mk := types.ExprString(check)
+		diag := analysis.Diagnostic{
+			Pos:     init.Pos(),
+			Message: fmt.Sprintf("%s is looked up again after the existence check; use the value from the comma-ok lookup", mk),
+		}
+		if name, edits := buildFix(pass, ifStmt, blank, lookup); edits != nil {
+			diag.Message = fmt.Sprintf("%s is looked up again after the existence check; use if %s, %s := %s; %s",
+				mk, name, okIdent.Name, mk, okIdent.Name)
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: "reuse the value from the existence check", TextEdits: edits},
+			}
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// findLookup returns the first read of the map element check inside body,
+// or nil if there is none. Assignments to the element and anything after an
+// assignment to the map or key are not reads of the checked value, so the
+// search stops there.
+func findLookup(pass *analysis.Pass, body *ast.BlockStmt, check *ast.IndexExpr) *ast.IndexExpr {
+	var found *ast.IndexExpr
+	stopped := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil || stopped {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if writes(pass, lhs, check) {
+					stopped = true
+					return false
+				}
+			}
+		case *ast.IncDecStmt:
+			if writes(pass, n.X, check) {
+				stopped = true
+				return false
+			}
+		case *ast.CallExpr:
+			// delete(m, ...) or clear(m) changes what a later lookup sees.
+			if fun, ok := n.Fun.(*ast.Ident); ok && (fun.Name == "delete" || fun.Name == "clear") {
+				if _, ok := pass.TypesInfo.Uses[fun].(*types.Builtin); ok {
+					stopped = true
+					return false
+				}
+			}
+		case *ast.FuncLit:
+			return false
+		case *ast.IndexExpr:
+			if sameExpr(pass, n.X, check.X) && sameExpr(pass, n.Index, check.Index) {
+				found = n
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// writes reports whether assigning to lhs changes the map, the key, or an
+// element of the map in check.
+func writes(pass *analysis.Pass, lhs ast.Expr, check *ast.IndexExpr) bool {
+	if sameExpr(pass, lhs, check.X) || sameExpr(pass, lhs, check.Index) {
+		return true
+	}
+	idx, ok := ast.Unparen(lhs).(*ast.IndexExpr)
+	return ok && sameExpr(pass, idx.X, check.X)
+}
+
+// buildFix returns the value name and the TextEdits for the case where the
+// branch starts with v := m[k]: v replaces the blank in the header and the
+// statement is deleted. It returns nil edits otherwise.
+func buildFix(pass *analysis.Pass, ifStmt *ast.IfStmt, blank *ast.Ident, lookup *ast.IndexExpr) (string, []analysis.TextEdit) {
+	first, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+	if !ok || first.Tok != token.DEFINE || len(first.Lhs) != 1 || len(first.Rhs) != 1 || first.Rhs[0] != ast.Expr(lookup) {
+		return "", nil
+	}
+	v, ok := first.Lhs[0].(*ast.Ident)
+	if !ok || v.Name == "_" {
+		return "", nil
+	}
+
+	// Declaring v in the header puts it in scope of the else branch too,
+	// where it could shadow a variable of the same name.
+	if ifStmt.Else != nil && mentions(ifStmt.Else, v.Name) {
+		return "", nil
+	}
+
+	del, ok := editutil.DeleteLines(pass, first.Pos(), first.End())
+	if !ok {
+		return "", nil
+	}
+	return v.Name, []analysis.TextEdit{
+		{Pos: blank.Pos(), End: blank.End(), NewText: []byte(v.Name)},
+		del,
+	}
+}
+
+// stable reports whether evaluating expr twice yields the same value without
+// side effects: a constant, a variable, or a field selection on one.
+func stable(pass *analysis.Pass, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if pass.TypesInfo.Types[expr].Value != nil {
+		return true
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return stable(pass, expr.X)
+	}
+	return false
+}
+
+// sameExpr reports whether a and b denote the same value: the same constant,
+// or the same identifier or selector chain referring to the same objects.
+func sameExpr(pass *analysis.Pass, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	if va, vb := pass.TypesInfo.Types[a].Value, pass.TypesInfo.Types[b].Value; va != nil || vb != nil {
+		return va != nil && vb != nil && constant.Compare(va, token.EQL, vb)
+	}
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) != nil && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameExpr(pass, a.X, b.X)
+	}
+	return false
+}
+
+// mentions reports whether n contains an identifier named name.
+func mentions(n ast.Node, name string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
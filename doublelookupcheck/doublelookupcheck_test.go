@@ -0,0 +1,13 @@
+package doublelookupcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/doublelookupcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDoubleLookupCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, doublelookupcheck.Analyzer, "doublelookuptest")
+}
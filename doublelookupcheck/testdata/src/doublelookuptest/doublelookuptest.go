@@ -0,0 +1,91 @@
+package doublelookuptest
+
+import "fmt"
+
+func first(m map[string]int, k string) {
+	if _, ok := m[k]; ok { // want `m\[k\] is looked up again after the existence check; use if v, ok := m\[k\]; ok`
+		v := m[k]
+		fmt.Println(v)
+	}
+}
+
+type cache struct {
+	entries map[int]string
+}
+
+func (c *cache) get(id int) string {
+	if _, found := c.entries[id]; found { // want `c\.entries\[id\] is looked up again after the existence check; use if e, found := c\.entries\[id\]; found`
+		e := c.entries[id]
+		return e
+	}
+	return ""
+}
+
+func constantKey(m map[string]bool) {
+	if _, ok := m["debug"]; ok { // want `m\["debug"\] is looked up again after the existence check; use if on, ok := m\["debug"\]; ok`
+		on := m["debug"]
+		fmt.Println(on)
+	}
+}
+
+// The second lookup is not the first statement of the branch.
+func later(m map[string]int, k string) {
+	if _, ok := m[k]; ok { // want `m\[k\] is looked up again after the existence check; use the value from the comma-ok lookup`
+		fmt.Println("found")
+		fmt.Println(m[k] * 2)
+	}
+}
+
+// An else branch using v would see the header's v instead.
+func elseUsesName(m map[string]int, k string, v int) {
+	if _, ok := m[k]; ok { // want `m\[k\] is looked up again after the existence check; use the value from the comma-ok lookup`
+		v := m[k]
+		fmt.Println(v)
+	} else {
+		fmt.Println(v)
+	}
+}
+
+// The value is already used.
+func single(m map[string]int, k string) {
+	if v, ok := m[k]; ok {
+		fmt.Println(v)
+	}
+}
+
+// A different key.
+func otherKey(m map[string]int, k, j string) {
+	if _, ok := m[k]; ok {
+		fmt.Println(m[j])
+	}
+}
+
+// The element is overwritten before it is read.
+func overwritten(m map[string]int, k string) {
+	if _, ok := m[k]; ok {
+		m[k] = 0
+		fmt.Println(m[k])
+	}
+}
+
+// The key changes before the second lookup.
+func keyChanged(m map[int]int, k int) {
+	if _, ok := m[k]; ok {
+		k++
+		fmt.Println(m[k])
+	}
+}
+
+// The key is computed, so the two lookups may differ.
+func computedKey(m map[string]int, key func() string) {
+	if _, ok := m[key()]; ok {
+		fmt.Println(m[key()])
+	}
+}
+
+// Slices are not maps.
+func slice(s []int, ok bool) {
+	if ok {
+		fmt.Println(s[0])
+	}
+}
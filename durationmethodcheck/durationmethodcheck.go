@@ -0,0 +1,160 @@
+// Package durationmethodcheck defines an analyzer that detects durations
+// compared as plain numbers.
+//
+// # Analyzer durationmethodcheck
+//
+// durationmethodcheck: detect d.Seconds() and similar compared with numbers
+//
+// This analyzer flags comparisons between a time.Duration converted to a
+// number of units and a constant:
+//
+//	if d.Nanoseconds() > 5000000000 { ... }
+//	if elapsed.Seconds() >= 1.5 { ... }
+//
+// The unit lives in the method name, far from the number, and large counts
+// are hard to read. Comparing the durations says the same thing in one
+// unit:
+//
+//	if d > 5*time.Second { ... }
+//	if elapsed >= 1500*time.Millisecond { ... }
+//
+// The methods covered are Nanoseconds, Microseconds, Milliseconds, Seconds,
+// Minutes, and Hours. The diagnostic suggests the constant in the largest
+// unit that divides it evenly. Diagnostics are report-only: the integer
+// methods truncate, so d.Milliseconds() > 5 also rejects 5.5ms, and the
+// exact equivalent is not always the intended one.
+package durationmethodcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "durationmethodcheck",
+	Doc:      "detect d.Seconds() and similar compared with numbers",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// unitNanos maps each conversion method to the length of its unit.
+var unitNanos = map[string]int64{
+	"Nanoseconds":  1,
+	"Microseconds": 1e3,
+	"Milliseconds": 1e6,
+	"Seconds":      1e9,
+	"Minutes":      60e9,
+	"Hours":        3600e9,
+}
+
+// units lists the time package constants from largest to smallest.
+var units = []struct {
+	name  string
+	nanos int64
+}{
+	{"time.Hour", 3600e9},
+	{"time.Minute", 60e9},
+	{"time.Second", 1e9},
+	{"time.Millisecond", 1e6},
+	{"time.Microsecond", 1e3},
+	{"time.Nanosecond", 1},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr := n.(*ast.BinaryExpr)
+		switch expr.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return
+		}
+		d, method := durationCall(pass, expr.X)
+		other := expr.Y
+		if d == nil {
+			d, method = durationCall(pass, expr.Y)
+			other = expr.X
+		}
+		if d == nil {
+			return
+		}
+		tv := pass.TypesInfo.Types[other]
+		if tv.Value == nil {
+			return
+		}
+
+		msg := fmt.Sprintf("comparing %s.%s() with %s hides the unit; compare %s with a time.Duration",
+			types.ExprString(d), method, types.ExprString(other), types.ExprString(d))
+		if dur, ok := durationLiteral(tv.Value, unitNanos[method]); ok {
+			msg += " such as " + dur
+		}
+		pass.Reportf(expr.Pos(), "%s", msg)
+	})
+
+	return nil, nil
+}
+
+// durationCall returns d and the method name if expr is a call of one of
+// the unit conversion methods of time.Duration on d.
+func durationCall(pass *analysis.Pass, expr ast.Expr) (ast.Expr, string) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil, ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+	if _, ok := unitNanos[sel.Sel.Name]; !ok {
+		return nil, ""
+	}
+	selection := pass.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil, ""
+	}
+	named, ok := types.Unalias(selection.Recv()).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "time" || named.Obj().Name() != "Duration" {
+		return nil, ""
+	}
+	return sel.X, sel.Sel.Name
+}
+
+// durationLiteral renders value units of unit nanoseconds as a multiple of
+// the largest time package constant dividing it, reporting false when the
+// value is not a whole number of nanoseconds or overflows a Duration.
+func durationLiteral(value constant.Value, unit int64) (string, bool) {
+	nanos := constant.BinaryOp(constant.ToFloat(value), token.MUL, constant.MakeInt64(unit))
+	nanos = constant.ToInt(nanos)
+	if nanos.Kind() != constant.Int {
+		return "", false
+	}
+	n, exact := constant.Int64Val(nanos)
+	if !exact {
+		return "", false
+	}
+	if n == 0 {
+		return "0", true
+	}
+	for _, u := range units {
+		if n%u.nanos != 0 {
+			continue
+		}
+		if count := n / u.nanos; count != 1 {
+			return fmt.Sprintf("%d*%s", count, u.name), true
+		}
+		return u.name, true
+	}
+	return "", false
+}
@@ -0,0 +1,13 @@
+package durationmethodcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/durationmethodcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestDurationMethodCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, durationmethodcheck.Analyzer, "durationmethodtest")
+}
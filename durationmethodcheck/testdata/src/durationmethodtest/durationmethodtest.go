@@ -0,0 +1,37 @@
+package durationmethodtest
+
+import "time"
+
+const limitMillis = 250
+
+func check(d, elapsed time.Duration) bool {
+	if d.Nanoseconds() > 5000000000 { // want `comparing d\.Nanoseconds\(\) with 5000000000 hides the unit; compare d with a time\.Duration such as 5\*time\.Second`
+		return true
+	}
+	if elapsed.Seconds() >= 1.5 { // want `comparing elapsed\.Seconds\(\) with 1\.5 hides the unit; compare elapsed with a time\.Duration such as 1500\*time\.Millisecond`
+		return true
+	}
+	if limitMillis < d.Milliseconds() { // want `comparing d\.Milliseconds\(\) with limitMillis hides the unit; compare d with a time\.Duration such as 250\*time\.Millisecond`
+		return true
+	}
+	if d.Minutes() == 60 { // want `comparing d\.Minutes\(\) with 60 hides the unit; compare d with a time\.Duration such as time\.Hour`
+		return true
+	}
+	return d.Seconds() > 1e-10 // want `comparing d\.Seconds\(\) with 1e-10 hides the unit; compare d with a time\.Duration$`
+}
+
+func notFlagged(d time.Duration, timeout time.Duration, f float64) bool {
+	// Duration comparison — should NOT be flagged.
+	if d > 5*time.Second || d < timeout {
+		return true
+	}
+
+	// Non-constant operand — should NOT be flagged.
+	if d.Seconds() > f {
+		return true
+	}
+
+	// Used as a number, not compared — should NOT be flagged.
+	rate := 100 / d.Seconds()
+	return rate > 1
+}
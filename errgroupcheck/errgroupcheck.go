@@ -0,0 +1,229 @@
+// Package errgroupcheck defines an analyzer that detects goroutine fan-out
+// hand-rolled with a sync.WaitGroup and a mutex-guarded error.
+//
+// # Analyzer errgroupcheck
+//
+// errgroupcheck: detect WaitGroup fan-out collecting errors under a mutex
+//
+// This analyzer flags functions that start goroutines tracked by a
+// sync.WaitGroup, where the goroutines record an error in a shared variable
+// while holding a mutex:
+//
+//	var wg sync.WaitGroup
+//	var mu sync.Mutex
+//	var firstErr error
+//	for _, u := range urls {
+//	    wg.Add(1)
+//	    go func() {
+//	        defer wg.Done()
+//	        if err := fetch(u); err != nil {
+//	            mu.Lock()
+//	            if firstErr == nil {
+//	                firstErr = err
+//	            }
+//	            mu.Unlock()
+//	        }
+//	    }()
+//	}
+//	wg.Wait()
+//
+// errgroup.Group from golang.org/x/sync/errgroup does the counting, the
+// locking, and the error keeping, and can cancel the rest through a context:
+//
+//	var g errgroup.Group
+//	for _, u := range urls {
+//	    g.Go(func() error { return fetch(u) })
+//	}
+//	err := g.Wait()
+//
+// The function must call Add and Wait on the same WaitGroup and start a
+// goroutine from a function literal that assigns a variable of type error
+// declared outside it between Lock and Unlock on a sync.Mutex or
+// sync.RWMutex. Goroutines appending to an []error are not flagged:
+// errgroup.Group keeps only the first error, so it would drop the rest.
+// Diagnostics are report-only, since the rewrite reshapes the goroutines and
+// adds a dependency.
+package errgroupcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errgroupcheck",
+	Doc:      "detect WaitGroup fan-out collecting errors under a mutex",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const msg = "goroutines tracked by a WaitGroup collect an error under a mutex; errgroup.Group from golang.org/x/sync/errgroup does both"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+		case *ast.FuncLit:
+			body = n.Body
+		}
+		if body == nil {
+			return
+		}
+
+		// Collect WaitGroup calls and goroutines of this function, leaving
+		// nested function literals to their own visit.
+		added, waited := map[string]bool{}, map[string]bool{}
+		var goStmts []*ast.GoStmt
+		ast.Inspect(body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.GoStmt:
+				if _, ok := n.Call.Fun.(*ast.FuncLit); ok {
+					goStmts = append(goStmts, n)
+				}
+				return false
+			case *ast.CallExpr:
+				if wg := syncCall(pass, n, "WaitGroup", "Add"); wg != nil {
+					added[types.ExprString(wg)] = true
+				}
+				if wg := syncCall(pass, n, "WaitGroup", "Wait"); wg != nil {
+					waited[types.ExprString(wg)] = true
+				}
+			}
+			return true
+		})
+		tracked := false
+		for wg := range added {
+			tracked = tracked || waited[wg]
+		}
+		if !tracked {
+			return
+		}
+
+		for _, goStmt := range goStmts {
+			if collectsErrorUnderLock(pass, goStmt.Call.Fun.(*ast.FuncLit)) {
+				pass.Report(analysis.Diagnostic{Pos: goStmt.Pos(), Message: msg})
+				return
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// collectsErrorUnderLock reports whether lit assigns an error variable
+// declared outside it while holding a mutex: after a Lock call and before
+// the matching Unlock in the same statement list.
+func collectsErrorUnderLock(pass *analysis.Pass, lit *ast.FuncLit) bool {
+	found := false
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+		var held string // the locked mutex
+		for _, stmt := range list {
+			if mu := lockStmt(pass, stmt, "Lock"); mu != nil {
+				held = types.ExprString(mu)
+			} else if mu := lockStmt(pass, stmt, "Unlock"); mu != nil && types.ExprString(mu) == held {
+				held = ""
+			} else if held != "" && assignsOuterError(pass, stmt, lit) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// lockStmt returns mu if stmt is mu.<method>() on a sync.Mutex or
+// sync.RWMutex. A deferred Unlock does not match, so the lock counts as
+// held to the end of the list.
+func lockStmt(pass *analysis.Pass, stmt ast.Stmt, method string) ast.Expr {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	if mu := syncCall(pass, call, "Mutex", method); mu != nil {
+		return mu
+	}
+	return syncCall(pass, call, "RWMutex", method)
+}
+
+// assignsOuterError reports whether stmt assigns a variable of type error
+// that is declared outside lit.
+func assignsOuterError(pass *analysis.Pass, stmt ast.Stmt, lit *ast.FuncLit) bool {
+	found := false
+	ast.Inspect(stmt, func(n ast.Node) bool {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN {
+			return !found
+		}
+		for _, lhs := range assign.Lhs {
+			ident, ok := ast.Unparen(lhs).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+			if !ok || (v.Pos() >= lit.Pos() && v.Pos() < lit.End()) {
+				continue
+			}
+			if types.Identical(v.Type(), types.Universe.Lookup("error").Type()) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// syncCall returns x if call is x.<method>() for the method of the named
+// type typeName in package sync.
+func syncCall(pass *analysis.Pass, call *ast.CallExpr, typeName, method string) ast.Expr {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return nil
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync" {
+		return nil
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+	t := recv.Type()
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Name() != typeName {
+		return nil
+	}
+	return sel.X
+}
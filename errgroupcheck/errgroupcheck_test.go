@@ -0,0 +1,13 @@
+package errgroupcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/errgroupcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrGroupCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errgroupcheck.Analyzer, "errgrouptest")
+}
@@ -0,0 +1,125 @@
+package errgrouptest
+
+import "sync"
+
+func fetch(string) error { return nil }
+
+func firstError(urls []string) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for _, u := range urls {
+		wg.Add(1)
+		go func() { // want `goroutines tracked by a WaitGroup collect an error under a mutex; errgroup\.Group from golang\.org/x/sync/errgroup does both`
+			defer wg.Done()
+			if err := fetch(u); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+type collector struct {
+	mu   sync.Mutex
+	wg   sync.WaitGroup
+	errs []error
+}
+
+func lastError(c *collector, urls []string) error {
+	var err error
+	for _, u := range urls {
+		c.wg.Add(1)
+		go func() { // want `goroutines tracked by a WaitGroup collect an error under a mutex`
+			defer c.wg.Done()
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			err = fetch(u)
+		}()
+	}
+	c.wg.Wait()
+	return err
+}
+
+// errgroup.Group keeps only the first error — should NOT be flagged.
+func allErrors(c *collector, urls []string) []error {
+	var errs []error
+	for _, u := range urls {
+		c.wg.Add(1)
+		go func() {
+			defer c.wg.Done()
+			err := fetch(u)
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			errs = append(errs, err)
+		}()
+	}
+	c.wg.Wait()
+	return errs
+}
+
+func notFlagged(urls []string) (int, error) {
+	// Plain WaitGroup without errors — should NOT be flagged.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			total++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	// Errors sent on a channel — should NOT be flagged.
+	errc := make(chan error, len(urls))
+	for _, u := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errc <- fetch(u)
+		}()
+	}
+	wg.Wait()
+
+	// Error assigned after the unlock — should NOT be flagged (it races,
+	// but that is not this pattern).
+	var last error
+	for _, u := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			total++
+			mu.Unlock()
+			last = fetch(u)
+		}()
+	}
+	wg.Wait()
+	return total, last
+}
+
+func noWait(urls []string) error {
+	// No Wait — should NOT be flagged.
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for _, u := range urls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			firstErr = fetch(u)
+			mu.Unlock()
+		}()
+	}
+	return firstErr
+}
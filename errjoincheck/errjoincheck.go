@@ -0,0 +1,242 @@
+// Package errjoincheck defines an analyzer that detects errors combined by
+// joining their messages by hand.
+//
+// # Analyzer errjoincheck
+//
+// errjoincheck: detect slices of errors joined through their Error strings
+//
+// This analyzer flags a loop over a slice of errors that builds one message
+// out of their Error strings, either by collecting them for strings.Join:
+//
+//	var msgs []string
+//	for _, err := range errs {
+//		msgs = append(msgs, err.Error())
+//	}
+//	return errors.New(strings.Join(msgs, "; "))
+//
+// or by concatenating them into a string:
+//
+//	var s string
+//	for _, err := range errs {
+//		s += err.Error() + "; "
+//	}
+//
+// Since Go 1.20 the standard library combines errors itself:
+//
+//	return errors.Join(errs...)
+//
+// The joined error keeps the originals, so errors.Is and errors.As still
+// find them, where a message built from their strings loses them.
+//
+// Only range loops binding each error to a variable are matched, and the
+// concatenation must only add string pieces to the same variable, directly
+// or under an if. Files built for a Go version before 1.20, where
+// errors.Join does not exist, are skipped. Diagnostics are report-only,
+// since the joined message is formatted differently and the code around
+// the loop has to change.
+package errjoincheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"go/version"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errjoincheck",
+	Doc:      "detect slices of errors joined through their Error strings",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.File)(nil),
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	// skip is set while visiting a file built for a Go version without
+	// errors.Join.
+	skip := false
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.File:
+			v := pass.TypesInfo.FileVersions[n]
+			skip = v != "" && version.Compare(v, "go1.20") < 0
+			return
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+		if skip {
+			return
+		}
+
+		for i, stmt := range list {
+			rng, ok := stmt.(*ast.RangeStmt)
+			if !ok || !isErrorSlice(pass, rng.X) || rng.Tok != token.DEFINE {
+				continue
+			}
+			id, ok := rng.Value.(*ast.Ident)
+			if !ok || id.Name == "_" {
+				continue
+			}
+			errVar := pass.TypesInfo.ObjectOf(id)
+			if !concatenates(pass, rng.Body.List, errVar) && !collectsForJoin(pass, rng.Body.List, list[i+1:], errVar) {
+				continue
+			}
+			errs := types.ExprString(rng.X)
+			pass.Report(analysis.Diagnostic{
+				Pos: rng.Pos(),
+				End: rng.Body.Lbrace,
+				Message: fmt.Sprintf("errors in %s are joined by their Error strings; use errors.Join(%s...), which keeps them for errors.Is and errors.As",
+					errs, errs),
+			})
+		}
+	})
+
+	return nil, nil
+}
+
+// isErrorSlice reports whether expr is a slice of error.
+func isErrorSlice(pass *analysis.Pass, expr ast.Expr) bool {
+	t, ok := pass.TypesInfo.TypeOf(expr).Underlying().(*types.Slice)
+	return ok && types.Identical(t.Elem(), types.Universe.Lookup("error").Type())
+}
+
+// concatenates reports whether body only adds to one string variable with
+// +=, directly or in if statements without else, and at least one of the
+// additions includes errVar.Error().
+func concatenates(pass *analysis.Pass, body []ast.Stmt, errVar types.Object) bool {
+	var target types.Object
+	found := false
+	var walk func(stmts []ast.Stmt) bool
+	walk = func(stmts []ast.Stmt) bool {
+		for _, stmt := range stmts {
+			switch stmt := stmt.(type) {
+			case *ast.AssignStmt:
+				if stmt.Tok != token.ADD_ASSIGN || len(stmt.Lhs) != 1 {
+					return false
+				}
+				id, ok := stmt.Lhs[0].(*ast.Ident)
+				if !ok || !isString(pass, id) {
+					return false
+				}
+				obj := pass.TypesInfo.ObjectOf(id)
+				if target == nil {
+					target = obj
+				} else if obj != target {
+					return false
+				}
+				found = found || callsError(pass, stmt.Rhs[0], errVar)
+			case *ast.IfStmt:
+				if stmt.Init != nil || stmt.Else != nil || !walk(stmt.Body.List) {
+					return false
+				}
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	return walk(body) && found
+}
+
+// collectsForJoin reports whether body is msgs = append(msgs, errVar.Error())
+// and one of the statements after the loop passes msgs to strings.Join.
+func collectsForJoin(pass *analysis.Pass, body, after []ast.Stmt, errVar types.Object) bool {
+	if len(body) != 1 {
+		return false
+	}
+	assign, ok := body[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return false
+	}
+	id, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return false
+	}
+	msgs := pass.TypesInfo.ObjectOf(id)
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 || !isBuiltin(pass, call.Fun, "append") ||
+		!isObj(pass, call.Args[0], msgs) || !isErrorCall(pass, call.Args[1], errVar) {
+		return false
+	}
+
+	joined := false
+	for _, stmt := range after {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && isStringsJoin(pass, call, msgs) {
+				joined = true
+			}
+			return !joined
+		})
+	}
+	return joined
+}
+
+// isStringsJoin reports whether call is strings.Join(msgs, sep).
+func isStringsJoin(pass *analysis.Pass, call *ast.CallExpr, msgs types.Object) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "strings" && fn.Name() == "Join" &&
+		len(call.Args) == 2 && isObj(pass, call.Args[0], msgs)
+}
+
+// callsError reports whether expr contains errVar.Error().
+func callsError(pass *analysis.Pass, expr ast.Expr, errVar types.Object) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if e, ok := n.(ast.Expr); ok && isErrorCall(pass, e, errVar) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isErrorCall reports whether expr is errVar.Error().
+func isErrorCall(pass *analysis.Pass, expr ast.Expr, errVar types.Object) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "Error" && isObj(pass, sel.X, errVar)
+}
+
+// isString reports whether expr has type string.
+func isString(pass *analysis.Pass, expr ast.Expr) bool {
+	b, ok := pass.TypesInfo.TypeOf(expr).Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsString != 0
+}
+
+// isBuiltin reports whether expr refers to the builtin function name.
+func isBuiltin(pass *analysis.Pass, expr ast.Expr, name string) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok || id.Name != name {
+		return false
+	}
+	_, ok = pass.TypesInfo.Uses[id].(*types.Builtin)
+	return ok
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && obj != nil && pass.TypesInfo.ObjectOf(id) == obj
+}
@@ -0,0 +1,21 @@
+package errjoincheck_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/errjoincheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrJoinCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errjoincheck.Analyzer, "errjointest")
+}
+
+// Modules on a Go version before 1.20 have no errors.Join, so nothing is
+// reported.
+func TestErrJoinCheckBeforeGo120(t *testing.T) {
+	dir := filepath.Join(analysistest.TestData(), "legacy")
+	analysistest.Run(t, dir, errjoincheck.Analyzer, "./...")
+}
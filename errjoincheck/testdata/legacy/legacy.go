@@ -0,0 +1,10 @@
+package legacy
+
+// Should NOT be flagged: errors.Join needs Go 1.20.
+func join(errs []error) string {
+	var s string
+	for _, err := range errs {
+		s += err.Error() + "; "
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package errjointest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func collect(errs []error) error {
+	// Should be flagged: messages collected for strings.Join.
+	var msgs []string
+	for _, err := range errs { // want `errors in errs are joined by their Error strings; use errors\.Join\(errs\.\.\.\), which keeps them for errors\.Is and errors\.As`
+		msgs = append(msgs, err.Error())
+	}
+	return errors.New(strings.Join(msgs, "; "))
+}
+
+func concat(errs []error) error {
+	// Should be flagged: messages concatenated with a separator.
+	var s string
+	for _, err := range errs { // want `errors in errs are joined by their Error strings`
+		s += err.Error() + "; "
+	}
+	return errors.New(s)
+}
+
+type multi struct {
+	errs []error
+}
+
+func (m *multi) Error() string {
+	// Should be flagged: separator added under an if.
+	var s string
+	for i, err := range m.errs { // want `errors in m\.errs are joined by their Error strings; use errors\.Join\(m\.errs\.\.\.\)`
+		if i > 0 {
+			s += ", "
+		}
+		s += err.Error()
+	}
+	return s
+}
+
+func wrapped(errs []error) error {
+	// Should be flagged: the joined string is formatted into another error.
+	msgs := make([]string, 0, len(errs))
+	for _, e := range errs { // want `errors in errs are joined by their Error strings`
+		msgs = append(msgs, e.Error())
+	}
+	return fmt.Errorf("validation failed: %s", strings.Join(msgs, ", "))
+}
+
+func alreadyJoined(errs []error) error {
+	// Already errors.Join — should NOT be flagged.
+	return errors.Join(errs...)
+}
+
+func notJoined(errs []error) []string {
+	// Messages collected but never joined — should NOT be flagged.
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+func other(errs []error, log func(string)) string {
+	// The loop does more than build the message — should NOT be flagged.
+	var s string
+	for _, err := range errs {
+		log(err.Error())
+		s += err.Error()
+	}
+	return s
+}
+
+func names(items []fmt.Stringer) string {
+	// Not errors — should NOT be flagged.
+	var s string
+	for _, item := range items {
+		s += item.String()
+	}
+	return s
+}
+
+func counted(errs []error) string {
+	// Builds the message from something other than the errors — should NOT be flagged.
+	var s string
+	for range errs {
+		s += "x"
+	}
+	return s
+}
@@ -0,0 +1,152 @@
+// Package errorfwrapcheck defines an analyzer that detects fmt.Errorf calls
+// that wrap an error without adding any context.
+//
+// # Analyzer errorfwrapcheck
+//
+// errorfwrapcheck: detect fmt.Errorf("%w", err) that adds no context
+//
+// This analyzer flags fmt.Errorf calls whose format is exactly "%w" or "%v"
+// and whose only argument is an error:
+//
+//	return fmt.Errorf("%w", err)
+//
+// The new error has the same message as err and, with %w, unwraps to it,
+// so it only adds an allocation. Returning err directly is equivalent:
+//
+//	return err
+//
+// The fix is offered for "%w" when the argument has the static type error,
+// so the replacement keeps the expression's type, and fmt keeps a use in
+// the file once every such call is rewritten. "%v" is report-only: it
+// deliberately cuts the chain, so returning err would change what errors.Is
+// and errors.As see. Either way, the rewrite assumes err is non-nil, as
+// fmt.Errorf returns a non-nil error even for a nil argument.
+package errorfwrapcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorfwrapcheck",
+	Doc:      `detect fmt.Errorf("%w", err) that adds no context`,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	errorType := types.Universe.Lookup("error").Type()
+
+	// Fixes are offered once all calls are known: a fix may only rely on
+	// fmt being used elsewhere if that use is not removed by another fix.
+	var diags []analysis.Diagnostic
+	var fixable []ast.Node
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) != 2 || call.Ellipsis.IsValid() || !isFmtErrorf(pass, call) {
+			return
+		}
+
+		tv, ok := pass.TypesInfo.Types[call.Args[0]]
+		if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+			return
+		}
+		format := constant.StringVal(tv.Value)
+		if format != "%w" && format != "%v" {
+			return
+		}
+
+		arg := call.Args[1]
+		argType := pass.TypesInfo.TypeOf(arg)
+		if argType == nil || !types.Implements(argType, errorType.Underlying().(*types.Interface)) {
+			return
+		}
+
+		argStr := types.ExprString(arg)
+		if format == "%v" {
+			pass.Reportf(call.Pos(),
+				`fmt.Errorf("%%v", %s) adds no context; return %s directly, or add context to the message`, argStr, argStr)
+			return
+		}
+
+		msg := fmt.Sprintf(`fmt.Errorf("%%w", %s) adds no context; return %s directly`, argStr, argStr)
+		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+		if types.Identical(argType, errorType) {
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{Pos: call.Pos(), End: arg.Pos()},
+						{Pos: arg.End(), End: call.End()},
+					},
+				},
+			}
+			fixable = append(fixable, call)
+		}
+		diags = append(diags, diag)
+	})
+
+	for _, diag := range diags {
+		if diag.SuggestedFixes != nil && !fmtStaysUsed(pass, diag.Pos, fixable) {
+			diag.SuggestedFixes = nil
+		}
+		pass.Report(diag)
+	}
+
+	return nil, nil
+}
+
+// fmtStaysUsed reports whether fmt remains in use in the file containing
+// pos once all of the fixable calls are removed.
+func fmtStaysUsed(pass *analysis.Pass, pos token.Pos, fixable []ast.Node) bool {
+	file := importutil.FindFileForPos(pass, pos)
+	if file == nil {
+		return false
+	}
+	for _, imp := range file.Imports {
+		if imp.Path.Value != `"fmt"` {
+			continue
+		}
+		pkgName := pass.TypesInfo.PkgNameOf(imp)
+		if pkgName == nil || !importutil.UsedOutside(pass, file, pkgName, fixable...) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFmtErrorf reports whether call is fmt.Errorf.
+func isFmtErrorf(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Errorf" {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return false
+	}
+
+	return pkgName.Imported().Path() == "fmt"
+}
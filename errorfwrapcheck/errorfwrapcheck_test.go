@@ -0,0 +1,13 @@
+package errorfwrapcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/errorfwrapcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrorfWrapCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, errorfwrapcheck.Analyzer, "errorfwraptest")
+}
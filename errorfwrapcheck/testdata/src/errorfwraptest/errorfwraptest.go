@@ -0,0 +1,39 @@
+package errorfwraptest
+
+import (
+	"fmt"
+	"os"
+)
+
+type myErr struct{}
+
+func (*myErr) Error() string { return "my" }
+
+func noContext() error {
+	_, err := os.Open("x")
+	if err != nil {
+		// Should be flagged: %w with nothing else.
+		return fmt.Errorf("%w", err) // want `fmt\.Errorf\("%w", err\) adds no context; return err directly`
+	}
+
+	e := &myErr{}
+	if e != nil {
+		// Should be flagged without a fix: returning e would change the static type.
+		return fmt.Errorf("%w", e) // want `fmt\.Errorf\("%w", e\) adds no context; return e directly`
+	}
+
+	// Should be flagged without a fix: %v cuts the chain on purpose.
+	return fmt.Errorf("%v", err) // want `fmt\.Errorf\("%v", err\) adds no context; return err directly, or add context to the message`
+}
+
+func withContext(name string) error {
+	_, err := os.Open(name)
+
+	// Context in the message — should NOT be flagged.
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+
+	// Not an error argument — should NOT be flagged.
+	return fmt.Errorf("%v", name)
+}
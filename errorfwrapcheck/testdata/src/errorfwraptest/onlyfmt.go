@@ -0,0 +1,13 @@
+package errorfwraptest
+
+import "fmt"
+
+// fmt is only used in the two flagged calls; applying both fixes would
+// leave an unused import, so neither is offered.
+func onlyFmtFirst(err error) error {
+	return fmt.Errorf("%w", err) // want `fmt\.Errorf\("%w", err\) adds no context; return err directly`
+}
+
+func onlyFmtSecond(err error) error {
+	return fmt.Errorf("%w", err) // want `fmt\.Errorf\("%w", err\) adds no context; return err directly`
+}
@@ -0,0 +1,133 @@
+// Package errorsascheck defines an analyzer that detects errors.As calls
+// whose target would make errors.As panic.
+//
+// # Analyzer errorsascheck
+//
+// errorsascheck: detect errors.As with a target that is not a pointer to an error type
+//
+// errors.As requires its second argument to be a non-nil pointer to a type
+// that implements error, or to an interface type. Anything else panics at
+// run time:
+//
+//	var perr *fs.PathError
+//	errors.As(err, perr)  // panics: fs.PathError does not implement error
+//
+//	var n int
+//	errors.As(err, &n)    // panics: int does not implement error
+//
+// The correct call passes the address of the variable:
+//
+//	errors.As(err, &perr)
+//
+// Targets whose static type is an interface (for example a parameter of
+// type any) are not checked, since their dynamic type is unknown. No
+// auto-fix is provided.
+package errorsascheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorsascheck",
+	Doc:      "detect errors.As with a target that is not a pointer to an error type",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		if !isErrorsAs(pass, call) {
+			return
+		}
+		target := call.Args[1]
+
+		t := pass.TypesInfo.TypeOf(target)
+		if t == nil {
+			return
+		}
+
+		if basic, ok := t.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+			pass.Reportf(target.Pos(),
+				"errors.As target must be a non-nil pointer; nil makes errors.As panic")
+			return
+		}
+
+		if types.IsInterface(t) {
+			return // dynamic type unknown
+		}
+
+		ptr, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			pass.Reportf(target.Pos(),
+				"errors.As target %s has non-pointer type %s; errors.As panics unless the target is a pointer",
+				types.ExprString(target), typeString(pass, t))
+			return
+		}
+
+		elem := ptr.Elem()
+		if types.IsInterface(elem) || types.Implements(elem, errorType) {
+			return
+		}
+
+		// A pointer that itself implements error is almost always a missing &.
+		if types.Implements(t, errorType) {
+			pass.Reportf(target.Pos(),
+				"errors.As target %s has type %s, which does not point to an error type; did you mean &%s?",
+				types.ExprString(target), typeString(pass, t), types.ExprString(target))
+			return
+		}
+
+		pass.Reportf(target.Pos(),
+			"errors.As target %s points to %s, which does not implement error; errors.As panics",
+			types.ExprString(target), typeString(pass, elem))
+	})
+
+	return nil, nil
+}
+
+// isErrorsAs reports whether call is errors.As from the standard library.
+func isErrorsAs(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "As" || len(call.Args) != 2 {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return false
+	}
+
+	return pkgName.Imported().Path() == "errors"
+}
+
+// typeString formats t as it would be written in the analyzed package,
+// qualifying types from other packages by package name.
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == pass.Pkg {
+			return ""
+		}
+		return pkg.Name()
+	})
+}
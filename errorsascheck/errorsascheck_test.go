@@ -0,0 +1,13 @@
+package errorsascheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/errorsascheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrorsAsCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errorsascheck.Analyzer, "errorsastest")
+}
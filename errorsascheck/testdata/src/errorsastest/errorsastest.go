@@ -0,0 +1,67 @@
+package errorsastest
+
+import (
+	"errors"
+	"io/fs"
+)
+
+type MyErr struct{}
+
+func (*MyErr) Error() string { return "my" }
+
+type valueErr struct{}
+
+func (valueErr) Error() string { return "value" }
+
+type notAnError struct{}
+
+func example(err error) {
+	// Should be flagged: pointer error variable passed without &.
+	var perr *fs.PathError
+	_ = errors.As(err, perr) // want `errors\.As target perr has type \*fs\.PathError, which does not point to an error type; did you mean &perr\?`
+
+	// Should be flagged: struct value target.
+	var v valueErr
+	_ = errors.As(err, v) // want `errors\.As target v has non-pointer type valueErr; errors\.As panics unless the target is a pointer`
+
+	// Should be flagged: pointee does not implement error.
+	var n notAnError
+	_ = errors.As(err, &n) // want `errors\.As target &n points to notAnError, which does not implement error; errors\.As panics`
+
+	// Should be flagged: pointer to int.
+	var i int
+	_ = errors.As(err, &i) // want `errors\.As target &i points to int, which does not implement error`
+
+	// Should be flagged: nil target.
+	_ = errors.As(err, nil) // want `errors\.As target must be a non-nil pointer; nil makes errors\.As panic`
+}
+
+type errorsPkg struct{}
+
+func (errorsPkg) As(err error, target any) bool { return false }
+
+func noMatch(err error, target any) {
+	// Pointer to a pointer type implementing error — should NOT be flagged.
+	var perr *fs.PathError
+	_ = errors.As(err, &perr)
+
+	// Pointer to a value type implementing error — should NOT be flagged.
+	var v valueErr
+	_ = errors.As(err, &v)
+
+	// Pointer to a pointer-receiver error type — should NOT be flagged.
+	var m *MyErr
+	_ = errors.As(err, &m)
+
+	// Pointer to an interface — should NOT be flagged.
+	var iface interface{ Timeout() bool }
+	_ = errors.As(err, &iface)
+
+	// Interface-typed target — dynamic type unknown, should NOT be flagged.
+	_ = errors.As(err, target)
+
+	// Not the errors package — should NOT be flagged.
+	var e errorsPkg
+	var n notAnError
+	_ = e.As(err, n)
+}
@@ -0,0 +1,123 @@
+// Package errstringcheck defines an analyzer that detects errors identified
+// by comparing their message text.
+//
+// # Analyzer errstringcheck
+//
+// errstringcheck: detect err.Error() compared with a string
+//
+// This analyzer flags equality comparisons between the message of an error
+// and a constant string:
+//
+//	if err.Error() == "record not found" { ... }
+//
+// The message is meant for people: wrapping the error, rewording it, or
+// adding detail breaks the comparison silently. A sentinel compared with
+// errors.Is, or an error type matched with errors.As, survives all three:
+//
+//	if errors.Is(err, ErrNotFound) { ... }
+//
+// Diagnostics are report-only, since the sentinel may not exist yet. A
+// comparison that is intentional, for example against an error from a
+// package that exports nothing better, can be kept by putting an
+// //errstringcheck:ignore comment on its line or the line above.
+package errstringcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errstringcheck",
+	Doc:      "detect err.Error() compared with a string",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// ignoreDirective marks a comparison as intentional.
+const ignoreDirective = "//errstringcheck:ignore"
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		expr := n.(*ast.BinaryExpr)
+		if expr.Op != token.EQL && expr.Op != token.NEQ {
+			return
+		}
+		recv := errorMessage(pass, expr.X)
+		other := expr.Y
+		if recv == nil {
+			recv, other = errorMessage(pass, expr.Y), expr.X
+		}
+		if recv == nil {
+			return
+		}
+		if tv := pass.TypesInfo.Types[other]; tv.Value == nil {
+			return
+		}
+		if ignored(pass, expr.Pos()) {
+			return
+		}
+		pass.Reportf(expr.Pos(),
+			"comparing %s.Error() with a string breaks when the message changes or the error is wrapped; use errors.Is with a sentinel or errors.As with a type",
+			types.ExprString(recv))
+	})
+
+	return nil, nil
+}
+
+// errorMessage returns x if expr is the call x.Error() of the Error method
+// of a value implementing error.
+func errorMessage(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Error" {
+		return nil
+	}
+	selection := pass.TypesInfo.Selections[sel]
+	if selection == nil || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	if !types.Implements(selection.Recv(), errorType) {
+		return nil
+	}
+	return sel.X
+}
+
+// ignored reports whether an ignore directive sits on the line of pos or
+// the line above.
+func ignored(pass *analysis.Pass, pos token.Pos) bool {
+	file := importutil.FindFileForPos(pass, pos)
+	if file == nil {
+		return false
+	}
+	line := pass.Fset.Position(pos).Line
+	for _, cg := range file.Comments {
+		for _, c := range cg.List {
+			if !strings.HasPrefix(c.Text, ignoreDirective) {
+				continue
+			}
+			if l := pass.Fset.Position(c.Slash).Line; l == line || l == line-1 {
+				return true
+			}
+		}
+	}
+	return false
+}
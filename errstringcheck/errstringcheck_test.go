@@ -0,0 +1,13 @@
+package errstringcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/errstringcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestErrStringCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, errstringcheck.Analyzer, "errstringtest")
+}
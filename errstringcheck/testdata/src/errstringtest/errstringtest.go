@@ -0,0 +1,48 @@
+package errstringtest
+
+import (
+	"errors"
+	"io"
+)
+
+var ErrNotFound = errors.New("not found")
+
+const msgNotFound = "not found"
+
+type myErr struct{}
+
+func (*myErr) Error() string { return "mine" }
+
+func compare(err error, e *myErr) bool {
+	if err.Error() == "not found" { // want `comparing err\.Error\(\) with a string breaks when the message changes or the error is wrapped; use errors\.Is with a sentinel or errors\.As with a type`
+		return true
+	}
+	if msgNotFound != err.Error() { // want `comparing err\.Error\(\) with a string breaks when the message changes or the error is wrapped`
+		return false
+	}
+	return e.Error() == "mine" // want `comparing e\.Error\(\) with a string breaks when the message changes or the error is wrapped`
+}
+
+func notFlagged(err error, other error, s string) bool {
+	// Sentinel comparison — should NOT be flagged.
+	if errors.Is(err, ErrNotFound) || err == io.EOF {
+		return true
+	}
+
+	// Comparison with a non-constant string — should NOT be flagged.
+	if err.Error() == s {
+		return true
+	}
+
+	// Two messages compared — should NOT be flagged.
+	if err.Error() == other.Error() {
+		return true
+	}
+
+	// Intentional — should NOT be flagged.
+	//errstringcheck:ignore
+	if err.Error() == "unexpected EOF" {
+		return true
+	}
+	return err.Error() == "closed" //errstringcheck:ignore the driver exports no sentinel
+}
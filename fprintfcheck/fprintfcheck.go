@@ -0,0 +1,169 @@
+// Package fprintfcheck defines an analyzer that detects runs of buffer writes
+// that build formatted text piece by piece.
+//
+// # Analyzer fprintfcheck
+//
+// fprintfcheck: detect consecutive buffer writes that can be one fmt.Fprintf
+//
+// This analyzer flags two or more consecutive WriteString or WriteByte calls
+// on the same bytes.Buffer or strings.Builder when at least one of them
+// writes the result of a strconv conversion or fmt.Sprint:
+//
+//	buf.WriteString("x=")
+//	buf.WriteString(strconv.Itoa(x))
+//	buf.WriteByte(',')
+//
+// A single format string shows the shape of the output at a glance:
+//
+//	fmt.Fprintf(&buf, "x=%d,", x)
+//
+// Runs of plain literal writes are left alone, since there is nothing to
+// format. Building the format string and its verbs from the individual
+// conversions is not worth automating, so diagnostics are report-only.
+package fprintfcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "fprintfcheck",
+	Doc:      "detect consecutive buffer writes that can be one fmt.Fprintf",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// conversions are the functions whose results, written to a buffer, are
+// better expressed as a formatting verb.
+var conversions = map[string]map[string]bool{
+	"strconv": {
+		"Itoa":        true,
+		"FormatInt":   true,
+		"FormatUint":  true,
+		"FormatFloat": true,
+		"FormatBool":  true,
+		"Quote":       true,
+	},
+	"fmt": {
+		"Sprint":   true,
+		"Sprintf":  true,
+		"Sprintln": true,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i < len(list); {
+			recv, ok := bufferWrite(pass, list[i])
+			if !ok {
+				i++
+				continue
+			}
+
+			// Extend the run while the writes go to the same buffer.
+			j := i + 1
+			converts := writesConversion(pass, list[i])
+			for j < len(list) {
+				next, ok := bufferWrite(pass, list[j])
+				if !ok || !sameVar(pass, recv, next) {
+					break
+				}
+				converts = converts || writesConversion(pass, list[j])
+				j++
+			}
+
+			if j-i >= 2 && converts {
+				target := types.ExprString(recv)
+				if _, isPtr := pass.TypesInfo.TypeOf(recv).(*types.Pointer); !isPtr {
+					target = "&" + target
+				}
+				pass.Reportf(list[i].Pos(), "%d consecutive writes to %s can be a single fmt.Fprintf(%s, ...)",
+					j-i, types.ExprString(recv), target)
+			}
+			i = j
+		}
+	})
+
+	return nil, nil
+}
+
+// bufferWrite returns the receiver of stmt if stmt is a WriteString or
+// WriteByte call on a bytes.Buffer or strings.Builder.
+func bufferWrite(pass *analysis.Pass, stmt ast.Stmt) (ast.Expr, bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, false
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "WriteString" && sel.Sel.Name != "WriteByte") {
+		return nil, false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	switch fn.FullName() {
+	case "(*bytes.Buffer).WriteString", "(*bytes.Buffer).WriteByte",
+		"(*strings.Builder).WriteString", "(*strings.Builder).WriteByte":
+		return sel.X, true
+	}
+	return nil, false
+}
+
+// writesConversion reports whether the buffer write in stmt writes the
+// result of one of the conversions.
+func writesConversion(pass *analysis.Pass, stmt ast.Stmt) bool {
+	call := stmt.(*ast.ExprStmt).X.(*ast.CallExpr)
+	arg, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, arg).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return false
+	}
+	return conversions[fn.Pkg().Path()][fn.Name()]
+}
+
+// sameVar reports whether a and b are the same identifier or selector chain
+// referring to the same objects.
+func sameVar(pass *analysis.Pass, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) != nil && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameVar(pass, a.X, b.X)
+	}
+	return false
+}
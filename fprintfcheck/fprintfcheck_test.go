@@ -0,0 +1,13 @@
+package fprintfcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/fprintfcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestFprintfCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, fprintfcheck.Analyzer, "fprintftest")
+}
@@ -0,0 +1,80 @@
+package fprintftest
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func mergeable(x int, name string) string {
+	var buf bytes.Buffer
+	buf.WriteString("x=") // want `3 consecutive writes to buf can be a single fmt\.Fprintf\(&buf, \.\.\.\)`
+	buf.WriteString(strconv.Itoa(x))
+	buf.WriteByte(',')
+	return buf.String()
+}
+
+func builderPointer(sb *strings.Builder, f float64, name string) {
+	sb.WriteString(fmt.Sprint(name)) // want `2 consecutive writes to sb can be a single fmt\.Fprintf\(sb, \.\.\.\)`
+	sb.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+type report struct {
+	out strings.Builder
+}
+
+func (r *report) line(n int) {
+	r.out.WriteString("n: ") // want `2 consecutive writes to r\.out can be a single fmt\.Fprintf\(&r\.out, \.\.\.\)`
+	r.out.WriteString(strconv.Itoa(n))
+}
+
+func inCase(buf *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case bool:
+		buf.WriteString("bool ") // want `2 consecutive writes to buf can be a single fmt\.Fprintf\(buf, \.\.\.\)`
+		buf.WriteString(strconv.FormatBool(v))
+	}
+}
+
+// Only literal writes: nothing to format.
+func literalsOnly() string {
+	var sb strings.Builder
+	sb.WriteString("a")
+	sb.WriteByte(' ')
+	sb.WriteString("b")
+	return sb.String()
+}
+
+// A single write with a conversion is not a run.
+func single(x int) string {
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(x))
+	return sb.String()
+}
+
+// Writes to different buffers do not form a run.
+func differentBuffers(x int) {
+	var a, b strings.Builder
+	a.WriteString("x=")
+	b.WriteString(strconv.Itoa(x))
+}
+
+// Another statement breaks the run.
+func interrupted(x int) string {
+	var sb strings.Builder
+	sb.WriteString("x=")
+	x++
+	sb.WriteString(strconv.Itoa(x))
+	return sb.String()
+}
+
+type writer struct{}
+
+func (writer) WriteString(s string) (int, error) { return len(s), nil }
+
+// Other types with the same methods are not flagged.
+func otherType(w writer, x int) {
+	w.WriteString("x=")
+	w.WriteString(strconv.Itoa(x))
+}
@@ -0,0 +1,86 @@
+// Package fullslicecheck defines an analyzer that detects redundant full
+// slice expressions on values that are already slices.
+//
+// # Analyzer fullslicecheck
+//
+// fullslicecheck: detect s[:] on a slice, which is a no-op
+//
+// This analyzer flags slice expressions of the form x[:] where x is
+// statically a slice:
+//
+//	t := s[:]
+//
+// These can be replaced with the bare operand:
+//
+//	t := s
+//
+// Arrays and pointers to arrays are not flagged, since a[:] is how an
+// array is turned into a slice.
+package fullslicecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "fullslicecheck",
+	Doc:      "detect s[:] on a slice, which is a no-op",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.SliceExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		sliceExpr := n.(*ast.SliceExpr)
+
+		// Only x[:] — any bound or a three-index form changes the result.
+		if sliceExpr.Low != nil || sliceExpr.High != nil || sliceExpr.Max != nil || sliceExpr.Slice3 {
+			return
+		}
+
+		// The operand must be a slice. Arrays and array pointers need a[:]
+		// to produce a slice, so the type check is essential.
+		t := pass.TypesInfo.TypeOf(sliceExpr.X)
+		if t == nil {
+			return
+		}
+		if _, ok := t.Underlying().(*types.Slice); !ok {
+			return
+		}
+
+		xStr := types.ExprString(sliceExpr.X)
+		msg := fmt.Sprintf("%s[:] is redundant on a slice; use %s", xStr, xStr)
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     sliceExpr.Pos(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{
+							// Drop the trailing [:] and keep the operand as written.
+							Pos:     sliceExpr.X.End(),
+							End:     sliceExpr.End(),
+							NewText: nil,
+						},
+					},
+				},
+			},
+		})
+	})
+
+	return nil, nil
+}
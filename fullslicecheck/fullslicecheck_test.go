@@ -0,0 +1,13 @@
+package fullslicecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/fullslicecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestFullSliceCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, fullslicecheck.Analyzer, "fullslicetest")
+}
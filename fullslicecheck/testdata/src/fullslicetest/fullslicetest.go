@@ -0,0 +1,43 @@
+package fullslicetest
+
+type byteSlice []byte
+
+type holder struct {
+	items []string
+}
+
+func example() {
+	s := []int{1, 2, 3}
+
+	// Should be flagged: s is already a slice.
+	t := s[:] // want `s\[:\] is redundant on a slice; use s`
+	_ = t
+
+	// Should be flagged: named slice type.
+	b := byteSlice("abc")
+	_ = b[:] // want `b\[:\] is redundant on a slice; use b`
+
+	// Should be flagged: slice field.
+	h := holder{items: []string{"a"}}
+	_ = h.items[:] // want `h\.items\[:\] is redundant on a slice; use h\.items`
+}
+
+func noMatch() {
+	// Array — a[:] converts it to a slice, should NOT be flagged.
+	var a [3]int
+	_ = a[:]
+
+	// Pointer to array — p[:] converts it to a slice, should NOT be flagged.
+	p := &a
+	_ = p[:]
+
+	// Bounded slice expressions — should NOT be flagged.
+	s := []int{1, 2, 3}
+	_ = s[1:]
+	_ = s[:2]
+	_ = s[:2:2]
+
+	// String — not a slice, should NOT be flagged.
+	str := "abc"
+	_ = str[:]
+}
@@ -0,0 +1,180 @@
+// Package growcheck defines an analyzer that detects hand-written capacity
+// growth that can be replaced with slices.Grow.
+//
+// # Analyzer growcheck
+//
+// growcheck: detect manual slice capacity growth that can use slices.Grow
+//
+// This analyzer flags if statements that ensure room for n more elements by
+// allocating a larger slice, copying into it, and swapping it in:
+//
+//	if cap(s)-len(s) < n {
+//	    grown := make([]T, len(s), len(s)+n)
+//	    copy(grown, s)
+//	    s = grown
+//	}
+//
+// slices.Grow does the same in one call:
+//
+//	s = slices.Grow(s, n)
+//
+// The capacity check, the make, the copy, and the reassignment must all refer
+// to the same slice and the same n. The pattern spans several statements, so
+// diagnostics are report-only.
+//
+// Available since Go 1.21.
+package growcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "growcheck",
+	Doc:      "detect manual slice capacity growth that can use slices.Grow",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		ifStmt := n.(*ast.IfStmt)
+		if ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) != 3 {
+			return
+		}
+
+		// Condition: cap(s)-len(s) < n
+		cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+		if !ok || cond.Op != token.LSS {
+			return
+		}
+		diff, ok := cond.X.(*ast.BinaryExpr)
+		if !ok || diff.Op != token.SUB {
+			return
+		}
+		s, ok := builtinArg(pass, diff.X, "cap")
+		if !ok {
+			return
+		}
+		if lenArg, ok := builtinArg(pass, diff.Y, "len"); !ok || !sameExpr(pass, s, lenArg) {
+			return
+		}
+		growBy := cond.Y
+
+		// Statement 1: grown := make([]T, len(s), len(s)+n)
+		assign, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+		if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return
+		}
+		grown, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return
+		}
+		makeCall, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(makeCall.Args) != 3 || !isBuiltin(pass, makeCall.Fun, "make") {
+			return
+		}
+		if !types.Identical(pass.TypesInfo.TypeOf(makeCall), pass.TypesInfo.TypeOf(s)) {
+			return
+		}
+		if lenArg, ok := builtinArg(pass, makeCall.Args[1], "len"); !ok || !sameExpr(pass, s, lenArg) {
+			return
+		}
+		if !isLenPlus(pass, makeCall.Args[2], s, growBy) {
+			return
+		}
+
+		// Statement 2: copy(grown, s)
+		exprStmt, ok := ifStmt.Body.List[1].(*ast.ExprStmt)
+		if !ok {
+			return
+		}
+		copyCall, ok := exprStmt.X.(*ast.CallExpr)
+		if !ok || len(copyCall.Args) != 2 || !isBuiltin(pass, copyCall.Fun, "copy") {
+			return
+		}
+		if !sameExpr(pass, copyCall.Args[0], grown) || !sameExpr(pass, copyCall.Args[1], s) {
+			return
+		}
+
+		// Statement 3: s = grown
+		reassign, ok := ifStmt.Body.List[2].(*ast.AssignStmt)
+		if !ok || reassign.Tok != token.ASSIGN || len(reassign.Lhs) != 1 || len(reassign.Rhs) != 1 {
+			return
+		}
+		if !sameExpr(pass, reassign.Lhs[0], s) || !sameExpr(pass, reassign.Rhs[0], grown) {
+			return
+		}
+
+		sStr := types.ExprString(s)
+		pass.Reportf(ifStmt.Pos(), "manual capacity growth can be simplified to %s = slices.Grow(%s, %s)",
+			sStr, sStr, types.ExprString(growBy))
+	})
+
+	return nil, nil
+}
+
+// isLenPlus reports whether expr is len(s)+n or n+len(s).
+func isLenPlus(pass *analysis.Pass, expr, s, n ast.Expr) bool {
+	sum, ok := expr.(*ast.BinaryExpr)
+	if !ok || sum.Op != token.ADD {
+		return false
+	}
+	for _, pair := range [2][2]ast.Expr{{sum.X, sum.Y}, {sum.Y, sum.X}} {
+		if lenArg, ok := builtinArg(pass, pair[0], "len"); ok && sameExpr(pass, s, lenArg) && sameExpr(pass, pair[1], n) {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinArg returns the argument of expr if expr is a one-argument call to
+// the builtin named name.
+func builtinArg(pass *analysis.Pass, expr ast.Expr, name string) (ast.Expr, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isBuiltin(pass, call.Fun, name) {
+		return nil, false
+	}
+	return call.Args[0], true
+}
+
+// isBuiltin reports whether fun is the builtin function named name.
+func isBuiltin(pass *analysis.Pass, fun ast.Expr, name string) bool {
+	ident, ok := fun.(*ast.Ident)
+	if !ok || ident.Name != name {
+		return false
+	}
+	_, ok = pass.TypesInfo.ObjectOf(ident).(*types.Builtin)
+	return ok
+}
+
+// sameExpr reports whether a and b denote the same value: the same constant,
+// or the same identifier or selector chain referring to the same objects.
+func sameExpr(pass *analysis.Pass, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	if va, vb := pass.TypesInfo.Types[a].Value, pass.TypesInfo.Types[b].Value; va != nil || vb != nil {
+		return va != nil && vb != nil && constant.Compare(va, token.EQL, vb)
+	}
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) != nil && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameExpr(pass, a.X, b.X)
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package growcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/growcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGrowCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, growcheck.Analyzer, "growtest")
+}
@@ -0,0 +1,97 @@
+package growtest
+
+func grow(s []int, n int) []int {
+	if cap(s)-len(s) < n { // want `manual capacity growth can be simplified to s = slices\.Grow\(s, n\)`
+		grown := make([]int, len(s), len(s)+n)
+		copy(grown, s)
+		s = grown
+	}
+	return s
+}
+
+type buffer struct {
+	data []byte
+}
+
+// A struct field and n+len(s) in the capacity.
+func (b *buffer) reserve(n int) {
+	if cap(b.data)-len(b.data) < n { // want `manual capacity growth can be simplified to b\.data = slices\.Grow\(b\.data, n\)`
+		newData := make([]byte, len(b.data), n+len(b.data))
+		copy(newData, b.data)
+		b.data = newData
+	}
+}
+
+// Constant growth.
+func growConst(s []string) []string {
+	if cap(s)-len(s) < 16 { // want `manual capacity growth can be simplified to s = slices\.Grow\(s, 16\)`
+		t := make([]string, len(s), len(s)+16)
+		copy(t, s)
+		s = t
+	}
+	return s
+}
+
+// Grows by a different amount than it checks.
+func growMismatchedN(s []int, n, m int) []int {
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s), len(s)+m)
+		copy(grown, s)
+		s = grown
+	}
+	return s
+}
+
+// Copies from a different slice.
+func growOtherSource(s, other []int, n int) []int {
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s), len(s)+n)
+		copy(grown, other)
+		s = grown
+	}
+	return s
+}
+
+// Assigns the new slice elsewhere.
+func growOtherTarget(s []int, n int) ([]int, []int) {
+	var out []int
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s), len(s)+n)
+		copy(grown, s)
+		out = grown
+	}
+	return s, out
+}
+
+// Extra work in the body.
+func growAndLog(s []int, n int, log func(string)) []int {
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s), len(s)+n)
+		copy(grown, s)
+		s = grown
+		log("grew")
+	}
+	return s
+}
+
+type ints []int
+
+// make of a different slice type.
+func growNamed(s ints, n int) ints {
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s), len(s)+n)
+		copy(grown, s)
+		s = grown
+	}
+	return s
+}
+
+// Length set to the new capacity.
+func growFullLength(s []int, n int) []int {
+	if cap(s)-len(s) < n {
+		grown := make([]int, len(s)+n, len(s)+n)
+		copy(grown, s)
+		s = grown
+	}
+	return s
+}
@@ -0,0 +1,145 @@
+// Package guardinvertcheck defines an analyzer that detects functions whose
+// logic is nested inside a final if statement that could be a guard clause.
+//
+// # Analyzer guardinvertcheck
+//
+// guardinvertcheck: detect trailing if statements that could be early returns
+//
+// This analyzer flags an if statement without an else that ends a function
+// and holds most of its body:
+//
+//	func handle(req *Request) {
+//	    if req != nil {
+//	        validate(req)
+//	        store(req)
+//	        notify(req)
+//	    }
+//	}
+//
+// Inverting the condition into a guard clause keeps the main path at the
+// outer indentation level:
+//
+//	func handle(req *Request) {
+//	    if req == nil {
+//	        return
+//	    }
+//	    validate(req)
+//	    store(req)
+//	    notify(req)
+//	}
+//
+// The suggested condition flips comparison operators, except for ordered
+// comparisons of floating-point values, which are negated as a whole: with
+// a NaN operand, both x < y and x >= y are false.
+//
+// Only functions without results are considered, since those are the ones
+// that can fall off the end of a trailing if. The if body must have at least
+// three statements and span more than half of the function body. Whether
+// the guard style reads better is a judgment call, so diagnostics are
+// report-only.
+package guardinvertcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "guardinvertcheck",
+	Doc:      "detect trailing if statements that could be early returns",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// minStmts is the fewest statements an if body needs before nesting it is
+// worth flagging.
+const minStmts = 3
+
+// inverses maps comparison operators to their negation.
+var inverses = map[token.Token]token.Token{
+	token.EQL: token.NEQ,
+	token.NEQ: token.EQL,
+	token.LSS: token.GEQ,
+	token.GEQ: token.LSS,
+	token.GTR: token.LEQ,
+	token.LEQ: token.GTR,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+		(*ast.FuncLit)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var funcType *ast.FuncType
+		var body *ast.BlockStmt
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			funcType, body = n.Type, n.Body
+		case *ast.FuncLit:
+			funcType, body = n.Type, n.Body
+		}
+		if body == nil || len(body.List) == 0 || (funcType.Results != nil && len(funcType.Results.List) > 0) {
+			return
+		}
+
+		ifStmt, ok := body.List[len(body.List)-1].(*ast.IfStmt)
+		if !ok || ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) < minStmts {
+			return
+		}
+
+		// The if body must be the bulk of the function.
+		tokFile := pass.Fset.File(body.Pos())
+		ifLines := tokFile.Line(ifStmt.Body.Rbrace) - tokFile.Line(ifStmt.Body.Lbrace)
+		funcLines := tokFile.Line(body.Rbrace) - tokFile.Line(body.Lbrace)
+		if 2*ifLines <= funcLines {
+			return
+		}
+
+		pass.Reportf(ifStmt.Pos(), "if wrapping the rest of the function can be inverted to an early return: if %s { return }",
+			invert(pass, ifStmt.Cond))
+	})
+
+	return nil, nil
+}
+
+// invert returns the source of the negation of cond, flipping comparison
+// operators and removing a leading ! where possible. Ordered comparisons of
+// floating-point values are not flipped: with a NaN operand, both x < y and
+// x >= y are false.
+func invert(pass *analysis.Pass, cond ast.Expr) string {
+	switch e := ast.Unparen(cond).(type) {
+	case *ast.BinaryExpr:
+		if e.Op != token.EQL && e.Op != token.NEQ && (isFloat(pass, e.X) || isFloat(pass, e.Y)) {
+			break
+		}
+		if op, ok := inverses[e.Op]; ok {
+			return types.ExprString(&ast.BinaryExpr{X: e.X, Op: op, Y: e.Y})
+		}
+	case *ast.UnaryExpr:
+		if e.Op == token.NOT {
+			return types.ExprString(ast.Unparen(e.X))
+		}
+	case *ast.Ident, *ast.SelectorExpr, *ast.CallExpr:
+		return "!" + types.ExprString(e)
+	}
+	return "!(" + types.ExprString(cond) + ")"
+}
+
+// isFloat reports whether expr has a floating-point type.
+func isFloat(pass *analysis.Pass, expr ast.Expr) bool {
+	t := pass.TypesInfo.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsFloat != 0
+}
@@ -0,0 +1,13 @@
+package guardinvertcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/guardinvertcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestGuardInvertCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, guardinvertcheck.Analyzer, "guardinverttest")
+}
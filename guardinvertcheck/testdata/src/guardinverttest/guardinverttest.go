@@ -0,0 +1,121 @@
+package guardinverttest
+
+import "fmt"
+
+type request struct {
+	id   int
+	body string
+}
+
+func handle(req *request) {
+	if req != nil { // want `if wrapping the rest of the function can be inverted to an early return: if req == nil \{ return \}`
+		fmt.Println("validating", req.id)
+		fmt.Println("storing", req.body)
+		fmt.Println("notifying", req.id)
+	}
+}
+
+func process(items []string, verbose bool) {
+	fmt.Println("start")
+	if verbose { // want `if wrapping the rest of the function can be inverted to an early return: if !verbose \{ return \}`
+		for _, item := range items {
+			fmt.Println(item)
+		}
+		fmt.Println("count", len(items))
+		fmt.Println("done")
+	}
+}
+
+func negated(done bool, n int) {
+	if !done { // want `if wrapping the rest of the function can be inverted to an early return: if done \{ return \}`
+		fmt.Println(n)
+		fmt.Println(n + 1)
+		fmt.Println(n + 2)
+	}
+}
+
+func compound(a, b int) {
+	if a > 0 && b > 0 { // want `if wrapping the rest of the function can be inverted to an early return: if !\(a > 0 && b > 0\) \{ return \}`
+		fmt.Println(a)
+		fmt.Println(b)
+		fmt.Println(a + b)
+	}
+}
+
+var callback = func(n int) {
+	if n < 10 { // want `if wrapping the rest of the function can be inverted to an early return: if n >= 10 \{ return \}`
+		fmt.Println(n)
+		fmt.Println(n * 2)
+		fmt.Println(n * 3)
+	}
+}
+
+// With a NaN, x < 0.5 and x >= 0.5 are both false, so the comparison is
+// negated as a whole.
+func ratio(x float64) {
+	if x < 0.5 { // want `if wrapping the rest of the function can be inverted to an early return: if !\(x < 0\.5\) \{ return \}`
+		fmt.Println(x)
+		fmt.Println(x * 2)
+		fmt.Println(x * 3)
+	}
+}
+
+// Equality with a NaN inverts cleanly.
+func exact(x float64) {
+	if x == 0 { // want `if wrapping the rest of the function can be inverted to an early return: if x != 0 \{ return \}`
+		fmt.Println(x)
+		fmt.Println(x * 2)
+		fmt.Println(x * 3)
+	}
+}
+
+// A short if body is fine nested.
+func short(req *request) {
+	if req != nil {
+		fmt.Println(req.id)
+	}
+}
+
+// Most of the work happens before the if.
+func mostlyBefore(req *request) {
+	fmt.Println("one")
+	fmt.Println("two")
+	fmt.Println("three")
+	fmt.Println("four")
+	fmt.Println("five")
+	fmt.Println("six")
+	fmt.Println("seven")
+	fmt.Println("eight")
+	if req != nil {
+		fmt.Println(req.id)
+		fmt.Println(req.body)
+		fmt.Println(req.id)
+	}
+}
+
+func withElse(req *request) {
+	if req != nil {
+		fmt.Println(req.id)
+		fmt.Println(req.body)
+		fmt.Println(req.id)
+	} else {
+		fmt.Println("nil")
+	}
+}
+
+func notLast(req *request) {
+	if req != nil {
+		fmt.Println(req.id)
+		fmt.Println(req.body)
+		fmt.Println(req.id)
+	}
+	fmt.Println("done")
+}
+
+func withInit(m map[int]*request) {
+	if req, ok := m[1]; ok {
+		fmt.Println(req.id)
+		fmt.Println(req.body)
+		fmt.Println(req.id)
+	}
+}
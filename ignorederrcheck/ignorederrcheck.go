@@ -0,0 +1,115 @@
+// Package ignorederrcheck defines an analyzer that detects ignored errors
+// from a curated list of standard library functions that fail silently.
+//
+// # Analyzer ignorederrcheck
+//
+// ignorederrcheck: detect ignored errors from os.MkdirAll, os.Remove, and similar calls
+//
+// This analyzer flags calls to listed functions whose only result is an
+// error, when that error is dropped in an expression statement or assigned
+// to the blank identifier:
+//
+//	os.MkdirAll(dir, 0o755)
+//	_ = os.Remove(tmp)
+//	f.Close()
+//
+// A failed MkdirAll or Remove leaves the file system in a state the rest of
+// the program does not expect, and a failed Close on a written file can mean
+// lost data, yet none of these say anything unless the error is checked.
+//
+// Unlike a general errcheck, only the functions named by -funcs are checked,
+// so the analyzer stays quiet about calls whose errors are routinely and
+// safely ignored. Deferred calls, such as defer f.Close() on a file opened
+// for reading, are not flagged. No auto-fix is provided.
+package ignorederrcheck
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "ignorederrcheck",
+	Doc:      "detect ignored errors from os.MkdirAll, os.Remove, and similar calls",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// defaultFuncs lists the functions checked unless -funcs is set, by
+// types.Func.FullName.
+const defaultFuncs = "os.Chdir,os.Chmod,os.Mkdir,os.MkdirAll,os.Remove,os.RemoveAll,os.Rename,os.WriteFile," +
+	"(*os.File).Close,(*os.File).Sync"
+
+// funcs is the comma-separated list of functions to check.
+var funcs string
+
+func init() {
+	Analyzer.Flags.StringVar(&funcs, "funcs", defaultFuncs,
+		"comma-separated functions whose error result must not be ignored, as pkg.Func or (*pkg.Type).Method")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	checked := make(map[string]bool)
+	for name := range strings.SplitSeq(funcs, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			checked[name] = true
+		}
+	}
+
+	nodeFilter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		switch stmt := n.(type) {
+		case *ast.ExprStmt:
+			call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+			if !ok {
+				return
+			}
+			if name, ok := checkedCall(pass, call, checked); ok {
+				pass.Reportf(call.Pos(), "error returned by %s is not checked", name)
+			}
+
+		case *ast.AssignStmt:
+			if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+				return
+			}
+			if ident, ok := stmt.Lhs[0].(*ast.Ident); !ok || ident.Name != "_" {
+				return
+			}
+			call, ok := ast.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+			if !ok {
+				return
+			}
+			if name, ok := checkedCall(pass, call, checked); ok {
+				pass.Reportf(call.Pos(), "error returned by %s is discarded", name)
+			}
+		}
+	})
+
+	return nil, nil
+}
+
+// checkedCall returns the full name of the function called by call if it is
+// in checked and returns only an error.
+func checkedCall(pass *analysis.Pass, call *ast.CallExpr, checked map[string]bool) (string, bool) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || !checked[fn.FullName()] {
+		return "", false
+	}
+	results := fn.Signature().Results()
+	if results.Len() != 1 || !types.Identical(results.At(0).Type(), types.Universe.Lookup("error").Type()) {
+		return "", false
+	}
+	return fn.FullName(), true
+}
@@ -0,0 +1,26 @@
+package ignorederrcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/ignorederrcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestIgnoredErrCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ignorederrcheck.Analyzer, "ignorederrtest")
+}
+
+func TestIgnoredErrCheckFuncs(t *testing.T) {
+	if err := ignorederrcheck.Analyzer.Flags.Set("funcs", "os.Setenv"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		f := ignorederrcheck.Analyzer.Flags.Lookup("funcs")
+		_ = f.Value.Set(f.DefValue)
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, ignorederrcheck.Analyzer, "ignorederrfuncstest")
+}
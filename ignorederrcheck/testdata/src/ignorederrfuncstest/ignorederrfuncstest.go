@@ -0,0 +1,9 @@
+package ignorederrfuncstest
+
+import "os"
+
+// With -funcs=os.Setenv, only os.Setenv is checked.
+func configure(dir string) {
+	os.Setenv("DIR", dir) // want `error returned by os\.Setenv is not checked`
+	os.MkdirAll(dir, 0o755)
+}
@@ -0,0 +1,45 @@
+package ignorederrtest
+
+import (
+	"fmt"
+	"os"
+)
+
+func setup(dir string) {
+	os.MkdirAll(dir, 0o755)     // want `error returned by os\.MkdirAll is not checked`
+	_ = os.Remove(dir + ".tmp") // want `error returned by os\.Remove is discarded`
+}
+
+func write(name string, data []byte) {
+	f, err := os.Create(name)
+	if err != nil {
+		return
+	}
+	f.Write(data)
+	f.Close() // want `error returned by \(\*os\.File\)\.Close is not checked`
+}
+
+// Checked errors are fine.
+func setupChecked(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	err := os.Remove(dir + ".tmp")
+	return err
+}
+
+// A deferred Close on a file opened for reading is not flagged.
+func read(name string) {
+	f, err := os.Open(name)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Println(f.Name())
+}
+
+// Functions outside the list are left to a general errcheck.
+func unlisted() {
+	os.Setenv("A", "b")
+	fmt.Println("hello")
+}
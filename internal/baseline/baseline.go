@@ -0,0 +1,143 @@
+// Package baseline records analyzer findings to a file and suppresses them on
+// later runs, so that only new findings are reported.
+//
+// A finding is identified by its file, analyzer, and a fingerprint hashed
+// from the normalized message and the source text of the surrounding
+// construct (the innermost enclosing statement or declaration, plus the name
+// of the enclosing function). Line numbers are deliberately not part of the
+// fingerprint, so unrelated edits that shift code up or down do not turn old
+// findings into new ones.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Finding is a single analyzer diagnostic as stored in a baseline file.
+type Finding struct {
+	File        string `json:"file"`
+	Analyzer    string `json:"analyzer"`
+	Fingerprint string `json:"fingerprint"`
+	// Message is kept for readers of the baseline file; it is already
+	// covered by Fingerprint and is not used for matching.
+	Message string `json:"message"`
+	// Position is where the finding was reported in the current run, for
+	// printing. Positions shift between runs, so it is not recorded.
+	Position token.Position `json:"-"`
+}
+
+// key returns the part of f that identifies it across runs.
+func (f Finding) key() [3]string {
+	return [3]string{f.File, f.Analyzer, f.Fingerprint}
+}
+
+type file struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Read loads the findings recorded in the baseline file at path.
+func Read(path string) ([]Finding, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	return f.Findings, nil
+}
+
+// Write records findings in a baseline file at path, replacing any existing
+// file.
+func Write(path string, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	data, err := json.MarshalIndent(file{Findings: findings}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Filter returns the findings in current that are not in baseline. Identical
+// findings are counted: if the baseline holds two matching entries and the
+// current run has three, one is reported as new.
+func Filter(baseline, current []Finding) []Finding {
+	remaining := make(map[[3]string]int)
+	for _, f := range baseline {
+		remaining[f.key()]++
+	}
+	var fresh []Finding
+	for _, f := range current {
+		if remaining[f.key()] > 0 {
+			remaining[f.key()]--
+			continue
+		}
+		fresh = append(fresh, f)
+	}
+	return fresh
+}
+
+// Fingerprint returns a stable identifier for a diagnostic reported by
+// analyzer with message at pos in f. src is the content of the file.
+func Fingerprint(analyzer, message string, fset *token.FileSet, f *ast.File, src []byte, pos token.Pos) string {
+	h := sha256.New()
+	for _, part := range []string{analyzer, normalize(message), construct(fset, f, src, pos)} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// construct describes the code around pos: the name of the enclosing
+// function followed by the normalized text of the innermost statement or
+// declaration containing pos.
+func construct(fset *token.FileSet, f *ast.File, src []byte, pos token.Pos) string {
+	path, _ := astutil.PathEnclosingInterval(f, pos, pos)
+
+	var node ast.Node
+	var funcName string
+	for _, n := range path {
+		switch n := n.(type) {
+		case ast.Stmt, ast.Spec:
+			if node == nil {
+				node = n
+			}
+		case *ast.FuncDecl:
+			if node == nil {
+				node = n
+			}
+			funcName = n.Name.Name
+		case ast.Decl:
+			if node == nil {
+				node = n
+			}
+		}
+	}
+	if node == nil {
+		return ""
+	}
+
+	tokFile := fset.File(node.Pos())
+	start, end := tokFile.Offset(node.Pos()), tokFile.Offset(node.End())
+	if end > len(src) {
+		return funcName
+	}
+	return funcName + "\x00" + normalize(string(src[start:end]))
+}
+
+// normalize collapses runs of whitespace so that reindentation and
+// reformatting do not change a fingerprint.
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
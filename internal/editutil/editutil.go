@@ -0,0 +1,65 @@
+// Package editutil provides shared helpers for building TextEdits that
+// delete code in go/analysis SuggestedFixes.
+package editutil
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// DeleteLines returns a TextEdit deleting the whole lines from the line of
+// start through the line of end, if nothing but white space shares those
+// lines with the text between them. It reports false otherwise, or when end
+// is on the last line of the file.
+func DeleteLines(pass *analysis.Pass, start, end token.Pos) (analysis.TextEdit, bool) {
+	tokFile := pass.Fset.File(start)
+	first, last := tokFile.Line(start), tokFile.Line(end)
+	if last == tokFile.LineCount() {
+		return analysis.TextEdit{}, false
+	}
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return analysis.TextEdit{}, false
+	}
+	lineStart := tokFile.LineStart(first)
+	nextLine := tokFile.LineStart(last + 1)
+	before := bytes.TrimSpace(src[tokFile.Offset(lineStart):tokFile.Offset(start)])
+	after := bytes.TrimSpace(src[tokFile.Offset(end):tokFile.Offset(nextLine)])
+	if len(before) > 0 || len(after) > 0 {
+		return analysis.TextEdit{}, false
+	}
+	return analysis.TextEdit{Pos: lineStart, End: nextLine}, true
+}
+
+// DeleteStmt returns a TextEdit removing stmt. When stmt occupies its lines
+// alone, optionally followed by a comment, the whole lines are removed;
+// otherwise the text from prevEnd through stmt is removed. Passing the end
+// of the preceding statement as prevEnd also drops a separating semicolon;
+// passing stmt.Pos() removes just the statement.
+func DeleteStmt(pass *analysis.Pass, stmt ast.Stmt, prevEnd token.Pos) analysis.TextEdit {
+	inline := analysis.TextEdit{Pos: prevEnd, End: stmt.End()}
+
+	tokFile := pass.Fset.File(stmt.Pos())
+	first, last := tokFile.Line(stmt.Pos()), tokFile.Line(stmt.End())
+	if last == tokFile.LineCount() {
+		return inline
+	}
+
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return inline
+	}
+
+	lineStart := tokFile.LineStart(first)
+	nextLine := tokFile.LineStart(last + 1)
+	before := bytes.TrimSpace(src[tokFile.Offset(lineStart):tokFile.Offset(stmt.Pos())])
+	rest := bytes.TrimSpace(src[tokFile.Offset(stmt.End()):tokFile.Offset(nextLine)])
+	if len(before) > 0 || (len(rest) > 0 && !bytes.HasPrefix(rest, []byte("//"))) {
+		return inline
+	}
+
+	return analysis.TextEdit{Pos: lineStart, End: nextLine}
+}
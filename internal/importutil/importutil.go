@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 )
@@ -22,15 +25,16 @@ func FindFileForPos(pass *analysis.Pass, pos token.Pos) *ast.File {
 
 // AddImportEdit creates a TextEdit to add the given package to the file's imports.
 // It returns nil if the package is already imported.
-func AddImportEdit(file *ast.File, pkg string) *analysis.TextEdit {
-	return AddMultipleImportsEdit(file, []string{pkg})
+func AddImportEdit(pass *analysis.Pass, file *ast.File, pkg string) *analysis.TextEdit {
+	return AddMultipleImportsEdit(pass, file, []string{pkg})
 }
 
 // AddMultipleImportsEdit creates a single TextEdit to add multiple packages to the
 // file's imports. Packages that are already imported are skipped. Returns nil if all
 // packages are already imported. The pkgs slice should be in the desired order
-// (typically alphabetical).
-func AddMultipleImportsEdit(file *ast.File, pkgs []string) *analysis.TextEdit {
+// (typically alphabetical). Packages added to a grouped import are indented
+// like its first existing spec.
+func AddMultipleImportsEdit(pass *analysis.Pass, file *ast.File, pkgs []string) *analysis.TextEdit {
 	// Filter out already-imported packages.
 	imported := map[string]bool{}
 	for _, imp := range file.Imports {
@@ -47,10 +51,14 @@ func AddMultipleImportsEdit(file *ast.File, pkgs []string) *analysis.TextEdit {
 	}
 
 	// Build insertion text for all needed packages.
-	var insertLines string
-	for _, pkg := range needed {
-		insertLines += fmt.Sprintf("\t%q\n", pkg)
+	insertText := func(indent string) string {
+		var lines string
+		for _, pkg := range needed {
+			lines += fmt.Sprintf("%s%q\n", indent, pkg)
+		}
+		return lines
 	}
+	insertLines := insertText("\t")
 
 	// Look for an existing import declaration.
 	for _, decl := range file.Decls {
@@ -64,7 +72,7 @@ func AddMultipleImportsEdit(file *ast.File, pkgs []string) *analysis.TextEdit {
 			return &analysis.TextEdit{
 				Pos:     gd.Rparen,
 				End:     gd.Rparen,
-				NewText: []byte(insertLines),
+				NewText: []byte(insertText(importIndent(pass, gd))),
 			}
 		}
 
@@ -94,3 +102,42 @@ func AddMultipleImportsEdit(file *ast.File, pkgs []string) *analysis.TextEdit {
 		NewText: []byte(newText),
 	}
 }
+
+// importIndent returns the whitespace before the first spec of the grouped
+// import gd, or a tab when gd is empty, its first spec shares a line with
+// other text, or the source cannot be read.
+func importIndent(pass *analysis.Pass, gd *ast.GenDecl) string {
+	if len(gd.Specs) == 0 {
+		return "\t"
+	}
+	tokFile := pass.Fset.File(gd.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return "\t"
+	}
+	pos := gd.Specs[0].Pos()
+	lineStart := tokFile.Offset(tokFile.LineStart(tokFile.Line(pos)))
+	indent := string(src[lineStart:tokFile.Offset(pos)])
+	if strings.Trim(indent, " \t") != "" {
+		return "\t"
+	}
+	return indent
+}
+
+// UsedOutside reports whether the package imported as pkgName is referenced
+// in file anywhere outside of nodes. Fixes that delete a reference use this
+// to avoid leaving an unused import behind; fixes that may be applied
+// together pass all the nodes they delete.
+func UsedOutside(pass *analysis.Pass, file *ast.File, pkgName types.Object, nodes ...ast.Node) bool {
+	used := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if slices.Contains(nodes, n) {
+			return false
+		}
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == pkgName {
+			used = true
+		}
+		return !used
+	})
+	return used
+}
@@ -0,0 +1,113 @@
+// Package severity assigns a severity level to each analyzer in this module.
+//
+// go/analysis diagnostics carry no severity, but consumers such as SARIF
+// viewers and language servers distinguish likely bugs from suggestions. The
+// level is a property of the analyzer rather than of a single diagnostic, so
+// it is kept in a table keyed by analyzer name instead of being encoded in
+// each diagnostic's Category.
+package severity
+
+// Level is how urgently a finding deserves attention.
+type Level int
+
+const (
+	// Hint marks style suggestions that are safe to ignore, such as
+	// redundant syntax.
+	Hint Level = iota
+	// Info marks migrations to newer or simpler APIs with the same behavior.
+	Info
+	// Warning marks code that is likely to misbehave: leaks, panics, or
+	// results that differ from what the code appears to do.
+	Warning
+)
+
+// String returns the lowercase name of l.
+func (l Level) String() string {
+	switch l {
+	case Hint:
+		return "hint"
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	}
+	return "unknown"
+}
+
+// SARIF returns the SARIF result level for l. SARIF has no level below
+// "note" for findings, so hints use "none".
+func (l Level) SARIF() string {
+	switch l {
+	case Warning:
+		return "warning"
+	case Info:
+		return "note"
+	}
+	return "none"
+}
+
+// levels maps analyzer names to their level. Analyzers not listed are Info.
+var levels = map[string]Level{
+	"appendmergecheck":       Hint,
+	"boolassigncheck":        Hint,
+	"byteindexcheck":         Hint,
+	"bytestringcompcheck":    Hint,
+	"countcheck":             Hint,
+	"doublelookupcheck":      Hint,
+	"durationmethodcheck":    Hint,
+	"errgroupcheck":          Hint,
+	"errjoincheck":           Hint,
+	"errorfwrapcheck":        Hint,
+	"fprintfcheck":           Hint,
+	"fullslicecheck":         Hint,
+	"guardinvertcheck":       Hint,
+	"logsprintfcheck":        Hint,
+	"mapliteralcheck":        Hint,
+	"nilsliceinitcheck":      Hint,
+	"noopdefercheck":         Hint,
+	"panicstringcheck":       Hint,
+	"parencheck":             Hint,
+	"printlnsprintfcheck":    Hint,
+	"rangeblankcheck":        Hint,
+	"redundantbreakcheck":    Hint,
+	"redundantcontinuecheck": Hint,
+	"redundantconvcheck":     Hint,
+	"redundantzerocheck":     Hint,
+	"repeatsearchcheck":      Hint,
+	"runecountcheck":         Hint,
+	"singleselectcheck":      Hint,
+	"splitindexcheck":        Hint,
+	"uselessclonecheck":      Hint,
+
+	"busywaitcheck":      Warning,
+	"chanlencheck":       Warning,
+	"compactcheck":       Warning,
+	"ctxkeycheck":        Warning,
+	"deferloopcheck":     Warning,
+	"errorsascheck":      Warning,
+	"errstringcheck":     Warning,
+	"ignorederrcheck":    Warning,
+	"jsoncopycheck":      Warning,
+	"lockcopycheck":      Warning,
+	"mapordercheck":      Warning,
+	"nilmapwritecheck":   Warning,
+	"niltruecheck":       Warning,
+	"prealloccheck":      Warning,
+	"respbodycheck":      Warning,
+	"scannererrcheck":    Warning,
+	"shiftoverflowcheck": Warning,
+	"synconcecheck":      Warning,
+	"tickcheck":          Warning,
+	"tickerstopcheck":    Warning,
+	"timelayoutcheck":    Warning,
+	"truncatecheck":      Warning,
+	"valrecvappendcheck": Warning,
+}
+
+// Of returns the level of the analyzer named name.
+func Of(name string) Level {
+	if l, ok := levels[name]; ok {
+		return l
+	}
+	return Info
+}
@@ -0,0 +1,228 @@
+// Package jsoncopycheck defines an analyzer that detects values deep-copied
+// through a JSON round trip.
+//
+// # Analyzer jsoncopycheck
+//
+// jsoncopycheck: detect json.Marshal followed by json.Unmarshal into the same type
+//
+// This analyzer flags a json.Marshal whose result is decoded straight back
+// with json.Unmarshal into a value of the type that was marshaled:
+//
+//	b, err := json.Marshal(src)
+//	if err != nil {
+//		return err
+//	}
+//	var dst Config
+//	if err := json.Unmarshal(b, &dst); err != nil {
+//		return err
+//	}
+//
+// The round trip is a deep copy, but a slow one: it allocates the encoded
+// form, reflects over the type twice, and silently drops everything JSON
+// does not carry, such as unexported fields, channels, and functions, and
+// it turns interface fields holding structs into maps. A copy written for
+// the type, or a Clone method, is faster and keeps every field.
+//
+// The Unmarshal must follow the Marshal directly, with at most an error
+// check and var declarations in between, and decode the marshaled bytes
+// into a pointer to the marshaled type (pointers to that type are marshaled
+// the same way). Decoding into a different type is a conversion, not a
+// copy, and is not flagged, nor is a value of interface type. Test files are
+// skipped, since a round trip there usually checks that the type survives
+// JSON. Diagnostics are report-only.
+package jsoncopycheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "jsoncopycheck",
+	Doc:      "detect json.Marshal followed by json.Unmarshal into the same type",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		// Round trips in tests check that a type survives JSON, which is
+		// the point rather than a copy.
+		if strings.HasSuffix(pass.Fset.File(n.Pos()).Name(), "_test.go") {
+			return
+		}
+
+		for i, stmt := range list {
+			marshal, buf, src, ok := marshalStmt(pass, stmt)
+			if !ok {
+				continue
+			}
+			t := pass.TypesInfo.TypeOf(src)
+			if ptr, ok := t.Underlying().(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			if types.IsInterface(t) {
+				continue
+			}
+
+			next := i + 1
+			if next < len(list) && isErrCheck(list[next]) {
+				next++
+			}
+			for next < len(list) && isVarDecl(list[next]) {
+				next++
+			}
+			if next >= len(list) {
+				continue
+			}
+			unmarshal, ok := unmarshalCall(pass, list[next])
+			if !ok || !isObj(pass, unmarshal.Args[0], buf) {
+				continue
+			}
+			dst, ok := pass.TypesInfo.TypeOf(unmarshal.Args[1]).Underlying().(*types.Pointer)
+			if !ok || !types.Identical(dst.Elem(), t) {
+				continue
+			}
+
+			loss := ""
+			if hasUnexportedFields(t) {
+				loss = " that drops unexported fields"
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos: marshal.Pos(),
+				End: marshal.End(),
+				Message: fmt.Sprintf("json.Marshal followed by json.Unmarshal into %s is a slow deep copy%s; copy the value directly",
+					types.TypeString(t, types.RelativeTo(pass.Pkg)), loss),
+			})
+		}
+	})
+
+	return nil, nil
+}
+
+// hasUnexportedFields reports whether t is a struct type with an unexported
+// field.
+func hasUnexportedFields(t types.Type) bool {
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for field := range st.Fields() {
+		if !field.Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// marshalStmt matches b, err := json.Marshal(src) and its = and blank
+// forms, and returns the call, the object of b, and src.
+func marshalStmt(pass *analysis.Pass, stmt ast.Stmt) (*ast.CallExpr, types.Object, ast.Expr, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return nil, nil, nil, false
+	}
+	call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+	if !ok || !isJSONFunc(pass, call, "Marshal") || len(call.Args) != 1 {
+		return nil, nil, nil, false
+	}
+	id, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, nil, false
+	}
+	obj := pass.TypesInfo.ObjectOf(id)
+	if obj == nil {
+		return nil, nil, nil, false
+	}
+	return call, obj, call.Args[0], true
+}
+
+// unmarshalCall returns the json.Unmarshal call of stmt, which may be the
+// statement itself, an assignment of its result, or the init or condition
+// of an if statement.
+func unmarshalCall(pass *analysis.Pass, stmt ast.Stmt) (*ast.CallExpr, bool) {
+	var expr ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.ExprStmt:
+		expr = stmt.X
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) == 1 {
+			expr = stmt.Rhs[0]
+		}
+	case *ast.IfStmt:
+		if init, ok := stmt.Init.(*ast.AssignStmt); ok && len(init.Rhs) == 1 {
+			expr = init.Rhs[0]
+		} else if cond, ok := stmt.Cond.(*ast.BinaryExpr); ok && stmt.Init == nil {
+			expr = cond.X
+		}
+	}
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || !isJSONFunc(pass, call, "Unmarshal") || len(call.Args) != 2 {
+		return nil, false
+	}
+	return call, true
+}
+
+// isErrCheck reports whether stmt is an if statement without an init whose
+// condition compares against nil, as in if err != nil { ... }.
+func isErrCheck(stmt ast.Stmt) bool {
+	ifStmt, ok := stmt.(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil {
+		return false
+	}
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok || cond.Op != token.NEQ {
+		return false
+	}
+	nilIdent, ok := cond.Y.(*ast.Ident)
+	return ok && nilIdent.Name == "nil"
+}
+
+// isVarDecl reports whether stmt is a var declaration.
+func isVarDecl(stmt ast.Stmt) bool {
+	decl, ok := stmt.(*ast.DeclStmt)
+	if !ok {
+		return false
+	}
+	gen, ok := decl.Decl.(*ast.GenDecl)
+	return ok && gen.Tok == token.VAR
+}
+
+// isJSONFunc reports whether call calls the encoding/json function name.
+func isJSONFunc(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "encoding/json" && fn.Name() == name &&
+		fn.Signature().Recv() == nil
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(id) == obj
+}
@@ -0,0 +1,13 @@
+package jsoncopycheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/jsoncopycheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestJSONCopyCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, jsoncopycheck.Analyzer, "jsoncopytest")
+}
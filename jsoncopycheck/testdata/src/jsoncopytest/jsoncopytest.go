@@ -0,0 +1,92 @@
+package jsoncopytest
+
+import "encoding/json"
+
+type Config struct {
+	Name    string
+	Retries int
+	secret  string
+}
+
+type ConfigDTO struct {
+	Name    string
+	Retries int
+}
+
+func deepCopy(src Config) (Config, error) {
+	// Should be flagged: round trip into the marshaled type.
+	b, err := json.Marshal(src) // want `json\.Marshal followed by json\.Unmarshal into Config is a slow deep copy that drops unexported fields; copy the value directly`
+	if err != nil {
+		return Config{}, err
+	}
+	var dst Config
+	if err := json.Unmarshal(b, &dst); err != nil {
+		return Config{}, err
+	}
+	return dst, nil
+}
+
+func deepCopyPointer(src *Config) *Config {
+	// Should be flagged: the pointer is marshaled as the value it points to.
+	dst := new(Config)
+	b, _ := json.Marshal(src) // want `json\.Marshal followed by json\.Unmarshal into Config is a slow deep copy`
+	_ = json.Unmarshal(b, dst)
+	return dst
+}
+
+func deepCopyMap(src map[string][]int) map[string][]int {
+	// Should be flagged: any non-interface type.
+	var dst map[string][]int
+	b, err := json.Marshal(src) // want `json\.Marshal followed by json\.Unmarshal into map\[string\]\[\]int is a slow deep copy; copy the value directly`
+	if err != nil {
+		panic(err)
+	}
+	json.Unmarshal(b, &dst)
+	return dst
+}
+
+func convert(src Config) (ConfigDTO, error) {
+	// Different type: a conversion, should NOT be flagged.
+	b, err := json.Marshal(src)
+	if err != nil {
+		return ConfigDTO{}, err
+	}
+	var dst ConfigDTO
+	err = json.Unmarshal(b, &dst)
+	return dst, err
+}
+
+func generic(src any) (map[string]any, error) {
+	// Interface source — should NOT be flagged.
+	b, err := json.Marshal(src)
+	if err != nil {
+		return nil, err
+	}
+	var dst any
+	err = json.Unmarshal(b, &dst)
+	return nil, err
+}
+
+func otherBytes(src Config, raw []byte) (Config, error) {
+	// Unmarshals different bytes — should NOT be flagged.
+	b, err := json.Marshal(src)
+	if err != nil {
+		return Config{}, err
+	}
+	_ = b
+	var dst Config
+	err = json.Unmarshal(raw, &dst)
+	return dst, err
+}
+
+func notAdjacent(src Config, send func([]byte)) (Config, error) {
+	// The encoded form is also used — should NOT be flagged.
+	b, err := json.Marshal(src)
+	if err != nil {
+		return Config{}, err
+	}
+	send(b)
+	var dst Config
+	err = json.Unmarshal(b, &dst)
+	return dst, err
+}
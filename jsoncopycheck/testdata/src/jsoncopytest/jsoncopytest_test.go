@@ -0,0 +1,22 @@
+package jsoncopytest
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestConfigRoundTrip(t *testing.T) {
+	// Round trip in a test file — should NOT be flagged.
+	src := Config{Name: "a", Retries: 3}
+	b, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var dst Config
+	if err := json.Unmarshal(b, &dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != src.Name || dst.Retries != src.Retries {
+		t.Errorf("round trip = %+v, want %+v", dst, src)
+	}
+}
@@ -0,0 +1,172 @@
+// Package lockcopycheck defines an analyzer that detects locking through a
+// range value variable, which locks a copy of the element.
+//
+// # Analyzer lockcopycheck
+//
+// lockcopycheck: detect range loops that lock a copy of each element
+//
+// This analyzer flags range loops over a slice or array whose elements
+// contain a lock by value, when the loop body locks or unlocks through the
+// value variable:
+//
+//	for _, c := range counters {
+//	    c.mu.Lock()
+//	    c.n++
+//	    c.mu.Unlock()
+//	}
+//
+// The value variable is a copy of the element, lock included, so the loop
+// locks a mutex nothing else shares and the element itself is never
+// protected. Index the slice instead:
+//
+//	for i := range counters {
+//	    c := &counters[i]
+//	    c.mu.Lock()
+//	    c.n++
+//	    c.mu.Unlock()
+//	}
+//
+// A type contains a lock if it is, or transitively embeds or has a field of,
+// a type whose pointer has Lock and Unlock methods that the value does not,
+// such as sync.Mutex and sync.RWMutex. Fields reached through a pointer are
+// shared, not copied, and are not flagged. No auto-fix is provided.
+package lockcopycheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "lockcopycheck",
+	Doc:      "detect range loops that lock a copy of each element",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// lockMethods are the methods that acquire or release a lock.
+var lockMethods = map[string]bool{
+	"Lock":     true,
+	"Unlock":   true,
+	"TryLock":  true,
+	"RLock":    true,
+	"RUnlock":  true,
+	"TryRLock": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		rng := n.(*ast.RangeStmt)
+		if rng.Tok != token.DEFINE || rng.Value == nil {
+			return
+		}
+		value, ok := rng.Value.(*ast.Ident)
+		if !ok {
+			return
+		}
+		obj := pass.TypesInfo.Defs[value]
+		if obj == nil || !containsLock(obj.Type(), nil) {
+			return
+		}
+
+		ast.Inspect(rng.Body, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !lockMethods[sel.Sel.Name] {
+				return true
+			}
+			if !copiedFrom(pass, sel.X, obj) || !containsLock(pass.TypesInfo.TypeOf(sel.X), nil) {
+				return true
+			}
+			pass.Reportf(call.Pos(),
+				"%s locks a copy: range value %s is a copy of the element; range over indices and use a pointer to the element",
+				types.ExprString(call.Fun)+"()", value.Name)
+			return true
+		})
+	})
+
+	return nil, nil
+}
+
+// copiedFrom reports whether expr is v or a chain of field selections from v
+// that never goes through a pointer, so it denotes part of v's own storage.
+func copiedFrom(pass *analysis.Pass, expr ast.Expr, v types.Object) bool {
+	for {
+		expr = ast.Unparen(expr)
+		if _, isPtr := pass.TypesInfo.TypeOf(expr).Underlying().(*types.Pointer); isPtr {
+			return false
+		}
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return pass.TypesInfo.Uses[e] == v
+		case *ast.SelectorExpr:
+			selection, ok := pass.TypesInfo.Selections[e]
+			if !ok || selection.Kind() != types.FieldVal || selection.Indirect() {
+				return false
+			}
+			expr = e.X
+		default:
+			return false
+		}
+	}
+}
+
+// containsLock reports whether a value of type t holds a lock directly, not
+// through a pointer. seen guards against recursive types.
+func containsLock(t types.Type, seen map[types.Type]bool) bool {
+	if t == nil || seen[t] {
+		return false
+	}
+	if seen == nil {
+		seen = make(map[types.Type]bool)
+	}
+	seen[t] = true
+
+	if isLock(t) {
+		return true
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Struct:
+		for field := range u.Fields() {
+			if containsLock(field.Type(), seen) {
+				return true
+			}
+		}
+	case *types.Array:
+		return containsLock(u.Elem(), seen)
+	}
+	return false
+}
+
+// isLock reports whether t is a named type whose pointer has Lock and Unlock
+// methods that t itself lacks, like sync.Mutex.
+func isLock(t types.Type) bool {
+	if _, ok := t.(*types.Named); !ok {
+		return false
+	}
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return false
+	}
+	ptrSet := types.NewMethodSet(types.NewPointer(t))
+	valSet := types.NewMethodSet(t)
+	for _, name := range []string{"Lock", "Unlock"} {
+		if ptrSet.Lookup(nil, name) == nil || valSet.Lookup(nil, name) != nil {
+			return false
+		}
+	}
+	return true
+}
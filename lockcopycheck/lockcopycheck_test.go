@@ -0,0 +1,13 @@
+package lockcopycheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/lockcopycheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestLockCopyCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, lockcopycheck.Analyzer, "lockcopytest")
+}
@@ -0,0 +1,108 @@
+package lockcopytest
+
+import "sync"
+
+type counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+func incrementAll(counters []counter) {
+	for _, c := range counters {
+		c.mu.Lock() // want `c\.mu\.Lock\(\) locks a copy: range value c is a copy of the element`
+		c.n++
+		c.mu.Unlock() // want `c\.mu\.Unlock\(\) locks a copy`
+	}
+}
+
+type embedded struct {
+	sync.RWMutex
+	data map[string]int
+}
+
+// Promoted methods of an embedded lock.
+func readAll(stores [4]embedded) {
+	for _, s := range stores {
+		s.RLock() // want `s\.RLock\(\) locks a copy`
+		_ = s.data
+		s.RUnlock() // want `s\.RUnlock\(\) locks a copy`
+	}
+}
+
+type outer struct {
+	inner counter
+}
+
+// The lock is nested two fields deep.
+func nested(items []outer) {
+	for _, it := range items {
+		it.inner.mu.Lock()   // want `it\.inner\.mu\.Lock\(\) locks a copy`
+		it.inner.mu.Unlock() // want `it\.inner\.mu\.Unlock\(\) locks a copy`
+	}
+}
+
+// Pointer elements share the lock.
+func pointers(counters []*counter) {
+	for _, c := range counters {
+		c.mu.Lock()
+		c.n++
+		c.mu.Unlock()
+	}
+}
+
+type shared struct {
+	mu *sync.Mutex
+}
+
+// The lock is behind a pointer field, so the copy shares it.
+func pointerField(items []shared) {
+	for _, s := range items {
+		s.mu.Lock()
+		s.mu.Unlock()
+	}
+}
+
+// Indexing locks the element itself.
+func indexed(counters []counter) {
+	for i := range counters {
+		c := &counters[i]
+		c.mu.Lock()
+		c.n++
+		c.mu.Unlock()
+	}
+}
+
+// Reading other fields of the copy without locking is not flagged here.
+func readOnly(counters []counter) int {
+	total := 0
+	for _, c := range counters {
+		total += c.n
+	}
+	return total
+}
+
+type fakeLock struct{}
+
+func (fakeLock) Lock()   {}
+func (fakeLock) Unlock() {}
+
+type withFake struct {
+	l fakeLock
+}
+
+// Value-receiver Lock methods work the same on a copy.
+func fake(items []withFake) {
+	for _, w := range items {
+		w.l.Lock()
+		w.l.Unlock()
+	}
+}
+
+// Locking a different, shared mutex inside the loop is fine.
+func sharedLock(mu *sync.Mutex, counters []counter) {
+	for _, c := range counters {
+		mu.Lock()
+		_ = c.n
+		mu.Unlock()
+	}
+}
@@ -0,0 +1,252 @@
+// Package logsprintfcheck defines an analyzer that detects fmt.Sprintf
+// results passed to log functions that can format on their own.
+//
+// # Analyzer logsprintfcheck
+//
+// logsprintfcheck: detect fmt.Sprintf passed to a log call
+//
+// This analyzer flags log calls whose only message is built with
+// fmt.Sprintf:
+//
+//	log.Print(fmt.Sprintf("x=%d", n))
+//	log.Printf("%s", fmt.Sprintf("x=%d", n))
+//	logger.Println(fmt.Sprintf("x=%d", n))
+//
+// The log package formats its arguments itself, so the intermediate string
+// is unnecessary:
+//
+//	log.Printf("x=%d", n)
+//
+// Calls on the log package and on *log.Logger are covered, for the Print,
+// Fatal, and Panic families. log.Panicln is report-only, since its panic
+// value keeps the trailing newline that Panicf would not add. The fix is
+// also withheld when the format is not a constant (go vet would then flag
+// the Printf call) and when fmt has no use in the file besides the
+// Sprintf calls that can be unwrapped, where applying the fixes would leave
+// an unused import.
+//
+// log/slog calls with a fmt.Sprintf message are reported without a fix:
+// slog messages are meant to be constant, with the varying values passed as
+// attributes.
+package logsprintfcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "logsprintfcheck",
+	Doc:      "detect fmt.Sprintf passed to a log call",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// printfNames maps log functions taking a single message to their Printf
+// counterpart. An empty value means the call is reported without a fix.
+var printfNames = map[string]string{
+	"Print":   "Printf",
+	"Println": "Printf",
+	"Fatal":   "Fatalf",
+	"Fatalln": "Fatalf",
+	"Panic":   "Panicf",
+	"Panicln": "",
+}
+
+// formatNames are the log functions that already take a format.
+var formatNames = map[string]bool{
+	"Printf": true,
+	"Fatalf": true,
+	"Panicf": true,
+}
+
+// slogNames are the log/slog functions and *slog.Logger methods whose first
+// argument is the message.
+var slogNames = map[string]bool{
+	"Debug": true,
+	"Info":  true,
+	"Warn":  true,
+	"Error": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	// Fixes are offered once all calls are known: a fix may only rely on
+	// fmt being used elsewhere if that use is not removed by another fix.
+	var pending []unwrap
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil {
+			return
+		}
+		name := fn.Name()
+
+		switch fn.Pkg().Path() {
+		case "log":
+			if newName, ok := printfNames[name]; ok && len(call.Args) == 1 {
+				pending = appendIf(pending, checkPrint(pass, call, sel, name, newName))
+			} else if formatNames[name] && len(call.Args) == 2 {
+				pending = appendIf(pending, checkPrintf(pass, call, name))
+			}
+		case "log/slog":
+			if slogNames[name] && len(call.Args) >= 1 {
+				if sprintf, ok := call.Args[0].(*ast.CallExpr); ok && isFmtSprintf(pass, sprintf) {
+					pass.Reportf(call.Pos(),
+						"fmt.Sprintf in slog.%s message; keep the message constant and pass values as attributes", name)
+				}
+			}
+		}
+	})
+
+	sprintfs := make([]ast.Node, len(pending))
+	for i, u := range pending {
+		sprintfs[i] = u.sprintf
+	}
+	for _, u := range pending {
+		if fmtStaysUsed(pass, u.sprintf, sprintfs) {
+			u.diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: u.diag.Message, TextEdits: u.edits},
+			}
+		}
+		pass.Report(u.diag)
+	}
+
+	return nil, nil
+}
+
+// unwrap is a diagnostic for a fmt.Sprintf that can be unwrapped into the
+// log call around it, with the edits that do so.
+type unwrap struct {
+	diag    analysis.Diagnostic
+	sprintf *ast.CallExpr
+	edits   []analysis.TextEdit
+}
+
+// appendIf appends u to pending unless u is nil.
+func appendIf(pending []unwrap, u *unwrap) []unwrap {
+	if u == nil {
+		return pending
+	}
+	return append(pending, *u)
+}
+
+// checkPrint reports log.Print(fmt.Sprintf(format, args...)), suggesting
+// log.Printf(format, args...). newName is the Printf counterpart of name.
+// It returns the diagnostic instead of reporting it when the fix may apply.
+func checkPrint(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, name, newName string) *unwrap {
+	sprintf, ok := call.Args[0].(*ast.CallExpr)
+	if !ok || !isFmtSprintf(pass, sprintf) {
+		return nil
+	}
+
+	if newName == "" {
+		pass.Reportf(call.Pos(), "fmt.Sprintf passed to %s; format the message with the log call instead", name)
+		return nil
+	}
+
+	msg := fmt.Sprintf("fmt.Sprintf passed to %s; use %s with the format and arguments directly", name, newName)
+	diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+	if !constFormat(pass, sprintf) {
+		pass.Report(diag)
+		return nil
+	}
+	edits := []analysis.TextEdit{
+		{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte(newName)},
+	}
+	return &unwrap{diag, sprintf, append(edits, unwrapEdits(sprintf)...)}
+}
+
+// checkPrintf reports log.Printf("%s", fmt.Sprintf(format, args...)),
+// suggesting log.Printf(format, args...). It returns the diagnostic instead
+// of reporting it when the fix may apply.
+func checkPrintf(pass *analysis.Pass, call *ast.CallExpr, name string) *unwrap {
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+	if format := constant.StringVal(tv.Value); format != "%s" && format != "%v" {
+		return nil
+	}
+	sprintf, ok := call.Args[1].(*ast.CallExpr)
+	if !ok || !isFmtSprintf(pass, sprintf) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("fmt.Sprintf passed to %s; pass the format and arguments directly", name)
+	diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+	if !constFormat(pass, sprintf) {
+		pass.Report(diag)
+		return nil
+	}
+	edits := []analysis.TextEdit{
+		{Pos: call.Args[0].Pos(), End: sprintf.Pos()},
+	}
+	return &unwrap{diag, sprintf, append(edits, unwrapEdits(sprintf)...)}
+}
+
+// unwrapEdits returns TextEdits that remove "fmt.Sprintf(" and the matching
+// ")" around sprintf's arguments.
+func unwrapEdits(sprintf *ast.CallExpr) []analysis.TextEdit {
+	return []analysis.TextEdit{
+		{Pos: sprintf.Pos(), End: sprintf.Lparen + 1},
+		{Pos: sprintf.Rparen, End: sprintf.Rparen + 1},
+	}
+}
+
+// constFormat reports whether the format of sprintf is a constant, so that
+// its arguments can be passed to a Printf-style log call.
+func constFormat(pass *analysis.Pass, sprintf *ast.CallExpr) bool {
+	if len(sprintf.Args) == 0 {
+		return false
+	}
+	tv, ok := pass.TypesInfo.Types[sprintf.Args[0]]
+	return ok && tv.Value != nil
+}
+
+// fmtStaysUsed reports whether fmt remains in use in the file of sprintf
+// once all of sprintfs are unwrapped.
+func fmtStaysUsed(pass *analysis.Pass, sprintf *ast.CallExpr, sprintfs []ast.Node) bool {
+	file := importutil.FindFileForPos(pass, sprintf.Pos())
+	if file == nil {
+		return false
+	}
+	fmtPkg := pass.TypesInfo.ObjectOf(sprintf.Fun.(*ast.SelectorExpr).X.(*ast.Ident))
+	return importutil.UsedOutside(pass, file, fmtPkg, sprintfs...)
+}
+
+// isFmtSprintf reports whether call is fmt.Sprintf.
+func isFmtSprintf(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Sprintf" {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return false
+	}
+
+	return pkgName.Imported().Path() == "fmt"
+}
@@ -0,0 +1,13 @@
+package logsprintfcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/logsprintfcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestLogSprintfCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, logsprintfcheck.Analyzer, "logsprintftest")
+}
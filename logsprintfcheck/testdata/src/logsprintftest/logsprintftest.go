@@ -0,0 +1,49 @@
+package logsprintftest
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+func example(n int, name string, logger *log.Logger) {
+	// Should be flagged: Print with Sprintf.
+	log.Print(fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf passed to Print; use Printf with the format and arguments directly`
+
+	// Should be flagged: Println with Sprintf, multiple args.
+	log.Println(fmt.Sprintf("%s=%d", name, n)) // want `fmt\.Sprintf passed to Println; use Printf with the format and arguments directly`
+
+	// Should be flagged: Printf("%s", Sprintf(...)).
+	log.Printf("%s", fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf passed to Printf; pass the format and arguments directly`
+
+	// Should be flagged: *log.Logger method.
+	logger.Print(fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf passed to Print; use Printf with the format and arguments directly`
+
+	// Should be flagged without a fix: non-constant format.
+	log.Print(fmt.Sprintf(name)) // want `fmt\.Sprintf passed to Print; use Printf`
+
+	// Should be flagged without a fix: Panicln keeps the newline in its panic value.
+	if n < 0 {
+		log.Panicln(fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf passed to Panicln; format the message with the log call instead`
+	}
+
+	// Should be flagged without a fix: slog messages should be constant.
+	slog.Info(fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf in slog\.Info message; keep the message constant and pass values as attributes`
+}
+
+func noMatch(n int, name string) {
+	// Already formats directly — should NOT be flagged.
+	log.Printf("n=%d", n)
+
+	// Sprintf is one of several arguments — should NOT be flagged.
+	log.Print("prefix: ", fmt.Sprintf("n=%d", n))
+
+	// Printf with a format other than %s/%v — should NOT be flagged.
+	log.Printf("value %s", fmt.Sprintf("n=%d", n))
+
+	// Not a log call — should NOT be flagged.
+	fmt.Println(fmt.Sprintf("n=%d", n))
+
+	// slog with a constant message — should NOT be flagged.
+	slog.Info("count", "n", n, "name", name)
+}
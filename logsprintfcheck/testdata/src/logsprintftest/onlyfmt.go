@@ -0,0 +1,12 @@
+package logsprintftest
+
+import (
+	"fmt"
+	"log"
+)
+
+// fmt is only used in the flagged call; removing it would leave an unused
+// import, so no fix is offered.
+func onlyUse(n int) {
+	log.Print(fmt.Sprintf("n=%d", n)) // want `fmt\.Sprintf passed to Print; use Printf`
+}
@@ -0,0 +1,17 @@
+package logsprintftest
+
+import (
+	"fmt"
+	"log"
+)
+
+// fmt is only used in the two flagged calls. Each fix alone would keep
+// the other use, but applying both would leave an unused import, so
+// neither is offered.
+func firstCall(n int) {
+	log.Print(fmt.Sprintf("first=%d", n)) // want `fmt\.Sprintf passed to Print; use Printf`
+}
+
+func secondCall(n int) {
+	log.Println(fmt.Sprintf("second=%d", n)) // want `fmt\.Sprintf passed to Println; use Printf`
+}
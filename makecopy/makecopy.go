@@ -15,7 +15,18 @@
 //
 //	dst := slices.Clone(src)
 //
-// Available since Go 1.21.
+// A slice sized for two sources and filled by two positioned copies is also
+// flagged:
+//
+//	dst := make([]T, len(a)+len(b))
+//	copy(dst, a)
+//	copy(dst[len(a):], b)
+//
+// and can be replaced with:
+//
+//	dst := slices.Concat(a, b)
+//
+// Available since Go 1.21; slices.Concat since Go 1.22.
 package makecopy
 
 import (
@@ -57,6 +68,9 @@ func run(pass *analysis.Pass) (any, error) {
 
 		for i := 0; i < len(block.List)-1; i++ {
 			checkPair(pass, block.List[i], block.List[i+1], importEditAdded)
+			if i+2 < len(block.List) {
+				checkConcat(pass, block.List[i], block.List[i+1], block.List[i+2], importEditAdded)
+			}
 		}
 	})
 
@@ -158,7 +172,7 @@ func checkPair(pass *analysis.Pass, s1, s2 ast.Stmt, importEditAdded map[string]
 		file := importutil.FindFileForPos(pass, assign.Pos())
 		fileName := pass.Fset.File(assign.Pos()).Name()
 		if file != nil && !importEditAdded[fileName] {
-			if ie := importutil.AddImportEdit(file, "slices"); ie != nil {
+			if ie := importutil.AddImportEdit(pass, file, "slices"); ie != nil {
 				edits = append(edits, *ie)
 				importEditAdded[fileName] = true
 			}
@@ -177,6 +191,129 @@ func checkPair(pass *analysis.Pass, s1, s2 ast.Stmt, importEditAdded map[string]
 	}
 }
 
+// checkConcat checks whether three consecutive statements concatenate two
+// slices by hand:
+//
+//	name := make([]T, len(a)+len(b))
+//	copy(name, a)
+//	copy(name[len(a):], b)
+func checkConcat(pass *analysis.Pass, s1, s2, s3 ast.Stmt, importEditAdded map[string]bool) {
+	// Statement 1: name := make([]T, len(a)+len(b))
+	assign, ok := s1.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+	dstIdent, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	makeCall, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(makeCall.Args) != 2 {
+		return
+	}
+	makeFun, ok := makeCall.Fun.(*ast.Ident)
+	if !ok || makeFun.Name != "make" {
+		return
+	}
+	if obj := pass.TypesInfo.ObjectOf(makeFun); obj != nil && obj.Pkg() != nil {
+		return // not the builtin
+	}
+	if _, ok := makeCall.Args[0].(*ast.ArrayType); !ok {
+		return
+	}
+	sum, ok := makeCall.Args[1].(*ast.BinaryExpr)
+	if !ok || sum.Op != token.ADD {
+		return
+	}
+	lenA, okA := sum.X.(*ast.CallExpr)
+	lenB, okB := sum.Y.(*ast.CallExpr)
+	if !okA || !okB || !isBuiltinLen(pass, lenA) || !isBuiltinLen(pass, lenB) {
+		return
+	}
+	a, b := lenA.Args[0], lenB.Args[0]
+
+	// Statement 2: copy(name, a)
+	dst, src, ok := copyArgs(pass, s2)
+	if !ok || !sameExpr(pass, dst, dstIdent) || !sameExpr(pass, src, a) {
+		return
+	}
+
+	// Statement 3: copy(name[len(a):], b)
+	dst, src, ok = copyArgs(pass, s3)
+	if !ok || !sameExpr(pass, src, b) {
+		return
+	}
+	offset, ok := dst.(*ast.SliceExpr)
+	if !ok || offset.High != nil || offset.Max != nil || !sameExpr(pass, offset.X, dstIdent) {
+		return
+	}
+	lenOffset, ok := offset.Low.(*ast.CallExpr)
+	if !ok || !isBuiltinLen(pass, lenOffset) || !sameExpr(pass, lenOffset.Args[0], a) {
+		return
+	}
+
+	// slices.Concat returns the type of its arguments, which must match the
+	// type that make allocated.
+	makeType := pass.TypesInfo.TypeOf(makeCall)
+	if !types.Identical(pass.TypesInfo.TypeOf(a), makeType) || !types.Identical(pass.TypesInfo.TypeOf(b), makeType) {
+		return
+	}
+
+	aStr, bStr := types.ExprString(a), types.ExprString(b)
+	msg := fmt.Sprintf("make+copy+copy can be simplified to %s := slices.Concat(%s, %s)",
+		dstIdent.Name, aStr, bStr)
+	newText := fmt.Sprintf("%s := slices.Concat(%s, %s)", dstIdent.Name, aStr, bStr)
+
+	edits := []analysis.TextEdit{
+		{
+			Pos:     assign.Pos(),
+			End:     s3.End(),
+			NewText: []byte(newText),
+		},
+	}
+
+	// Add "slices" import if not already added for this file.
+	file := importutil.FindFileForPos(pass, assign.Pos())
+	fileName := pass.Fset.File(assign.Pos()).Name()
+	if file != nil && !importEditAdded[fileName] {
+		if ie := importutil.AddImportEdit(pass, file, "slices"); ie != nil {
+			edits = append(edits, *ie)
+			importEditAdded[fileName] = true
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     assign.Pos(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message:   msg,
+				TextEdits: edits,
+			},
+		},
+	})
+}
+
+// copyArgs returns the arguments of stmt if it is a call to the builtin copy.
+func copyArgs(pass *analysis.Pass, stmt ast.Stmt) (dst, src ast.Expr, ok bool) {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil, nil, false
+	}
+	copyCall, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(copyCall.Args) != 2 {
+		return nil, nil, false
+	}
+	copyFun, ok := copyCall.Fun.(*ast.Ident)
+	if !ok || copyFun.Name != "copy" {
+		return nil, nil, false
+	}
+	if obj := pass.TypesInfo.ObjectOf(copyFun); obj != nil && obj.Pkg() != nil {
+		return nil, nil, false // not the builtin
+	}
+	return copyCall.Args[0], copyCall.Args[1], true
+}
+
 // matchLenSource reports whether lenArg is a length expression that matches
 // copySrc. It handles these forms:
 //
@@ -0,0 +1,12 @@
+package makecopytest
+
+import str "strings"
+
+// A single aliased import is expanded to a grouped import that keeps the
+// alias.
+func aliasedImport() {
+	src := []string{"a", "b"}
+	dst := make([]string, len(src)) // want `make\+copy can be simplified to dst := slices\.Clone\(src\)`
+	copy(dst, src)
+	_ = str.Join(dst, ",")
+}
@@ -0,0 +1,53 @@
+package makecopytest
+
+func concat(a, b []int) []int {
+	out := make([]int, len(a)+len(b)) // want `make\+copy\+copy can be simplified to out := slices\.Concat\(a, b\)`
+	copy(out, a)
+	copy(out[len(a):], b)
+	return out
+}
+
+type pair struct {
+	head, tail []string
+}
+
+func concatFields(p pair) []string {
+	all := make([]string, len(p.head)+len(p.tail)) // want `make\+copy\+copy can be simplified to all := slices\.Concat\(p\.head, p\.tail\)`
+	copy(all, p.head)
+	copy(all[len(p.head):], p.tail)
+	return all
+}
+
+// The second copy starts at the wrong offset.
+func wrongOffset(a, b []int) []int {
+	out := make([]int, len(a)+len(b))
+	copy(out, a)
+	copy(out[len(b):], b)
+	return out
+}
+
+// The copies come in the other order from the lengths.
+func swapped(a, b []int) []int {
+	out := make([]int, len(a)+len(b))
+	copy(out, b)
+	copy(out[len(b):], a)
+	return out
+}
+
+type ids []int
+
+// slices.Concat would return ids, not []int.
+func namedSources(a, b ids) []int {
+	out := make([]int, len(a)+len(b))
+	copy(out, a)
+	copy(out[len(a):], b)
+	return out
+}
+
+// Extra capacity is not a plain concatenation.
+func withCap(a, b []int) []int {
+	out := make([]int, len(a)+len(b), 2*(len(a)+len(b)))
+	copy(out, a)
+	copy(out[len(a):], b)
+	return out
+}
@@ -0,0 +1,232 @@
+// Package mapkeyscancheck defines an analyzer that detects loops scanning a
+// map's keys for a single key.
+//
+// # Analyzer mapkeyscancheck
+//
+// mapkeyscancheck: detect range-over-map key scans that can be a direct lookup
+//
+// This analyzer flags loops that range over a map and compare each key with
+// a target to set a found flag:
+//
+//	found := false
+//	for k := range m {
+//	    if k == target {
+//	        found = true
+//	        break
+//	    }
+//	}
+//
+// This is an O(n) scan for what a map does in O(1):
+//
+//	_, found := m[target]
+//
+// The auto-fix is offered when the flag is declared false immediately
+// before the loop and the if body only sets the flag (optionally followed
+// by break). Otherwise the loop is reported without a fix, for example when
+// the body also uses the map value.
+package mapkeyscancheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "mapkeyscancheck",
+	Doc:      "detect range-over-map key scans that can be a direct lookup",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i, stmt := range block.List {
+			loop, ok := stmt.(*ast.RangeStmt)
+			if !ok {
+				continue
+			}
+			var prev ast.Stmt
+			if i > 0 {
+				prev = block.List[i-1]
+			}
+			checkLoop(pass, loop, prev)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkLoop reports loop if it scans a map's keys for a target. prev is the
+// statement before the loop, if any.
+func checkLoop(pass *analysis.Pass, loop *ast.RangeStmt, prev ast.Stmt) {
+	t := pass.TypesInfo.TypeOf(loop.X)
+	if t == nil {
+		return
+	}
+	mapType, ok := t.Underlying().(*types.Map)
+	if !ok {
+		return
+	}
+
+	keyIdent, ok := loop.Key.(*ast.Ident)
+	if !ok || keyIdent.Name == "_" {
+		return
+	}
+	key := pass.TypesInfo.ObjectOf(keyIdent)
+	var value types.Object
+	if valueIdent, ok := loop.Value.(*ast.Ident); ok && valueIdent.Name != "_" {
+		value = pass.TypesInfo.ObjectOf(valueIdent)
+	}
+
+	// The body is a single if comparing the key to the target.
+	if len(loop.Body.List) != 1 {
+		return
+	}
+	ifStmt, ok := loop.Body.List[0].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) == 0 {
+		return
+	}
+	target := keyComparison(pass, ifStmt.Cond, key)
+	if target == nil || !isPure(target) || refersTo(pass, target, key) || (value != nil && refersTo(pass, target, value)) {
+		return
+	}
+	targetType := pass.TypesInfo.TypeOf(target)
+	if targetType == nil || !types.AssignableTo(targetType, mapType.Key()) {
+		return
+	}
+
+	// The if body starts by setting a bool flag to true.
+	flagAssign, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+	if !ok || flagAssign.Tok != token.ASSIGN || len(flagAssign.Lhs) != 1 || len(flagAssign.Rhs) != 1 {
+		return
+	}
+	flagIdent, ok := flagAssign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	flag := pass.TypesInfo.ObjectOf(flagIdent)
+	if flag == nil || !types.Identical(flag.Type(), types.Typ[types.Bool]) || !isTrue(pass, flagAssign.Rhs[0]) {
+		return
+	}
+
+	lookup := fmt.Sprintf("_, %s := %s[%s]", flagIdent.Name, types.ExprString(loop.X), types.ExprString(target))
+	msg := fmt.Sprintf("loop scans map keys for %s; use %s", types.ExprString(target), lookup)
+	diag := analysis.Diagnostic{Pos: loop.Pos(), Message: msg}
+
+	rest := ifStmt.Body.List[1:]
+	simpleBody := len(rest) == 0 || (len(rest) == 1 && isBreak(rest[0]))
+	if simpleBody && (value == nil || !refersTo(pass, loop.Body, value)) && declaresFalse(pass, prev, flag) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: []analysis.TextEdit{
+					{Pos: prev.Pos(), End: loop.End(), NewText: []byte(lookup)},
+				},
+			},
+		}
+	}
+
+	pass.Report(diag)
+}
+
+// keyComparison returns the other operand if cond is key == x or x == key.
+func keyComparison(pass *analysis.Pass, cond ast.Expr, key types.Object) ast.Expr {
+	binExpr, ok := cond.(*ast.BinaryExpr)
+	if !ok || binExpr.Op != token.EQL {
+		return nil
+	}
+	if ident, ok := binExpr.X.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == key {
+		return binExpr.Y
+	}
+	if ident, ok := binExpr.Y.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == key {
+		return binExpr.X
+	}
+	return nil
+}
+
+// isPure reports whether expr can be evaluated once instead of on every
+// iteration without changing behavior.
+func isPure(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.Ident, *ast.BasicLit:
+		return true
+	case *ast.SelectorExpr:
+		return isPure(e.X)
+	case *ast.ParenExpr:
+		return isPure(e.X)
+	}
+	return false
+}
+
+// refersTo reports whether node mentions obj.
+func refersTo(pass *analysis.Pass, node ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isTrue reports whether expr is the predeclared true.
+func isTrue(pass *analysis.Pass, expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(ident) == types.Universe.Lookup("true")
+}
+
+// isBreak reports whether stmt is an unlabeled break.
+func isBreak(stmt ast.Stmt) bool {
+	br, ok := stmt.(*ast.BranchStmt)
+	return ok && br.Tok == token.BREAK && br.Label == nil
+}
+
+// declaresFalse reports whether stmt declares flag with a false value:
+//
+//	found := false
+//	var found bool
+//	var found = false
+func declaresFalse(pass *analysis.Pass, stmt ast.Stmt, flag types.Object) bool {
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return false
+		}
+		ident, ok := stmt.Lhs[0].(*ast.Ident)
+		if !ok || pass.TypesInfo.Defs[ident] != flag {
+			return false
+		}
+		rhs, ok := stmt.Rhs[0].(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(rhs) == types.Universe.Lookup("false")
+
+	case *ast.DeclStmt:
+		gd, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR || len(gd.Specs) != 1 {
+			return false
+		}
+		spec := gd.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || pass.TypesInfo.Defs[spec.Names[0]] != flag {
+			return false
+		}
+		if len(spec.Values) == 0 {
+			return true
+		}
+		rhs, ok := spec.Values[0].(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(rhs) == types.Universe.Lookup("false")
+	}
+	return false
+}
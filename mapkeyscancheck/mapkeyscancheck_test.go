@@ -0,0 +1,13 @@
+package mapkeyscancheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/mapkeyscancheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMapKeyScanCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, mapkeyscancheck.Analyzer, "mapkeyscantest")
+}
@@ -0,0 +1,105 @@
+package mapkeyscantest
+
+type config struct {
+	name string
+}
+
+func fixable(m map[string]int, target string, c config) {
+	// Should be flagged: := false, break.
+	found := false
+	for k := range m { // want `loop scans map keys for target; use _, found := m\[target\]`
+		if k == target {
+			found = true
+			break
+		}
+	}
+	_ = found
+
+	// Should be flagged: var declaration, target on the left, no break.
+	var ok bool
+	for key := range m { // want `loop scans map keys for c\.name; use _, ok := m\[c\.name\]`
+		if c.name == key {
+			ok = true
+		}
+	}
+	_ = ok
+
+	// Should be flagged: unused value variable.
+	has := false
+	for k, _ := range m { // want `loop scans map keys for "x"; use _, has := m\["x"\]`
+		if k == "x" {
+			has = true
+		}
+	}
+	_ = has
+}
+
+func reportOnly(m map[string]int, target string) int {
+	// Should be flagged without a fix: the body also uses the value.
+	found := false
+	total := 0
+	for k, v := range m { // want `loop scans map keys for target`
+		if k == target {
+			found = true
+			total += v
+		}
+	}
+	_ = found
+
+	// Should be flagged without a fix: flag not declared right before the loop.
+	seen := false
+	total++
+	for k := range m { // want `loop scans map keys for target`
+		if k == target {
+			seen = true
+		}
+	}
+	_ = seen
+	return total
+}
+
+func target() string { return "x" }
+
+func noMatch(m map[string]int, s []string, t string, keys map[any]bool) {
+	found := false
+
+	// Ranging over a slice — should NOT be flagged.
+	for _, k := range s {
+		if k == t {
+			found = true
+		}
+	}
+
+	// Comparison is not equality — should NOT be flagged.
+	for k := range m {
+		if k < t {
+			found = true
+		}
+	}
+
+	// Target is a call that would only run once — should NOT be flagged.
+	for k := range m {
+		if k == target() {
+			found = true
+		}
+	}
+
+	// Compares the value, not the key — should NOT be flagged.
+	for _, v := range m {
+		if v == 3 {
+			found = true
+		}
+	}
+
+	// Interface target against a concrete key type — m[x] would not
+	// compile, should NOT be flagged.
+	var x any = "a"
+	for k := range m {
+		if k == x {
+			found = true
+		}
+	}
+
+	_ = found
+	_ = keys
+}
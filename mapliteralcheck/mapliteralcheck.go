@@ -0,0 +1,243 @@
+// Package mapliteralcheck defines an analyzer that detects maps built with
+// make and then filled by a run of inserts, where a map literal would do.
+//
+// # Analyzer mapliteralcheck
+//
+// mapliteralcheck: detect make(map) followed by inserts that can be a map literal
+//
+// This analyzer flags a map created with make and no size hint when the
+// statements right after it insert into it:
+//
+//	m := make(map[string]int)
+//	m["a"] = 1
+//	m["b"] = 2
+//
+// A composite literal says the same in one statement:
+//
+//	m := map[string]int{
+//	    "a": 1,
+//	    "b": 2,
+//	}
+//
+// A suggested fix is offered when every key is a distinct constant, no value
+// refers to the map, and no comments sit between the statements. Otherwise,
+// for example when keys are computed and might collide, the diagnostic is
+// report-only.
+package mapliteralcheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "mapliteralcheck",
+	Doc:      "detect make(map) followed by inserts that can be a map literal",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i, stmt := range block.List {
+			assign, m, ok := makeMap(pass, stmt)
+			if !ok {
+				continue
+			}
+			var inserts []*ast.AssignStmt
+			for _, next := range block.List[i+1:] {
+				ins, ok := insertInto(pass, next, m)
+				if !ok {
+					break
+				}
+				inserts = append(inserts, ins)
+			}
+			if len(inserts) == 0 {
+				continue
+			}
+
+			msg := "make followed by inserts into " + m.Name() + " can be a map literal"
+			diag := analysis.Diagnostic{Pos: assign.Pos(), Message: msg}
+			if canFix(pass, assign, inserts, m) {
+				if edits := buildFix(pass, assign, inserts); edits != nil {
+					diag.SuggestedFixes = []analysis.SuggestedFix{
+						{Message: "use a map literal", TextEdits: edits},
+					}
+				}
+			}
+			pass.Report(diag)
+		}
+	})
+
+	return nil, nil
+}
+
+// makeMap matches m := make(map[K]V) with no size hint and returns the
+// statement and m.
+func makeMap(pass *analysis.Pass, stmt ast.Stmt) (*ast.AssignStmt, types.Object, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, nil, false
+	}
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "make" {
+		return nil, nil, false
+	}
+	if _, ok := pass.TypesInfo.ObjectOf(fun).(*types.Builtin); !ok {
+		return nil, nil, false
+	}
+	if _, ok := call.Args[0].(*ast.MapType); !ok {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	if obj == nil {
+		return nil, nil, false
+	}
+	return assign, obj, true
+}
+
+// insertInto matches m[k] = v for the map m.
+func insertInto(pass *analysis.Pass, stmt ast.Stmt, m types.Object) (*ast.AssignStmt, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	idx, ok := assign.Lhs[0].(*ast.IndexExpr)
+	if !ok {
+		return nil, false
+	}
+	ident, ok := idx.X.(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[ident] != m {
+		return nil, false
+	}
+	return assign, true
+}
+
+// canFix reports whether the inserts can move into a literal unchanged: the
+// keys are distinct constants, the values do not read the map, and no
+// comments would be lost. A comment at the end of the make line is kept.
+func canFix(pass *analysis.Pass, assign *ast.AssignStmt, inserts []*ast.AssignStmt, m types.Object) bool {
+	var keys []constant.Value
+	for _, ins := range inserts {
+		key := ins.Lhs[0].(*ast.IndexExpr).Index
+		kv := pass.TypesInfo.Types[key].Value
+		if kv == nil {
+			return false
+		}
+		for _, prev := range keys {
+			if constant.Compare(prev, token.EQL, kv) {
+				return false // duplicate keys do not compile in a literal
+			}
+		}
+		keys = append(keys, kv)
+		if mentions(pass, ins.Rhs[0], m) {
+			return false
+		}
+	}
+
+	file := importutil.FindFileForPos(pass, assign.Pos())
+	if file == nil {
+		return false
+	}
+	makeLine := pass.Fset.Position(assign.Pos()).Line
+	for _, cg := range file.Comments {
+		if cg.Pos() < assign.End() || cg.Pos() > inserts[len(inserts)-1].End() {
+			continue
+		}
+		if cg.Pos() > inserts[0].Pos() || pass.Fset.Position(cg.Pos()).Line != makeLine {
+			return false
+		}
+	}
+	return true
+}
+
+// buildFix returns TextEdits replacing the make and the inserts with a map
+// literal. A single insert is folded onto the make line; otherwise, or when
+// the make line ends in a comment, the literal has one element per line and
+// the comment stays after the opening brace.
+func buildFix(pass *analysis.Pass, assign *ast.AssignStmt, inserts []*ast.AssignStmt) []analysis.TextEdit {
+	tokFile := pass.Fset.File(assign.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	text := func(n ast.Node) []byte {
+		return src[tokFile.Offset(n.Pos()):tokFile.Offset(n.End())]
+	}
+	lineStart := tokFile.LineStart(tokFile.Line(assign.Pos()))
+	indent := src[tokFile.Offset(lineStart):tokFile.Offset(assign.Pos())]
+	makeCall := assign.Rhs[0].(*ast.CallExpr)
+	mapType := text(makeCall.Args[0])
+	last := inserts[len(inserts)-1]
+
+	elem := func(buf *bytes.Buffer, ins *ast.AssignStmt) {
+		buf.Write(text(ins.Lhs[0].(*ast.IndexExpr).Index))
+		buf.WriteString(": ")
+		buf.Write(text(ins.Rhs[0]))
+	}
+
+	// canFix allows only a comment at the end of the make line here.
+	between := src[tokFile.Offset(assign.End()):tokFile.Offset(inserts[0].Pos())]
+	trailingComment := len(bytes.TrimSpace(between)) > 0
+	if len(inserts) == 1 && !trailingComment {
+		var buf bytes.Buffer
+		buf.Write(mapType)
+		buf.WriteByte('{')
+		elem(&buf, inserts[0])
+		buf.WriteByte('}')
+		return []analysis.TextEdit{
+			{Pos: makeCall.Pos(), End: last.End(), NewText: buf.Bytes()},
+		}
+	}
+
+	var buf bytes.Buffer
+	for i, ins := range inserts {
+		if i > 0 {
+			buf.Write(indent)
+		}
+		buf.WriteByte('\t')
+		elem(&buf, ins)
+		buf.WriteString(",\n")
+	}
+	buf.Write(indent)
+	buf.WriteByte('}')
+	return []analysis.TextEdit{
+		{Pos: makeCall.Pos(), End: makeCall.End(), NewText: append(append([]byte{}, mapType...), '{')},
+		{Pos: inserts[0].Pos(), End: last.End(), NewText: buf.Bytes()},
+	}
+}
+
+// mentions reports whether expr uses obj.
+func mentions(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,13 @@
+package mapliteralcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/mapliteralcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMapLiteralCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, mapliteralcheck.Analyzer, "mapliteraltest")
+}
@@ -0,0 +1,84 @@
+package mapliteraltest
+
+import "strings"
+
+const keyC = "c"
+
+func single() map[string]int {
+	m := make(map[string]int) // want `make followed by inserts into m can be a map literal`
+	m["a"] = 1
+	return m
+}
+
+func multi(v int) map[string]int {
+	m := make(map[string]int) // want `make followed by inserts into m can be a map literal`
+	m["a"] = 1
+	m["b"] = v * 2
+	m[keyC] = len("c")
+	return m
+}
+
+type point struct{ x, y int }
+
+func nestedBlock(ok bool) {
+	if ok {
+		seen := make(map[int]point) // want `make followed by inserts into seen can be a map literal`
+		seen[1] = point{1, 2}
+		seen[2] = point{3, 4}
+		_ = seen
+	}
+}
+
+// Computed keys may collide: report-only.
+func computed(name string) map[string]bool {
+	m := make(map[string]bool) // want `make followed by inserts into m can be a map literal`
+	m[strings.ToLower(name)] = true
+	m[strings.ToUpper(name)] = true
+	return m
+}
+
+// Repeated constant keys would not compile in a literal: report-only.
+func repeated() map[int]string {
+	m := make(map[int]string) // want `make followed by inserts into m can be a map literal`
+	m[1] = "a"
+	m[1] = "b"
+	return m
+}
+
+// A value reading the map: report-only.
+func selfReferential() map[string]int {
+	m := make(map[string]int) // want `make followed by inserts into m can be a map literal`
+	m["a"] = 1
+	m["b"] = len(m)
+	return m
+}
+
+// Comments between the statements would be lost: report-only.
+func commented() map[string]int {
+	m := make(map[string]int) // want `make followed by inserts into m can be a map literal`
+	// The answer.
+	m["a"] = 42
+	return m
+}
+
+// A size hint is kept deliberately.
+func sized() map[string]int {
+	m := make(map[string]int, 64)
+	m["a"] = 1
+	return m
+}
+
+// Other statements first.
+func interleaved() map[string]int {
+	m := make(map[string]int)
+	println("building")
+	m["a"] = 1
+	return m
+}
+
+// Not a map.
+func slice() []int {
+	s := make([]int, 1)
+	s[0] = 1
+	return s
+}
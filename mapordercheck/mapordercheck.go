@@ -0,0 +1,218 @@
+// Package mapordercheck defines an analyzer that detects slices collected
+// from a map in iteration order and never sorted.
+//
+// # Analyzer mapordercheck
+//
+// mapordercheck: detect slices built from a map range without a sort
+//
+// This analyzer flags range loops over a map that append the key or value
+// to a slice, when the function never sorts that slice afterwards:
+//
+//	var names []string
+//	for name := range users {
+//	    names = append(names, name)
+//	}
+//	return names
+//
+// Map iteration order is not specified and differs between runs, so the
+// slice comes out in a different order each time: output, golden files,
+// and anything comparing results become flaky. Sorting after the loop fixes
+// the order:
+//
+//	slices.Sort(names)
+//
+// A call after the loop to sort.Strings, sort.Ints, sort.Float64s,
+// sort.Slice, sort.SliceStable, sort.Sort, sort.Stable, slices.Sort,
+// slices.SortFunc, or slices.SortStableFunc whose arguments mention the
+// slice counts as sorting it. The slice must be a local variable, and the
+// appended values must mention a range variable. Diagnostics are
+// report-only, since some callers do not care about the order.
+package mapordercheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "mapordercheck",
+	Doc:      "detect slices built from a map range without a sort",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// sortFuncs lists the functions that put a slice in a defined order.
+var sortFuncs = map[string]map[string]bool{
+	"sort": {
+		"Strings": true, "Ints": true, "Float64s": true,
+		"Slice": true, "SliceStable": true, "Sort": true, "Stable": true,
+	},
+	"slices": {
+		"Sort": true, "SortFunc": true, "SortStableFunc": true,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		rng := n.(*ast.RangeStmt)
+		t := pass.TypesInfo.TypeOf(rng.X)
+		if t == nil {
+			return true
+		}
+		if _, ok := t.Underlying().(*types.Map); !ok {
+			return true
+		}
+		body := enclosingFuncBody(stack)
+		if body == nil {
+			return true
+		}
+
+		for _, s := range collected(pass, rng) {
+			if sortedAfter(pass, body, rng, s) {
+				continue
+			}
+			pass.Reportf(rng.Pos(),
+				"%s is filled in map iteration order, which changes between runs; sort it after the loop",
+				s.Name())
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingFuncBody returns the body of the innermost function in stack.
+func enclosingFuncBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncDecl:
+			return n.Body
+		case *ast.FuncLit:
+			return n.Body
+		}
+	}
+	return nil
+}
+
+// collected returns the local slice variables declared outside rng that
+// its body appends a value mentioning a range variable to, in order of
+// first append.
+func collected(pass *analysis.Pass, rng *ast.RangeStmt) []types.Object {
+	var rangeVars []types.Object
+	for _, expr := range []ast.Expr{rng.Key, rng.Value} {
+		if ident, ok := expr.(*ast.Ident); ok && ident.Name != "_" {
+			if obj := pass.TypesInfo.ObjectOf(ident); obj != nil {
+				rangeVars = append(rangeVars, obj)
+			}
+		}
+	}
+	if len(rangeVars) == 0 {
+		return nil
+	}
+
+	var out []types.Object
+	seen := map[types.Object]bool{}
+	ast.Inspect(rng.Body, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return true
+		}
+		s, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+		if !ok || seen[s] || s.Parent() == pass.Pkg.Scope() || (s.Pos() >= rng.Pos() && s.Pos() < rng.End()) {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 || !isBuiltin(pass, call.Fun, "append") || !isObj(pass, call.Args[0], s) {
+			return true
+		}
+		for _, arg := range call.Args[1:] {
+			if mentionsAny(pass, arg, rangeVars) {
+				seen[s] = true
+				out = append(out, s)
+				break
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// sortedAfter reports whether body calls a sort function mentioning s
+// after rng.
+func sortedAfter(pass *analysis.Pass, body *ast.BlockStmt, rng *ast.RangeStmt, s types.Object) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call.Pos() < rng.End() {
+			return !found
+		}
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || !sortFuncs[fn.Pkg().Path()][fn.Name()] {
+			return !found
+		}
+		if fn.Signature().Recv() != nil {
+			return !found
+		}
+		for _, arg := range call.Args {
+			if mentionsAny(pass, arg, []types.Object{s}) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isBuiltin reports whether fun is the builtin function name.
+func isBuiltin(pass *analysis.Pass, fun ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok || ident.Name != name {
+		return false
+	}
+	_, ok = pass.TypesInfo.Uses[ident].(*types.Builtin)
+	return ok
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == obj
+}
+
+// mentionsAny reports whether n refers to one of objs.
+func mentionsAny(pass *analysis.Pass, n ast.Node, objs []types.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok {
+			for _, obj := range objs {
+				if pass.TypesInfo.Uses[ident] == obj {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,13 @@
+package mapordercheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/mapordercheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMapOrderCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, mapordercheck.Analyzer, "mapordertest")
+}
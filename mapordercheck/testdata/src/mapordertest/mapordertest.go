@@ -0,0 +1,71 @@
+package mapordertest
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+type user struct{ Name string }
+
+func names(users map[string]user) []string {
+	var out []string
+	for name := range users { // want `out is filled in map iteration order, which changes between runs; sort it after the loop`
+		out = append(out, name)
+	}
+	return out
+}
+
+func values(users map[string]user) string {
+	list := make([]string, 0, len(users))
+	for _, u := range users { // want `list is filled in map iteration order, which changes between runs; sort it after the loop`
+		if u.Name != "" {
+			list = append(list, strings.ToUpper(u.Name))
+		}
+	}
+	return strings.Join(list, ",")
+}
+
+func sortedKeys(users map[string]user) []string {
+	var out []string
+	for name := range users {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+type byName []user
+
+func (b byName) Len() int           { return len(b) }
+func (b byName) Less(i, j int) bool { return b[i].Name < b[j].Name }
+func (b byName) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+
+func sortedValues(users map[string]user, verbose bool) []user {
+	var out []user
+	for _, u := range users {
+		out = append(out, u)
+	}
+	if verbose {
+		sort.Sort(byName(out))
+	} else {
+		slices.SortFunc(out, func(a, b user) int { return strings.Compare(a.Name, b.Name) })
+	}
+	return out
+}
+
+func notFlagged(users map[string]user, list []string) int {
+	// Appending something other than the range variables — should NOT be
+	// flagged: every element is the same.
+	var marks []bool
+	for range users {
+		marks = append(marks, true)
+	}
+
+	// Ranging over a slice — should NOT be flagged.
+	var copied []string
+	for _, s := range list {
+		copied = append(copied, s)
+	}
+	return len(marks) + len(copied)
+}
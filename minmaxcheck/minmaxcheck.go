@@ -0,0 +1,438 @@
+// Package minmaxcheck defines an analyzer that detects sequential
+// comparisons computing the min or max of three or more values.
+//
+// # Analyzer minmaxcheck
+//
+// minmaxcheck: detect sequential if-updates that can use variadic min/max
+//
+// This analyzer flags a seed assignment followed by two or more conditional
+// updates of the same accumulator, all in the same direction:
+//
+//	m := a
+//	if b > m {
+//	    m = b
+//	}
+//	if c > m {
+//	    m = c
+//	}
+//
+// The min and max builtins are variadic, so this can be replaced with:
+//
+//	m := max(a, b, c)
+//
+// The single-update form (two values) is already covered by modernize's
+// minmax analyzer and is not flagged.
+//
+// An if statement returning the smaller or larger of the two values it
+// compares is flagged too, with or without an else:
+//
+//	if a < b {
+//	    return a
+//	}
+//	return b
+//
+// becomes:
+//
+//	return min(a, b)
+//
+// A guard following one in the opposite direction on the same value is the
+// tail of a clamp, which clampcheck reports, and is not flagged.
+//
+// Each update must compare the accumulator against the value it assigns,
+// and that value must be free of calls and must not mention the
+// accumulator, so evaluating it once is equivalent. In the return form both
+// operands must be free of calls, and the comparison may be strict or not.
+// Floating-point values are reported without a fix: the builtins propagate
+// NaN and order -0 before +0, while the comparisons do neither.
+//
+// Available since Go 1.21.
+package minmaxcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "minmaxcheck",
+	Doc:      "detect sequential if-updates that can use variadic min/max",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i := 0; i < len(block.List); i++ {
+			if checkReturn(pass, block.List, i) {
+				continue
+			}
+			i += checkSeed(pass, block.List, i)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkSeed checks whether stmts[i] seeds an accumulator that the following
+// statements update with min or max semantics, reporting it if so. It
+// returns the number of update statements consumed.
+func checkSeed(pass *analysis.Pass, stmts []ast.Stmt, i int) int {
+	acc, seed := seedAssign(stmts[i])
+	if acc == nil {
+		return 0
+	}
+	obj := pass.TypesInfo.ObjectOf(acc)
+	if obj == nil {
+		return 0
+	}
+	basic, ok := obj.Type().Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsOrdered == 0 {
+		return 0
+	}
+
+	var fn string // "min" or "max"
+	values := []ast.Expr{seed}
+	var last *ast.IfStmt
+	for _, stmt := range stmts[i+1:] {
+		ifStmt, ok := stmt.(*ast.IfStmt)
+		if !ok {
+			break
+		}
+		value, dir := update(pass, ifStmt, obj)
+		if value == nil || (fn != "" && dir != fn) {
+			break
+		}
+		fn = dir
+		values = append(values, value)
+		last = ifStmt
+	}
+	updates := len(values) - 1
+	if updates < 2 {
+		return 0
+	}
+
+	args := make([]string, len(values))
+	for j, v := range values {
+		args[j] = types.ExprString(v)
+	}
+	call := fmt.Sprintf("%s(%s)", fn, strings.Join(args, ", "))
+	msg := fmt.Sprintf("sequential comparisons on %s can be simplified to %s", acc.Name, call)
+	diag := analysis.Diagnostic{Pos: stmts[i].Pos(), Message: msg}
+
+	if basic.Info()&types.IsFloat == 0 && isBuiltin(pass, fn, stmts[i].Pos()) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: []analysis.TextEdit{
+					{
+						Pos:     seed.Pos(),
+						End:     seed.End(),
+						NewText: []byte(call),
+					},
+					{
+						Pos: stmts[i].End(),
+						End: last.End(),
+					},
+				},
+			},
+		}
+	}
+
+	pass.Report(diag)
+	return updates
+}
+
+// checkReturn checks whether stmts[i] is an if statement returning the
+// smaller or larger of the two values it compares, either from both
+// branches or from its body and the return statement after it, reporting it
+// if so.
+func checkReturn(pass *analysis.Pass, stmts []ast.Stmt, i int) bool {
+	ifStmt, ok := stmts[i].(*ast.IfStmt)
+	if !ok || ifStmt.Init != nil || len(ifStmt.Body.List) != 1 {
+		return false
+	}
+	thenValue := returnValue(ifStmt.Body.List[0])
+	var elseStmt ast.Stmt
+	switch els := ifStmt.Else.(type) {
+	case nil:
+		if i+1 < len(stmts) {
+			elseStmt = stmts[i+1]
+		}
+	case *ast.BlockStmt:
+		if len(els.List) == 1 {
+			elseStmt = els.List[0]
+		}
+	}
+	elseValue := returnValue(elseStmt)
+	if thenValue == nil || elseValue == nil {
+		return false
+	}
+
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok {
+		return false
+	}
+	fn := returnFn(cond, thenValue, elseValue)
+	if fn == "" {
+		return false
+	}
+	// The guard form may be the tail of a clamp, which clampcheck reports:
+	//
+	//	if v < lo {
+	//	    return lo
+	//	}
+	//	if v > hi {
+	//	    return hi
+	//	}
+	//	return v
+	if ifStmt.Else == nil && i > 0 {
+		if prev, ok := stmts[i-1].(*ast.IfStmt); ok && prev.Init == nil && prev.Else == nil && len(prev.Body.List) == 1 {
+			prevCond, _ := prev.Cond.(*ast.BinaryExpr)
+			if prevFn := returnFn(prevCond, returnValue(prev.Body.List[0]), elseValue); prevFn != "" && prevFn != fn {
+				return false
+			}
+		}
+	}
+
+	// min and max evaluate each operand once, and need operands of one
+	// ordered type.
+	if !isSimple(pass, cond.X, nil) || !isSimple(pass, cond.Y, nil) {
+		return false
+	}
+	tx, ty := pass.TypesInfo.Types[cond.X], pass.TypesInfo.Types[cond.Y]
+	if tx.Type == nil || ty.Type == nil || (tx.Value != nil && ty.Value != nil) {
+		return false
+	}
+	t := tx.Type
+	if tx.Value != nil {
+		t = ty.Type
+	} else if ty.Value == nil && !types.Identical(tx.Type, ty.Type) {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok || basic.Info()&types.IsOrdered == 0 {
+		return false
+	}
+
+	call := fmt.Sprintf("%s(%s, %s)", fn, types.ExprString(cond.X), types.ExprString(cond.Y))
+	msg := fmt.Sprintf("if returning the %s of %s and %s can be simplified to return %s",
+		map[string]string{"min": "smaller", "max": "larger"}[fn], types.ExprString(cond.X), types.ExprString(cond.Y), call)
+	diag := analysis.Diagnostic{Pos: ifStmt.Pos(), Message: msg}
+
+	end := ifStmt.End()
+	if ifStmt.Else == nil {
+		end = elseStmt.End()
+	}
+	// A comment after the opening brace stays; the rest of the statements
+	// are replaced, so the body must end on a later line than the if.
+	tokFile := pass.Fset.File(ifStmt.Pos())
+	lineEnd := tokFile.LineStart(tokFile.Line(ifStmt.Pos())+1) - 1
+	if basic.Info()&types.IsFloat == 0 && isBuiltin(pass, fn, ifStmt.Pos()) &&
+		lineEnd > ifStmt.Body.Lbrace && lineEnd < ifStmt.Body.Rbrace && !hasComments(pass, lineEnd, end) {
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message: msg,
+				TextEdits: []analysis.TextEdit{
+					{Pos: ifStmt.Pos(), End: ifStmt.Body.Lbrace + 1, NewText: []byte("return " + call)},
+					{Pos: lineEnd, End: end},
+				},
+			},
+		}
+	}
+
+	pass.Report(diag)
+	return true
+}
+
+// returnFn returns "min" or "max" if an if statement with condition cond
+// returning thenValue, and elseValue otherwise, returns the smaller or
+// larger of the operands of cond. It returns "" otherwise.
+func returnFn(cond *ast.BinaryExpr, thenValue, elseValue ast.Expr) string {
+	if cond == nil || thenValue == nil || elseValue == nil {
+		return ""
+	}
+	var less bool // whether the condition holds when X is the smaller
+	switch cond.Op {
+	case token.LSS, token.LEQ:
+		less = true
+	case token.GTR, token.GEQ:
+		less = false
+	default:
+		return ""
+	}
+	switch {
+	case sameOperand(thenValue, cond.X) && sameOperand(elseValue, cond.Y):
+		return map[bool]string{true: "min", false: "max"}[less]
+	case sameOperand(thenValue, cond.Y) && sameOperand(elseValue, cond.X):
+		return map[bool]string{true: "max", false: "min"}[less]
+	}
+	return ""
+}
+
+// returnValue returns the value of stmt if it is a return statement with a
+// single result.
+func returnValue(stmt ast.Stmt) ast.Expr {
+	ret, ok := stmt.(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return nil
+	}
+	return ret.Results[0]
+}
+
+// sameOperand reports whether a and b are written the same.
+func sameOperand(a, b ast.Expr) bool {
+	return types.ExprString(a) == types.ExprString(b)
+}
+
+// hasComments reports whether a comment lies between pos and end.
+func hasComments(pass *analysis.Pass, pos, end token.Pos) bool {
+	for _, file := range pass.Files {
+		if file.FileStart > pos || file.FileEnd < end {
+			continue
+		}
+		for _, cg := range file.Comments {
+			if cg.Pos() > pos && cg.Pos() < end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// seedAssign returns the accumulator and its initial value if stmt is one of:
+//
+//	m := a
+//	m = a
+//	var m = a
+func seedAssign(stmt ast.Stmt) (*ast.Ident, ast.Expr) {
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if (stmt.Tok != token.DEFINE && stmt.Tok != token.ASSIGN) || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return nil, nil
+		}
+		ident, ok := stmt.Lhs[0].(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			return nil, nil
+		}
+		return ident, stmt.Rhs[0]
+
+	case *ast.DeclStmt:
+		gd, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR || len(gd.Specs) != 1 {
+			return nil, nil
+		}
+		spec := gd.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) != 1 || spec.Names[0].Name == "_" {
+			return nil, nil
+		}
+		return spec.Names[0], spec.Values[0]
+	}
+	return nil, nil
+}
+
+// update matches an if statement that conditionally replaces acc with a new
+// value:
+//
+//	if v > m { m = v }   if m < v { m = v }   → (v, "max")
+//	if v < m { m = v }   if m > v { m = v }   → (v, "min")
+//
+// It returns a nil value if ifStmt has any other shape.
+func update(pass *analysis.Pass, ifStmt *ast.IfStmt, acc types.Object) (ast.Expr, string) {
+	if ifStmt.Init != nil || ifStmt.Else != nil || len(ifStmt.Body.List) != 1 {
+		return nil, ""
+	}
+	assign, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, ""
+	}
+	if !isObj(pass, assign.Lhs[0], acc) {
+		return nil, ""
+	}
+	value := assign.Rhs[0]
+
+	cond, ok := ifStmt.Cond.(*ast.BinaryExpr)
+	if !ok {
+		return nil, ""
+	}
+	var other ast.Expr
+	var greater bool // whether the condition reads "value > acc"
+	switch {
+	case isObj(pass, cond.Y, acc):
+		other = cond.X
+		greater = cond.Op == token.GTR
+		if !greater && cond.Op != token.LSS {
+			return nil, ""
+		}
+	case isObj(pass, cond.X, acc):
+		other = cond.Y
+		greater = cond.Op == token.LSS
+		if !greater && cond.Op != token.GTR {
+			return nil, ""
+		}
+	default:
+		return nil, ""
+	}
+
+	// The compared value must be the assigned value, and evaluating it once
+	// instead of twice must not change behavior.
+	if types.ExprString(other) != types.ExprString(value) || !isSimple(pass, value, acc) {
+		return nil, ""
+	}
+
+	if greater {
+		return value, "max"
+	}
+	return value, "min"
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(ident) == obj
+}
+
+// isSimple reports whether expr contains no calls other than conversions and
+// does not refer to acc.
+func isSimple(pass *analysis.Pass, expr ast.Expr, acc types.Object) bool {
+	simple := true
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if tv, ok := pass.TypesInfo.Types[n.Fun]; !ok || !tv.IsType() {
+				simple = false
+			}
+		case *ast.Ident:
+			if pass.TypesInfo.ObjectOf(n) == acc {
+				simple = false
+			}
+		}
+		return simple
+	})
+	return simple
+}
+
+// isBuiltin reports whether name refers to the predeclared function at pos,
+// i.e. it is not shadowed by a local declaration.
+func isBuiltin(pass *analysis.Pass, name string, pos token.Pos) bool {
+	scope := pass.Pkg.Scope().Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent(name, pos)
+	_, ok := obj.(*types.Builtin)
+	return ok
+}
@@ -0,0 +1,36 @@
+package minmaxcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/minmaxcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestMinMaxCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, minmaxcheck.Analyzer, "minmaxtest")
+}
+
+// gofmt splits a one-line if body over several lines, so this case cannot
+// live in the (gofmt-checked) testdata directory. It has no golden file:
+// the fix must be withheld.
+const oneLine = `package onelinetest
+
+func oneLine(a, b int) int {
+	if a < b { return a } // want "if returning the smaller of a and b can be simplified to return min\\(a, b\\)"
+	return b
+}
+`
+
+func TestMinMaxCheckOneLineBody(t *testing.T) {
+	dir, cleanup, err := analysistest.WriteFiles(map[string]string{
+		"onelinetest/onelinetest.go": oneLine,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+
+	analysistest.RunWithSuggestedFixes(t, dir, minmaxcheck.Analyzer, "onelinetest")
+}
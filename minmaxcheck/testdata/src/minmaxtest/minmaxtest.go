@@ -0,0 +1,226 @@
+package minmaxtest
+
+type point struct{ x, y, z int }
+
+func threeWayMax(a, b, c int) int {
+	// Should be flagged: three-way max.
+	m := a // want `sequential comparisons on m can be simplified to max\(a, b, c\)`
+	if b > m {
+		m = b
+	}
+	if c > m {
+		m = c
+	}
+	return m
+}
+
+func fourWayMin(a, b, c, d int) int {
+	// Should be flagged: four-way min, accumulator on either side.
+	m := a // want `sequential comparisons on m can be simplified to min\(a, b, c, d\)`
+	if b < m {
+		m = b
+	}
+	if m > c {
+		m = c
+	}
+	if d < m {
+		m = d
+	}
+	return m
+}
+
+func fields(p point) int {
+	// Should be flagged: var seed and field values.
+	var m = p.x // want `sequential comparisons on m can be simplified to max\(p\.x, p\.y, p\.z\)`
+	if m < p.y {
+		m = p.y
+	}
+	if m < p.z {
+		m = p.z
+	}
+	return m
+}
+
+func strs(a, b, c string) string {
+	// Should be flagged: strings are ordered.
+	var m string
+	m = a // want `sequential comparisons on m can be simplified to min\(a, b, c\)`
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
+
+func floats(a, b, c float64) float64 {
+	// Should be flagged without a fix: max propagates NaN, the comparisons don't.
+	m := a // want `sequential comparisons on m can be simplified to max\(a, b, c\)`
+	if b > m {
+		m = b
+	}
+	if c > m {
+		m = c
+	}
+	return m
+}
+
+func next() int { return 0 }
+
+func noMatch(a, b, c int) int {
+	// Single update (two values) — left to modernize, should NOT be flagged.
+	m := a
+	if b > m {
+		m = b
+	}
+
+	// Mixed directions — should NOT be flagged.
+	n := a
+	if b > n {
+		n = b
+	}
+	if c < n {
+		n = c
+	}
+
+	// Compared value differs from assigned value — should NOT be flagged.
+	o := a
+	if b > o {
+		o = c
+	}
+	if c > o {
+		o = c
+	}
+
+	// Value is a call evaluated twice — should NOT be flagged.
+	p := a
+	if next() > p {
+		p = next()
+	}
+	if next() > p {
+		p = next()
+	}
+
+	// Non-strict comparison — should NOT be flagged.
+	q := a
+	if b >= q {
+		q = b
+	}
+	if c >= q {
+		q = c
+	}
+
+	return m + n + o + p + q
+}
+
+func shadowed(a, b, c int) int {
+	max := func(xs ...int) int { return 0 }
+	_ = max
+
+	// Should be flagged without a fix: max is shadowed.
+	m := a // want `sequential comparisons on m can be simplified to max\(a, b, c\)`
+	if b > m {
+		m = b
+	}
+	if c > m {
+		m = c
+	}
+	return m
+}
+
+func returnElse(a, b int) int {
+	if a < b { // want `if returning the smaller of a and b can be simplified to return min\(a, b\)`
+		return a
+	} else {
+		return b
+	}
+}
+
+func returnGuard(a, b int) int {
+	if a > b { // want `if returning the larger of a and b can be simplified to return max\(a, b\)`
+		return a
+	}
+	return b
+}
+
+func returnSwapped(a, b int) int {
+	if a < b { // want `if returning the larger of a and b can be simplified to return max\(a, b\)`
+		return b
+	}
+	return a
+}
+
+func returnNonStrict(n int) int {
+	if n >= 10 { // want `if returning the smaller of n and 10 can be simplified to return min\(n, 10\)`
+		return 10
+	} else {
+		return n
+	}
+}
+
+func returnFloat(a, b float64) float64 {
+	// Should be flagged without a fix: min propagates NaN.
+	if a < b { // want `if returning the smaller of a and b can be simplified to return min\(a, b\)`
+		return a
+	}
+	return b
+}
+
+func returnNotFlagged(a, b, c int) int {
+	// Returned values do not match the operands — should NOT be flagged.
+	if a < b {
+		return a
+	}
+	if a < b {
+		return c
+	}
+
+	// Else-if chain — should NOT be flagged.
+	if a < b {
+		return a
+	} else if b < c {
+		return b
+	} else {
+		return c
+	}
+}
+
+func returnCall(a int) int {
+	// Operand is a call — should NOT be flagged.
+	if next() < a {
+		return next()
+	}
+	return a
+}
+
+func returnCommented(a, b int) int {
+	// Should be flagged without a fix: the fix would drop the comment.
+	if a < b { // want `if returning the smaller of a and b can be simplified to return min\(a, b\)`
+		// a wins ties too.
+		return a
+	}
+	return b
+}
+
+func returnClamp(v, lo, hi int) int {
+	// The tail of a clamp is clampcheck's — should NOT be flagged.
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func returnTwoGuards(v, lo, hi int) int {
+	// Both guards return the larger value — the second is flagged.
+	if v < lo {
+		return lo
+	}
+	if v < hi { // want `if returning the larger of v and hi can be simplified to return max\(v, hi\)`
+		return hi
+	}
+	return v
+}
@@ -0,0 +1,299 @@
+// Package nilmapwritecheck defines an analyzer that detects writes to a map
+// variable that is still nil.
+//
+// # Analyzer nilmapwritecheck
+//
+// nilmapwritecheck: detect writes to map variables declared nil
+//
+// This analyzer flags a write to a local map declared with var and no
+// initial value when nothing assigns the variable before the write:
+//
+//	var seen map[string]bool
+//	for _, name := range names {
+//	    seen[name] = true // panics: assignment to entry in nil map
+//	}
+//
+// Reading a nil map returns zero values, but writing to one panics. The
+// map needs to be made first:
+//
+//	seen := make(map[string]bool)
+//
+// The statements after the declaration are followed in source order until
+// the first write or the first statement that might initialize the map: an
+// assignment to it, taking its address, a method call on it, a loop that
+// assigns it anywhere in its body, or a function literal that mentions it.
+// Writes inside an if or switch whose condition mentions the map are
+// assumed guarded and not flagged, and functions with goto are skipped.
+// Diagnostics are report-only.
+package nilmapwritecheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "nilmapwritecheck",
+	Doc:      "detect writes to map variables declared nil",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i, stmt := range list {
+			idents := nilMaps(pass, stmt)
+			if len(idents) == 0 || hasGoto(stack) {
+				continue
+			}
+			for _, ident := range idents {
+				obj := pass.TypesInfo.Defs[ident]
+				if write := firstWrite(pass, list[i+1:], obj); write != nil {
+					pass.Report(analysis.Diagnostic{
+						Pos:     write.Pos(),
+						Message: "write to nil map " + ident.Name + " panics; it is declared with var and not initialized before this write",
+						Related: []analysis.RelatedInformation{
+							{Pos: ident.Pos(), Message: ident.Name + " declared here"},
+						},
+					})
+				}
+			}
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// hasGoto reports whether the innermost function in stack contains a goto,
+// which could jump back over an initialization.
+func hasGoto(stack []ast.Node) bool {
+	var body *ast.BlockStmt
+	for i := len(stack) - 1; i >= 0 && body == nil; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+	}
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if br, ok := n.(*ast.BranchStmt); ok && br.Tok == token.GOTO {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// nilMaps returns the names declared by stmt when it is var m map[K]V
+// without an initial value.
+func nilMaps(pass *analysis.Pass, stmt ast.Stmt) []*ast.Ident {
+	decl, ok := stmt.(*ast.DeclStmt)
+	if !ok {
+		return nil
+	}
+	gen, ok := decl.Decl.(*ast.GenDecl)
+	if !ok || gen.Tok != token.VAR {
+		return nil
+	}
+	var idents []*ast.Ident
+	for _, spec := range gen.Specs {
+		vs := spec.(*ast.ValueSpec)
+		if len(vs.Values) != 0 {
+			continue
+		}
+		for _, ident := range vs.Names {
+			obj := pass.TypesInfo.Defs[ident]
+			if obj == nil || ident.Name == "_" {
+				continue
+			}
+			if _, ok := obj.Type().Underlying().(*types.Map); ok {
+				idents = append(idents, ident)
+			}
+		}
+	}
+	return idents
+}
+
+// firstWrite returns the index expression of the first write to obj in
+// stmts, or nil if something that might initialize obj comes first.
+func firstWrite(pass *analysis.Pass, stmts []ast.Stmt, obj types.Object) *ast.IndexExpr {
+	var found *ast.IndexExpr
+	stopped := false
+	// guards counts the enclosing ifs and switches whose conditions
+	// mention obj.
+	guards := 0
+	var stack []ast.Node
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		if n == nil {
+			if guarded(pass, stack[len(stack)-1], obj) {
+				guards--
+			}
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		if found != nil || stopped {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if uses(pass, n, obj) {
+				stopped = true
+			}
+			return false
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isObj(pass, n.X, obj) {
+				stopped = true
+				return false
+			}
+		case *ast.SelectorExpr:
+			if isObj(pass, n.X, obj) {
+				stopped = true
+				return false
+			}
+		case *ast.ForStmt, *ast.RangeStmt:
+			if assigns(pass, n, obj) {
+				stopped = true
+				return false
+			}
+		case *ast.AssignStmt:
+			// The right-hand side is evaluated before anything is assigned.
+			for _, rhs := range n.Rhs {
+				ast.Inspect(rhs, visit)
+			}
+			for _, lhs := range n.Lhs {
+				if isObj(pass, lhs, obj) {
+					stopped = true
+				} else if idx, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok && isObj(pass, idx.X, obj) && guards == 0 && found == nil && !stopped {
+					found = idx
+				} else {
+					ast.Inspect(lhs, visit)
+				}
+			}
+			return false
+		case *ast.IncDecStmt:
+			if idx, ok := ast.Unparen(n.X).(*ast.IndexExpr); ok && isObj(pass, idx.X, obj) && guards == 0 {
+				found = idx
+				return false
+			}
+		}
+		if guarded(pass, n, obj) {
+			guards++
+		}
+		stack = append(stack, n)
+		return true
+	}
+
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, visit)
+		if found != nil || stopped {
+			break
+		}
+	}
+	return found
+}
+
+// guarded reports whether n is an if or switch whose condition or tag
+// mentions obj, such as if m != nil.
+func guarded(pass *analysis.Pass, n ast.Node, obj types.Object) bool {
+	switch n := n.(type) {
+	case *ast.IfStmt:
+		return uses(pass, n.Cond, obj)
+	case *ast.SwitchStmt:
+		if n.Tag != nil {
+			return uses(pass, n.Tag, obj)
+		}
+		for _, stmt := range n.Body.List {
+			for _, expr := range stmt.(*ast.CaseClause).List {
+				if uses(pass, expr, obj) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// assigns reports whether obj is assigned anywhere in n.
+func assigns(pass *analysis.Pass, n ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isObj(pass, lhs, obj) {
+					found = true
+				}
+			}
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN && (isObj(pass, n.Key, obj) || isObj(pass, n.Value, obj)) {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isObj(pass, n.X, obj) {
+				found = true
+			}
+		case *ast.SelectorExpr:
+			if isObj(pass, n.X, obj) {
+				found = true
+			}
+		case *ast.FuncLit:
+			if uses(pass, n, obj) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	if expr == nil {
+		return false
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == obj
+}
+
+// uses reports whether n refers to obj.
+func uses(pass *analysis.Pass, n ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
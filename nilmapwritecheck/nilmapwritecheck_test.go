@@ -0,0 +1,13 @@
+package nilmapwritecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/nilmapwritecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNilMapWriteCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nilmapwritecheck.Analyzer, "nilmapwritetest")
+}
@@ -0,0 +1,136 @@
+package nilmapwritetest
+
+import "encoding/json"
+
+func index(names []string) map[string]bool {
+	var seen map[string]bool
+	for _, name := range names {
+		seen[name] = true // want `write to nil map seen panics; it is declared with var and not initialized before this write`
+	}
+	return seen
+}
+
+func count(words []string) map[string]int {
+	var counts map[string]int
+	for _, w := range words {
+		counts[w]++ // want `write to nil map counts panics`
+	}
+	return counts
+}
+
+func total(m map[string]int) map[string]int {
+	var (
+		sums  map[string]int
+		limit = 10
+	)
+	for k, v := range m {
+		sums[k] += v + limit // want `write to nil map sums panics`
+	}
+	return sums
+}
+
+// Only the first write is reported.
+func twice() {
+	var m map[int]int
+	m[1] = 1 // want `write to nil map m panics`
+	m[2] = 2
+}
+
+// Initialized before the write.
+
+func made(names []string) map[string]bool {
+	var seen map[string]bool
+	seen = make(map[string]bool)
+	for _, name := range names {
+		seen[name] = true
+	}
+	return seen
+}
+
+func literal() map[string]int {
+	var m map[string]int
+	m = map[string]int{}
+	m["a"] = 1
+	return m
+}
+
+func lazily(names []string) map[string]bool {
+	var seen map[string]bool
+	for _, name := range names {
+		if seen == nil {
+			seen = make(map[string]bool)
+		}
+		seen[name] = true
+	}
+	return seen
+}
+
+func branch(ok bool) map[string]int {
+	var m map[string]int
+	if ok {
+		m = make(map[string]int)
+	}
+	m["a"] = 1
+	return m
+}
+
+func decoded(data []byte) map[string]int {
+	var m map[string]int
+	_ = json.Unmarshal(data, &m)
+	m["seen"] = 1
+	return m
+}
+
+func closure() map[string]int {
+	var m map[string]int
+	init := func() { m = map[string]int{} }
+	init()
+	m["a"] = 1
+	return m
+}
+
+// Guarded by a nil check.
+func guarded() {
+	var m map[string]int
+	if m != nil {
+		m["a"] = 1
+	}
+}
+
+// Reads and deletes do not panic.
+func reads() int {
+	var m map[string]int
+	delete(m, "a")
+	return m["a"] + len(m)
+}
+
+// A short variable declaration is not nil.
+func short() {
+	m := map[string]int{}
+	m["a"] = 1
+}
+
+// goto could come back after an initialization.
+func jumps(n int) {
+	var m map[int]int
+	i := 0
+loop:
+	if i > 0 {
+		m[i] = i
+	}
+	m = map[int]int{}
+	i++
+	if i < n {
+		goto loop
+	}
+}
+
+type Set map[string]struct{}
+
+func (s *Set) Init() { *s = Set{} }
+
+func method() {
+	var s Set
+	s.Init()
+	s["a"] = struct{}{}
+}
@@ -0,0 +1,169 @@
+// Package nilsliceinitcheck defines an analyzer that detects slices
+// initialized empty when a nil slice would do.
+//
+// # Analyzer nilsliceinitcheck
+//
+// nilsliceinitcheck: detect empty slice literals only appended to and returned
+//
+// This analyzer flags a slice declared with an empty composite literal when
+// the function only appends to it and returns it:
+//
+//	out := []string{}
+//	for _, v := range in {
+//	    out = append(out, v)
+//	}
+//	return out
+//
+// append works on a nil slice, so the idiomatic declaration allocates
+// nothing up front:
+//
+//	var out []string
+//
+// The two are not interchangeable everywhere: when nothing is appended, the
+// function returns nil instead of an empty slice, which encoding/json
+// marshals as null rather than [], and which reflect.DeepEqual and callers
+// comparing against nil can tell apart. For that reason the diagnostic is
+// report-only.
+package nilsliceinitcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "nilsliceinitcheck",
+	Doc:      "detect empty slice literals only appended to and returned",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		assign := n.(*ast.AssignStmt)
+		if assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return true
+		}
+		ident, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok {
+			return true
+		}
+		lit, ok := assign.Rhs[0].(*ast.CompositeLit)
+		if !ok || len(lit.Elts) != 0 {
+			return true
+		}
+		if _, ok := lit.Type.(*ast.ArrayType); !ok {
+			return true
+		}
+		if _, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Slice); !ok {
+			return true
+		}
+		obj := pass.TypesInfo.Defs[ident]
+		if obj == nil {
+			return true
+		}
+
+		body := enclosingBody(stack)
+		if body == nil || !appendedAndReturned(pass, body, obj) {
+			return true
+		}
+
+		pass.Reportf(assign.Pos(),
+			"%s is only appended to and returned; var %s %s avoids an allocation but returns nil, not an empty slice, when nothing is appended",
+			ident.Name, ident.Name, types.ExprString(lit.Type))
+		return true
+	})
+
+	return nil, nil
+}
+
+// enclosingBody returns the body of the innermost function in stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// appendedAndReturned reports whether every use of obj in body is either
+// obj = append(obj, ...) or a return of obj itself, with at least one of
+// each.
+func appendedAndReturned(pass *analysis.Pass, body *ast.BlockStmt, obj types.Object) bool {
+	allowed := map[*ast.Ident]bool{}
+	var appended, returned bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if lhs, arg, ok := selfAppend(pass, n, obj); ok {
+				allowed[lhs], allowed[arg] = true, true
+				appended = true
+			}
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if ident, ok := ast.Unparen(result).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+					allowed[ident] = true
+					returned = true
+				}
+			}
+		}
+		return true
+	})
+	if !appended || !returned {
+		return false
+	}
+
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		if ident, isIdent := n.(*ast.Ident); isIdent && pass.TypesInfo.Uses[ident] == obj && !allowed[ident] {
+			ok = false
+		}
+		return ok
+	})
+	return ok
+}
+
+// selfAppend matches obj = append(obj, ...) and returns both identifiers.
+func selfAppend(pass *analysis.Pass, assign *ast.AssignStmt, obj types.Object) (lhs, arg *ast.Ident, ok bool) {
+	if assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	lhs, ok = assign.Lhs[0].(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[lhs] != obj {
+		return nil, nil, false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return nil, nil, false
+	}
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "append" {
+		return nil, nil, false
+	}
+	if _, ok := pass.TypesInfo.ObjectOf(fun).(*types.Builtin); !ok {
+		return nil, nil, false
+	}
+	arg, ok = call.Args[0].(*ast.Ident)
+	if !ok || pass.TypesInfo.Uses[arg] != obj {
+		return nil, nil, false
+	}
+	return lhs, arg, true
+}
@@ -0,0 +1,13 @@
+package nilsliceinitcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/nilsliceinitcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNilSliceInitCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, nilsliceinitcheck.Analyzer, "nilsliceinittest")
+}
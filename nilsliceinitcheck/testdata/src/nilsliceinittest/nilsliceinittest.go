@@ -0,0 +1,84 @@
+package nilsliceinittest
+
+import (
+	"fmt"
+	"strings"
+)
+
+func upper(in []string) []string {
+	out := []string{} // want `out is only appended to and returned; var out \[\]string avoids an allocation but returns nil, not an empty slice, when nothing is appended`
+	for _, v := range in {
+		out = append(out, strings.ToUpper(v))
+	}
+	return out
+}
+
+type point struct{ x, y int }
+
+func evens(n int) ([]point, error) {
+	pts := []point{} // want `pts is only appended to and returned`
+	for i := range n {
+		if i%2 == 0 {
+			pts = append(pts, point{i, i})
+		}
+	}
+	return pts, nil
+}
+
+var collect = func(ch <-chan int) []int {
+	vals := []int{} // want `vals is only appended to and returned`
+	for v := range ch {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+// Read for its length, so not only appended to and returned.
+func counted(in []string) []string {
+	out := []string{}
+	for _, v := range in {
+		out = append(out, v)
+	}
+	fmt.Println(len(out))
+	return out
+}
+
+// Never returned.
+func printed(in []int) {
+	out := []int{}
+	for _, v := range in {
+		out = append(out, v*2)
+	}
+	fmt.Println(out)
+}
+
+// Appended from another slice's contents into a different target.
+func copied(in []int) []int {
+	out := []int{}
+	other := append(out, in...)
+	return other
+}
+
+// Already a nil slice.
+func nilSlice(in []int) []int {
+	var out []int
+	for _, v := range in {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Non-empty literals are not flagged.
+func seeded(in []int) []int {
+	out := []int{0}
+	for _, v := range in {
+		out = append(out, v)
+	}
+	return out
+}
+
+// Arrays are not slices.
+func array() [0]int {
+	a := [0]int{}
+	return a
+}
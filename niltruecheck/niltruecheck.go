@@ -0,0 +1,150 @@
+// Package niltruecheck defines an analyzer that detects returning a nil
+// error from the branch that just found a non-nil one.
+//
+// # Analyzer niltruecheck
+//
+// niltruecheck: detect return of a nil error inside an err != nil branch
+//
+// This analyzer flags return statements that return nil as the error result
+// inside an if err != nil branch that does not otherwise use err:
+//
+//	v, err := load()
+//	if err != nil {
+//	    return nil, nil
+//	}
+//
+// The caller sees success and a zero value, and the error is lost. This is
+// usually a typo for return nil, err. A branch that mentions err, for
+// example to log it or to check it with errors.Is, is taken to swallow it on
+// purpose and is not flagged. No auto-fix is provided.
+package niltruecheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "niltruecheck",
+	Doc:      "detect return of a nil error inside an err != nil branch",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		ifStmt := n.(*ast.IfStmt)
+
+		errVar := nonNilErrCond(pass, ifStmt.Cond)
+		if errVar == nil || mentions(pass, ifStmt.Body, errVar) {
+			return true
+		}
+
+		// The enclosing function must return an error last.
+		sig := enclosingSig(pass, stack)
+		if sig == nil || sig.Results().Len() == 0 ||
+			!types.Identical(sig.Results().At(sig.Results().Len()-1).Type(), errorType) {
+			return true
+		}
+		nres := sig.Results().Len()
+
+		ast.Inspect(ifStmt.Body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				if len(n.Results) != nres {
+					return true
+				}
+				if isNil(pass, n.Results[nres-1]) {
+					pass.Reportf(n.Pos(), "returns a nil error although %s != nil; return %s or wrap it",
+						errVar.Name(), errVar.Name())
+				}
+			}
+			return true
+		})
+		return true
+	})
+
+	return nil, nil
+}
+
+// nonNilErrCond returns the variable v if cond is v != nil or nil != v and
+// v has type error.
+func nonNilErrCond(pass *analysis.Pass, cond ast.Expr) types.Object {
+	bin, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || bin.Op != token.NEQ {
+		return nil
+	}
+	x, y := ast.Unparen(bin.X), ast.Unparen(bin.Y)
+	if isNil(pass, x) {
+		x, y = y, x
+	}
+	if !isNil(pass, y) {
+		return nil
+	}
+	ident, ok := x.(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || !types.Identical(v.Type(), errorType) {
+		return nil
+	}
+	return v
+}
+
+// enclosingSig returns the signature of the innermost function in stack.
+func enclosingSig(pass *analysis.Pass, stack []ast.Node) *types.Signature {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			if obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+				return obj.Signature()
+			}
+			return nil
+		case *ast.FuncLit:
+			sig, _ := pass.TypesInfo.TypeOf(fn).(*types.Signature)
+			return sig
+		}
+	}
+	return nil
+}
+
+// mentions reports whether node uses obj.
+func mentions(pass *analysis.Pass, node ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(node, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isNil reports whether expr is the predeclared nil.
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, ok = pass.TypesInfo.Uses[ident].(*types.Nil)
+	return ok
+}
@@ -0,0 +1,13 @@
+package niltruecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/niltruecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNilTrueCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, niltruecheck.Analyzer, "niltruetest")
+}
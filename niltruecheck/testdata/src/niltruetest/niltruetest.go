@@ -0,0 +1,80 @@
+package niltruetest
+
+import (
+	"errors"
+	"log"
+	"os"
+)
+
+func load() ([]byte, error) {
+	data, err := os.ReadFile("config")
+	if err != nil {
+		return nil, nil // want `returns a nil error although err != nil; return err or wrap it`
+	}
+	return data, nil
+}
+
+func remove(name string) error {
+	err := os.Remove(name)
+	if nil != err {
+		return nil // want `returns a nil error although err != nil`
+	}
+	return nil
+}
+
+// Nested returns in the branch count too.
+func nested(name string, strict bool) error {
+	if err := os.Remove(name); err != nil {
+		if strict {
+			return errors.New("remove failed")
+		}
+		return nil // want `returns a nil error although err != nil`
+	}
+	return nil
+}
+
+// Returning the error is correct.
+func loadChecked() ([]byte, error) {
+	data, err := os.ReadFile("config")
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// A branch that looks at err swallows it on purpose.
+func removeIfExists(name string) error {
+	if err := os.Remove(name); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func removeLogged(name string) error {
+	if err := os.Remove(name); err != nil {
+		log.Printf("remove: %v", err)
+		return nil
+	}
+	return nil
+}
+
+// A function literal in the branch returns its own results.
+func deferredCleanup(name string) error {
+	if err := os.Remove(name); err != nil {
+		retry := func() error { return nil }
+		return retry()
+	}
+	return nil
+}
+
+// Functions without an error result are not checked.
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	if err != nil {
+		return false
+	}
+	return true
+}
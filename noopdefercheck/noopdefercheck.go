@@ -0,0 +1,200 @@
+// Package noopdefercheck defines an analyzer that detects deferred function
+// literals that do nothing.
+//
+// # Analyzer noopdefercheck
+//
+// noopdefercheck: detect defer of a function literal with no effect
+//
+// This analyzer flags defer statements whose function literal has an empty
+// body, or a body made only of blank assignments of variables and
+// constants:
+//
+//	defer func() {}()
+//	defer func() { _ = f }()
+//
+// Such a defer runs nothing when the function returns, but still costs a
+// deferred call and suggests cleanup that is not there. The fix deletes the
+// statement.
+//
+// Deferred literals that call anything, take arguments, or read a field or
+// element, which can panic, are not flagged. When removing the defer, along
+// with the other no-op defers of the file, would leave a variable or import
+// unused, it is reported without a fix.
+package noopdefercheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "noopdefercheck",
+	Doc:      "detect defer of a function literal with no effect",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const msg = "deferred function literal has no effect"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.DeferStmt)(nil),
+	}
+
+	// Collect the no-op defers first: removing several of them must not
+	// remove every read of a variable or import between them.
+	var noops []*ast.DeferStmt
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		deferStmt := n.(*ast.DeferStmt)
+		call := deferStmt.Call
+		if len(call.Args) != 0 {
+			return
+		}
+		lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit)
+		if !ok {
+			return
+		}
+		for _, stmt := range lit.Body.List {
+			if !noEffect(pass, stmt) {
+				return
+			}
+		}
+		noops = append(noops, deferStmt)
+	})
+
+	for _, deferStmt := range noops {
+		lit := ast.Unparen(deferStmt.Call.Fun).(*ast.FuncLit)
+		diag := analysis.Diagnostic{Pos: deferStmt.Pos(), Message: msg}
+
+		// Blank assignments may be what keeps a variable or import used.
+		file := importutil.FindFileForPos(pass, deferStmt.Pos())
+		if file == nil || !referencesReadOutside(pass, file, lit.Body, noops) {
+			pass.Report(diag)
+			continue
+		}
+
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{
+				Message:   "remove the no-op defer",
+				TextEdits: []analysis.TextEdit{editutil.DeleteStmt(pass, deferStmt, deferStmt.Pos())},
+			},
+		}
+		pass.Report(diag)
+	}
+
+	return nil, nil
+}
+
+// noEffect reports whether stmt is empty or a blank assignment of values
+// that cannot panic or call anything when evaluated.
+func noEffect(pass *analysis.Pass, stmt ast.Stmt) bool {
+	switch stmt := stmt.(type) {
+	case *ast.EmptyStmt:
+		return true
+	case *ast.AssignStmt:
+		if stmt.Tok != token.ASSIGN {
+			return false
+		}
+		for _, lhs := range stmt.Lhs {
+			if ident, ok := lhs.(*ast.Ident); !ok || ident.Name != "_" {
+				return false
+			}
+		}
+		for _, rhs := range stmt.Rhs {
+			if !pure(pass, rhs) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// pure reports whether expr is a constant, an identifier, or a qualified
+// identifier from another package.
+func pure(pass *analysis.Pass, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if pass.TypesInfo.Types[expr].Value != nil {
+		return true
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		ident, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		_, ok = pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+		return ok
+	}
+	return false
+}
+
+// referencesReadOutside reports whether every local variable and imported
+// package referenced in body is also read in file outside the no-op defers
+// skip, so that deleting all of them leaves none of them unused.
+func referencesReadOutside(pass *analysis.Pass, file *ast.File, body *ast.BlockStmt, skip []*ast.DeferStmt) bool {
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		ident, isIdent := n.(*ast.Ident)
+		if !isIdent {
+			return ok
+		}
+		obj := pass.TypesInfo.Uses[ident]
+		switch obj := obj.(type) {
+		case *types.PkgName:
+		case *types.Var:
+			if obj.Parent() == pass.Pkg.Scope() {
+				return ok
+			}
+		default:
+			return ok
+		}
+		ok = readOutside(pass, file, obj, skip)
+		return ok
+	})
+	return ok
+}
+
+// readOutside reports whether obj is read in file outside the statements
+// skip. Assigning to obj or incrementing it does not count as a read, as
+// it does not count as a use for the compiler.
+func readOutside(pass *analysis.Pass, file *ast.File, obj types.Object, skip []*ast.DeferStmt) bool {
+	written := make(map[*ast.Ident]bool)
+	read := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.DeferStmt:
+			if slices.Contains(skip, n) {
+				return false
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if ident, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+					written[ident] = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if ident, ok := ast.Unparen(n.X).(*ast.Ident); ok {
+				written[ident] = true
+			}
+		case *ast.Ident:
+			if !written[n] && pass.TypesInfo.Uses[n] == obj {
+				read = true
+			}
+		}
+		return !read
+	})
+	return read
+}
@@ -0,0 +1,13 @@
+package noopdefercheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/noopdefercheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestNoopDeferCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, noopdefercheck.Analyzer, "noopdefertest")
+}
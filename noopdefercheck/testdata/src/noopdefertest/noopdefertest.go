@@ -0,0 +1,101 @@
+package noopdefertest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+func empty() {
+	defer func() {}() // want `deferred function literal has no effect`
+	fmt.Println("work")
+}
+
+func emptyMultiline() {
+	defer func() { // want `deferred function literal has no effect`
+	}()
+	fmt.Println("work")
+}
+
+func blankAssign(f *os.File) {
+	defer func() { _ = f }() // want `deferred function literal has no effect`
+	fmt.Println(f.Name())
+}
+
+func blankConstAndPackageVar() {
+	defer func() { // want `deferred function literal has no effect`
+		_ = 42
+		_ = io.EOF
+	}()
+	fmt.Println(io.EOF)
+}
+
+// The blank assignment is the only use of x, so removing the defer would
+// not compile.
+func onlyUse() {
+	x := 1
+	defer func() { _ = x }() // want `deferred function literal has no effect`
+}
+
+// Assigning to y is not a use, so the blank assignment is its only read.
+func onlyReadAfterAssign() {
+	y := 1
+	y = 2
+	defer func() { _ = y }() // want `deferred function literal has no effect`
+}
+
+// Each defer is the only read of x once the other is removed.
+func twoDefers() {
+	x := 1
+	defer func() { _ = x }() // want `deferred function literal has no effect`
+	defer func() { _ = x }() // want `deferred function literal has no effect`
+}
+
+// The blank assignment is the only use of the io import.
+func onlyImportUse() {
+	defer func() { _ = io.ErrUnexpectedEOF }() // want `deferred function literal has no effect`
+}
+
+func closes(f *os.File) {
+	defer func() { _ = f.Close() }()
+}
+
+func unlocks(mu *sync.Mutex) {
+	mu.Lock()
+	defer func() { mu.Unlock() }()
+}
+
+func recovers() {
+	defer func() {
+		_ = recover()
+	}()
+}
+
+// Reading a field can panic on a nil pointer.
+type conn struct{ id int }
+
+func readsField(c *conn) {
+	defer func() { _ = c.id }()
+}
+
+// Arguments are evaluated when the defer runs.
+func withArgs() {
+	defer func(s string) {}(fmt.Sprint(1))
+}
+
+func setsResult() (err error) {
+	defer func() { err = nil }()
+	return io.EOF
+}
+
+func namedFunc(f func()) {
+	defer f()
+}
+
+func inLoop(files []*os.File) {
+	for _, f := range files {
+		defer func() { _ = f }() // want `deferred function literal has no effect`
+		fmt.Println(f)
+	}
+}
@@ -0,0 +1,157 @@
+// Package panicstringcheck defines an analyzer that detects panics with
+// string values where an error value would serve recover handlers better.
+//
+// # Analyzer panicstringcheck
+//
+// panicstringcheck: detect panic with a string instead of an error
+//
+// This analyzer flags calls to the panic builtin whose argument is a string,
+// either built with fmt.Sprintf or passed directly:
+//
+//	panic(fmt.Sprintf("bad index %d", i))
+//	panic("unreachable")
+//
+// A recovered error value can be inspected with errors.Is and errors.As and
+// can wrap a cause, while a string cannot:
+//
+//	panic(fmt.Errorf("bad index %d", i))
+//	panic(errors.New("unreachable"))
+//
+// This is a style preference, so diagnostics are report-only by default.
+// With -fix-panic-strings, a suggested fix rewrites fmt.Sprintf to
+// fmt.Errorf and wraps plain strings in errors.New.
+package panicstringcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "panicstringcheck",
+	Doc:      "detect panic with a string instead of an error",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// fixPanicStrings enables suggested fixes for flagged panics.
+var fixPanicStrings bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&fixPanicStrings, "fix-panic-strings", false,
+		"offer fixes rewriting panic(fmt.Sprintf(...)) to panic(fmt.Errorf(...)) and panic(s) to panic(errors.New(s))")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	// Track which files have already received an import TextEdit for "errors"
+	// to avoid duplicate edits when multiple diagnostics exist in the same file.
+	importEditAdded := map[string]bool{}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		if !isBuiltinPanic(pass, call) {
+			return
+		}
+		arg := call.Args[0]
+
+		if sprintf, ok := arg.(*ast.CallExpr); ok && isFmtSprintf(pass, sprintf) {
+			msg := "panic with fmt.Sprintf; use fmt.Errorf so recover handlers get an error"
+			diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+			if fixPanicStrings {
+				sel := sprintf.Fun.(*ast.SelectorExpr)
+				diag.SuggestedFixes = []analysis.SuggestedFix{
+					{
+						Message: msg,
+						TextEdits: []analysis.TextEdit{
+							{Pos: sel.Sel.Pos(), End: sel.Sel.End(), NewText: []byte("Errorf")},
+						},
+					},
+				}
+			}
+			pass.Report(diag)
+			return
+		}
+
+		t := pass.TypesInfo.TypeOf(arg)
+		if t == nil {
+			return
+		}
+		basic, ok := t.Underlying().(*types.Basic)
+		if !ok || basic.Info()&types.IsString == 0 {
+			return
+		}
+
+		msg := "panic with a string; use errors.New so recover handlers get an error"
+		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+
+		// errors.New takes a string, so named string types would need a
+		// conversion; leave those report-only.
+		if _, named := t.(*types.Named); fixPanicStrings && !named {
+			edits := []analysis.TextEdit{
+				{Pos: arg.Pos(), End: arg.Pos(), NewText: []byte("errors.New(")},
+				{Pos: arg.End(), End: arg.End(), NewText: []byte(")")},
+			}
+
+			// Add "errors" import if not already added for this file.
+			file := importutil.FindFileForPos(pass, call.Pos())
+			fileName := pass.Fset.File(call.Pos()).Name()
+			if file != nil && !importEditAdded[fileName] {
+				if ie := importutil.AddImportEdit(pass, file, "errors"); ie != nil {
+					edits = append(edits, *ie)
+					importEditAdded[fileName] = true
+				}
+			}
+
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: msg, TextEdits: edits},
+			}
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// isBuiltinPanic reports whether call is a call to the builtin panic.
+func isBuiltinPanic(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "panic" || len(call.Args) != 1 {
+		return false
+	}
+	if obj := pass.TypesInfo.ObjectOf(fun); obj != nil && obj.Pkg() != nil {
+		return false // not the builtin
+	}
+	return true
+}
+
+// isFmtSprintf reports whether call is fmt.Sprintf.
+func isFmtSprintf(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Sprintf" {
+		return false
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return false
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return false
+	}
+
+	return pkgName.Imported().Path() == "fmt"
+}
@@ -0,0 +1,25 @@
+package panicstringcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/panicstringcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestPanicStringCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, panicstringcheck.Analyzer, "panicstringtest")
+}
+
+func TestPanicStringCheckFix(t *testing.T) {
+	if err := panicstringcheck.Analyzer.Flags.Set("fix-panic-strings", "true"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = panicstringcheck.Analyzer.Flags.Set("fix-panic-strings", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, panicstringcheck.Analyzer, "panicstringtest")
+}
@@ -0,0 +1,53 @@
+package panicstringtest
+
+import (
+	"fmt"
+	"log"
+)
+
+type reason string
+
+func example(i int, msg string, r reason) {
+	if i < 0 {
+		// Should be flagged: fmt.Sprintf argument.
+		panic(fmt.Sprintf("bad index %d", i)) // want "panic with fmt.Sprintf; use fmt.Errorf"
+	}
+	if i == 0 {
+		// Should be flagged: string literal.
+		panic("unreachable") // want "panic with a string; use errors.New"
+	}
+	if i == 1 {
+		// Should be flagged: string variable.
+		panic(msg) // want "panic with a string; use errors.New"
+	}
+	if i == 2 {
+		// Should be flagged without a fix: named string type.
+		panic(r) // want "panic with a string; use errors.New"
+	}
+}
+
+type myErr struct{}
+
+func (myErr) Error() string { return "boom" }
+
+func noMatch(err error) {
+	if err != nil {
+		// Error values — should NOT be flagged.
+		panic(err)
+	}
+	if err == nil {
+		panic(fmt.Errorf("wrapped: %w", err))
+	}
+	if err == nil {
+		panic(myErr{})
+	}
+
+	// Non-string values — should NOT be flagged.
+	if err == nil {
+		panic(42)
+	}
+
+	// Shadowed panic — should NOT be flagged.
+	panic := func(v any) { log.Print(v) }
+	panic("not the builtin")
+}
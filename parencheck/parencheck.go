@@ -0,0 +1,162 @@
+// Package parencheck defines an analyzer that detects parentheses that do
+// not affect how an expression is parsed.
+//
+// # Analyzer parencheck
+//
+// parencheck: detect redundant parentheses
+//
+// This analyzer flags parenthesized expressions where the parentheses are
+// provably unnecessary:
+//
+//	if (x > 0) { ... }     // the whole condition of if, for, or switch
+//	return (a + b)         // a whole return result
+//	y := (x)               // an operand that is already atomic
+//
+// These can be written without parentheses:
+//
+//	if x > 0 { ... }
+//	return a + b
+//	y := x
+//
+// Parentheses inside larger expressions are only flagged when they wrap an
+// atomic operand (an identifier, literal, call, index, selector, or similar),
+// so precedence is never affected. Parentheses around a composite literal in
+// an if, for, or switch header are required by the parser and are left
+// alone.
+package parencheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "parencheck",
+	Doc:      "detect redundant parentheses",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ParenExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		paren := n.(*ast.ParenExpr)
+		parent := stack[len(stack)-2]
+
+		if !isAtomic(paren.X) && !isWholeExpr(paren, parent) {
+			return true
+		}
+
+		if inControlHeader(paren, stack) && containsCompositeLit(paren.X) {
+			return true // if (T{}) == x { — the parser needs these
+		}
+
+		msg := fmt.Sprintf("redundant parentheses around %s", types.ExprString(paren.X))
+		pass.Report(analysis.Diagnostic{
+			Pos:     paren.Pos(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message: msg,
+					TextEdits: []analysis.TextEdit{
+						{Pos: paren.Lparen, End: paren.Lparen + 1, NewText: nil},
+						{Pos: paren.Rparen, End: paren.Rparen + 1, NewText: nil},
+					},
+				},
+			},
+		})
+		return true
+	})
+
+	return nil, nil
+}
+
+// isAtomic reports whether expr binds at least as tightly as any operator,
+// so parentheses around it never change the parse.
+func isAtomic(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.CallExpr, *ast.IndexExpr, *ast.IndexListExpr,
+		*ast.SelectorExpr, *ast.SliceExpr, *ast.TypeAssertExpr, *ast.ParenExpr:
+		return true
+	}
+	return false
+}
+
+// isWholeExpr reports whether paren is the entire condition of an if or for
+// statement, the tag of a switch statement, or an entire return result.
+func isWholeExpr(paren *ast.ParenExpr, parent ast.Node) bool {
+	switch p := parent.(type) {
+	case *ast.IfStmt:
+		return p.Cond == paren
+	case *ast.ForStmt:
+		return p.Cond == paren
+	case *ast.SwitchStmt:
+		return p.Tag == paren
+	case *ast.ReturnStmt:
+		for _, r := range p.Results {
+			if r == paren {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// inControlHeader reports whether paren appears in the header of an if,
+// for, range, or switch statement, where an unparenthesized composite
+// literal would be parsed as the statement body.
+func inControlHeader(paren *ast.ParenExpr, stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		var lbrace token.Pos
+		switch s := stack[i].(type) {
+		case *ast.FuncLit:
+			return false // a function body resets the context
+		case *ast.IfStmt:
+			lbrace = s.Body.Lbrace
+		case *ast.ForStmt:
+			lbrace = s.Body.Lbrace
+		case *ast.RangeStmt:
+			lbrace = s.Body.Lbrace
+		case *ast.SwitchStmt:
+			lbrace = s.Body.Lbrace
+		case *ast.TypeSwitchStmt:
+			lbrace = s.Body.Lbrace
+		default:
+			continue
+		}
+		if paren.Pos() < lbrace {
+			return true
+		}
+	}
+	return false
+}
+
+// containsCompositeLit reports whether expr contains a composite literal
+// outside of any function literal body.
+func containsCompositeLit(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.CompositeLit:
+			found = true
+		case *ast.FuncLit:
+			return false
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,68 @@
+package parencheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/parencheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestParenCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, parencheck.Analyzer, "parentest")
+}
+
+// gofmt already strips parentheses around if, for, and switch headers, so
+// these cases cannot live in the (gofmt-checked) testdata directory.
+const unformatted = `package headertest
+
+type point struct{ x int }
+
+func conditions(x int, p point) {
+	if (x > 0) { // want "redundant parentheses around x > 0"
+		_ = x
+	}
+	for (x < 10) { // want "redundant parentheses around x < 10"
+		x++
+	}
+	switch (x % 2) { // want "redundant parentheses around x % 2"
+	case 0:
+	}
+	if (p == point{}) {
+		_ = x
+	}
+}
+`
+
+const unformattedGolden = `package headertest
+
+type point struct{ x int }
+
+func conditions(x int, p point) {
+	if x > 0 { // want "redundant parentheses around x > 0"
+		_ = x
+	}
+	for x < 10 { // want "redundant parentheses around x < 10"
+		x++
+	}
+	switch x % 2 { // want "redundant parentheses around x % 2"
+	case 0:
+	}
+	if (p == point{}) {
+		_ = x
+	}
+}
+`
+
+func TestParenCheckControlHeaders(t *testing.T) {
+	dir, cleanup, err := analysistest.WriteFiles(map[string]string{
+		"headertest/headertest.go":        unformatted,
+		"headertest/headertest.go.golden": unformattedGolden,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+
+	analysistest.RunWithSuggestedFixes(t, dir, parencheck.Analyzer, "headertest")
+}
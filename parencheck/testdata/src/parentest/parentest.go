@@ -0,0 +1,52 @@
+package parentest
+
+type point struct{ x, y int }
+
+func f() int { return 1 }
+
+func operands(x int, ok bool) {
+	// Should be flagged: atomic operand inside a larger expression.
+	_ = (ok) && x > 0 // want `redundant parentheses around ok`
+}
+
+func returns(a, b int) (int, int) {
+	// Should be flagged: whole return results.
+	return (a + b), (a * b) // want `redundant parentheses around a \+ b` `redundant parentheses around a \* b`
+}
+
+func atomic(s []int, m map[string]int) {
+	// Should be flagged: call, index, and selector operands.
+	_ = (f()) + 1    // want `redundant parentheses around f\(\)`
+	_ = (s[0]) * 2   // want `redundant parentheses around s\[0\]`
+	_ = (m["k"]) - 1 // want `redundant parentheses around m\["k"\]`
+	p := point{1, 2}
+	_ = (p.x) + p.y // want `redundant parentheses around p\.x`
+}
+
+func noMatch(a, b, c int, p *point) {
+	// Precedence — should NOT be flagged.
+	_ = (a + b) * c
+	_ = a - (b - c)
+
+	// Dereference before selector — should NOT be flagged.
+	_ = (*p).x
+
+	// Conversion to a pointer type — should NOT be flagged.
+	_ = (*int)(nil)
+
+	// Composite literal in an if header — required, should NOT be flagged.
+	if (point{1, 2}) == *p {
+		_ = a
+	}
+	if p.x == (point{}).x {
+		_ = a
+	}
+
+	// Immediately invoked function literal — should NOT be flagged.
+	_ = (func() int { return 1 })()
+
+	// Receive inside a larger expression — should NOT be flagged.
+	ch := make(chan int, 1)
+	ch <- 1
+	_ = (<-ch) + 1
+}
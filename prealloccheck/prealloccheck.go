@@ -0,0 +1,313 @@
+// Package prealloccheck defines an analyzer that detects slices preallocated
+// for a different number of elements than the loop after them appends.
+//
+// # Analyzer prealloccheck
+//
+// prealloccheck: detect capacity hints that do not match the appends
+//
+// This analyzer flags a slice made with zero length and a capacity, followed
+// by a loop appending one element per iteration, when the capacity and the
+// iteration count are both known and differ:
+//
+//	names := make([]string, 0, len(users))
+//	for _, g := range groups {
+//	    names = append(names, g.Name)
+//	}
+//
+// The hint was most likely copied from a loop over another collection: with
+// more groups than users the slice grows anyway, and with fewer it holds
+// memory it never uses.
+//
+// A count is known when it is len(x) of a slice, array, pointer to array, or
+// map, or a constant. The capacity comes from make; the iteration count from
+// ranging over a collection or an integer, or from a loop of the form
+// for i := 0; i < n; i++. The loop must directly follow make and append to
+// the slice exactly once, unconditionally, with no break, continue, return,
+// or goto in its body. Counts of different kinds, such as a length and a
+// constant, are not compared. Diagnostics are report-only.
+package prealloccheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "prealloccheck",
+	Doc:      "detect capacity hints that do not match the appends",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i+1 < len(list); i++ {
+			slice, capacity, ok := preallocation(pass, list[i])
+			if !ok {
+				continue
+			}
+			iterations, body, ok := iterationCount(pass, list[i+1])
+			if !ok || !appendsOnce(pass, body, slice) {
+				continue
+			}
+			if !mismatch(pass, capacity, iterations) {
+				continue
+			}
+			count := types.ExprString(iterations)
+			if _, isLen := lenArg(pass, iterations); !isLen && pass.TypesInfo.Types[iterations].Value == nil {
+				count = "len(" + count + ")"
+			}
+			pass.Reportf(capacity.Pos(),
+				"%s is made with capacity %s but the loop after it appends %s elements",
+				slice.Name(), types.ExprString(capacity), count)
+		}
+	})
+
+	return nil, nil
+}
+
+// preallocation matches s := make([]T, 0, n) and returns s and n.
+func preallocation(pass *analysis.Pass, stmt ast.Stmt) (types.Object, ast.Expr, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok || len(call.Args) != 3 || !isBuiltin(pass, call.Fun, "make") {
+		return nil, nil, false
+	}
+	if tv := pass.TypesInfo.Types[call.Args[1]]; tv.Value == nil || constant.Sign(tv.Value) != 0 {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	if obj == nil {
+		return nil, nil, false
+	}
+	return obj, call.Args[2], true
+}
+
+// iterationCount returns the number of iterations of the loop stmt, as
+// len(x), a constant, or the collection ranged over, with the loop body.
+func iterationCount(pass *analysis.Pass, stmt ast.Stmt) (ast.Expr, *ast.BlockStmt, bool) {
+	switch loop := stmt.(type) {
+	case *ast.RangeStmt:
+		t := pass.TypesInfo.TypeOf(loop.X)
+		if t == nil {
+			return nil, nil, false
+		}
+		switch u := t.Underlying().(type) {
+		case *types.Slice, *types.Array, *types.Map:
+		case *types.Pointer:
+			if _, ok := u.Elem().Underlying().(*types.Array); !ok {
+				return nil, nil, false
+			}
+		case *types.Basic:
+			if u.Info()&types.IsInteger == 0 {
+				return nil, nil, false
+			}
+		default:
+			return nil, nil, false
+		}
+		return loop.X, loop.Body, true
+
+	case *ast.ForStmt:
+		// for i := 0; i < n; i++
+		init, ok := loop.Init.(*ast.AssignStmt)
+		if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+			return nil, nil, false
+		}
+		if tv := pass.TypesInfo.Types[init.Rhs[0]]; tv.Value == nil || constant.Sign(tv.Value) != 0 {
+			return nil, nil, false
+		}
+		index, ok := init.Lhs[0].(*ast.Ident)
+		if !ok {
+			return nil, nil, false
+		}
+		obj := pass.TypesInfo.Defs[index]
+		cond, ok := loop.Cond.(*ast.BinaryExpr)
+		if !ok || cond.Op != token.LSS || !isObj(pass, cond.X, obj) {
+			return nil, nil, false
+		}
+		post, ok := loop.Post.(*ast.IncDecStmt)
+		if !ok || post.Tok != token.INC || !isObj(pass, post.X, obj) {
+			return nil, nil, false
+		}
+		if assigns(pass, loop.Body, obj) {
+			return nil, nil, false
+		}
+		return cond.Y, loop.Body, true
+	}
+	return nil, nil, false
+}
+
+// appendsOnce reports whether body appends a single element to s exactly
+// once at its top level, assigns s nowhere else, and cannot leave an
+// iteration early.
+func appendsOnce(pass *analysis.Pass, body *ast.BlockStmt, s types.Object) bool {
+	var appendStmt ast.Stmt
+	for _, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 || !isObj(pass, assign.Lhs[0], s) {
+			continue
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || len(call.Args) != 2 || call.Ellipsis.IsValid() || !isBuiltin(pass, call.Fun, "append") || !isObj(pass, call.Args[0], s) {
+			return false
+		}
+		if appendStmt != nil {
+			return false
+		}
+		appendStmt = stmt
+	}
+	if appendStmt == nil {
+		return false
+	}
+
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.BranchStmt, *ast.ReturnStmt:
+			ok = false
+		case *ast.AssignStmt:
+			if n != appendStmt && assigns(pass, n, s) {
+				ok = false
+			}
+		}
+		return ok
+	})
+	return ok
+}
+
+// mismatch reports whether capacity and iterations are both lengths of
+// different expressions, or both constants with different values.
+func mismatch(pass *analysis.Pass, capacity, iterations ast.Expr) bool {
+	capTV, iterTV := pass.TypesInfo.Types[capacity], pass.TypesInfo.Types[iterations]
+	if capTV.Value != nil || iterTV.Value != nil {
+		return capTV.Value != nil && iterTV.Value != nil &&
+			capTV.Value.Kind() == constant.Int && iterTV.Value.Kind() == constant.Int &&
+			!constant.Compare(capTV.Value, token.EQL, iterTV.Value)
+	}
+
+	capX, ok := lenArg(pass, capacity)
+	if !ok {
+		return false
+	}
+	// The loop count is either len(x) or the collection x ranged over.
+	iterX, ok := lenArg(pass, iterations)
+	if !ok {
+		iterX = iterations
+		if t := pass.TypesInfo.TypeOf(iterX); t == nil {
+			return false
+		} else if _, isBasic := t.Underlying().(*types.Basic); isBasic {
+			return false // ranging over an integer variable
+		}
+	}
+	if !stable(capX) || !stable(iterX) {
+		return false
+	}
+	return types.ExprString(ast.Unparen(capX)) != types.ExprString(ast.Unparen(iterX))
+}
+
+// lenArg returns x if expr is a call of the builtin len on a slice, array,
+// pointer to array, or map.
+func lenArg(pass *analysis.Pass, expr ast.Expr) (ast.Expr, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || !isBuiltin(pass, call.Fun, "len") {
+		return nil, false
+	}
+	t := pass.TypesInfo.TypeOf(call.Args[0])
+	if t == nil {
+		return nil, false
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map:
+		return call.Args[0], true
+	case *types.Pointer:
+		if _, ok := u.Elem().Underlying().(*types.Array); ok {
+			return call.Args[0], true
+		}
+	}
+	return nil, false
+}
+
+// stable reports whether expr is an identifier or a selector chain of
+// identifiers, which names the same collection wherever it is written.
+func stable(expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return stable(expr.X)
+	}
+	return false
+}
+
+// isBuiltin reports whether fun is the builtin function name.
+func isBuiltin(pass *analysis.Pass, fun ast.Expr, name string) bool {
+	ident, ok := ast.Unparen(fun).(*ast.Ident)
+	if !ok || ident.Name != name {
+		return false
+	}
+	_, ok = pass.TypesInfo.Uses[ident].(*types.Builtin)
+	return ok
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	if expr == nil || obj == nil {
+		return false
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == obj
+}
+
+// assigns reports whether n assigns to obj.
+func assigns(pass *analysis.Pass, n ast.Node, obj types.Object) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isObj(pass, lhs, obj) {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if isObj(pass, n.X, obj) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
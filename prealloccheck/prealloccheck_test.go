@@ -0,0 +1,13 @@
+package prealloccheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/prealloccheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestPreallocCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, prealloccheck.Analyzer, "prealloctest")
+}
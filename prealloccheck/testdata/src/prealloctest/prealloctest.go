@@ -0,0 +1,86 @@
+package prealloctest
+
+type user struct{ Name string }
+
+type group struct{ Name string }
+
+func mismatchedLen(users []user, groups []group) []string {
+	names := make([]string, 0, len(users)) // want `names is made with capacity len\(users\) but the loop after it appends len\(groups\) elements`
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+func mismatchedIndexLoop(a, b []int) []int {
+	out := make([]int, 0, len(a)) // want `out is made with capacity len\(a\) but the loop after it appends len\(b\) elements`
+	for i := 0; i < len(b); i++ {
+		out = append(out, b[i]*2)
+	}
+	return out
+}
+
+func mismatchedConst() []int {
+	out := make([]int, 0, 8) // want `out is made with capacity 8 but the loop after it appends 16 elements`
+	for i := range 16 {
+		out = append(out, i)
+	}
+	return out
+}
+
+type index struct{ byName map[string]int }
+
+func mismatchedField(idx index, keys []string) []string {
+	out := make([]string, 0, len(idx.byName)) // want `out is made with capacity len\(idx\.byName\) but the loop after it appends len\(keys\) elements`
+	for _, k := range keys {
+		out = append(out, k)
+	}
+	return out
+}
+
+func notFlagged(users []user, groups []group, n int) []string {
+	// Matching preallocation — should NOT be flagged.
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Name)
+	}
+
+	// Conditional append — should NOT be flagged.
+	admins := make([]string, 0, len(users))
+	for _, g := range groups {
+		if g.Name == "admin" {
+			admins = append(admins, g.Name)
+		}
+	}
+
+	// Loop may stop early — should NOT be flagged.
+	first := make([]string, 0, len(users))
+	for _, g := range groups {
+		if g.Name == "" {
+			break
+		}
+		first = append(first, g.Name)
+	}
+
+	// Length against a variable — should NOT be flagged: unknown relation.
+	some := make([]string, 0, n)
+	for _, g := range groups {
+		some = append(some, g.Name)
+	}
+
+	// Two appends per iteration — should NOT be flagged.
+	pairs := make([]string, 0, len(users))
+	for _, u := range users {
+		pairs = append(pairs, u.Name)
+		pairs = append(pairs, u.Name)
+	}
+
+	// Not right after make — should NOT be flagged.
+	late := make([]string, 0, len(users))
+	late = append(late, "x")
+	for _, g := range groups {
+		late = append(late, g.Name)
+	}
+
+	return append(append(append(names, admins...), append(first, some...)...), append(pairs, late...)...)
+}
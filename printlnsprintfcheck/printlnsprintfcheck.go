@@ -0,0 +1,123 @@
+// Package printlnsprintfcheck defines an analyzer that detects fmt.Sprintf
+// results printed with fmt.Print or fmt.Println.
+//
+// # Analyzer printlnsprintfcheck
+//
+// printlnsprintfcheck: detect fmt.Sprintf passed to fmt.Print or fmt.Println
+//
+// This analyzer flags print calls whose only argument is built with
+// fmt.Sprintf:
+//
+//	fmt.Println(fmt.Sprintf("x=%d", n))
+//	fmt.Print(fmt.Sprintf("x=%d", n))
+//
+// fmt.Printf formats directly, so the intermediate string is unnecessary:
+//
+//	fmt.Printf("x=%d\n", n)
+//	fmt.Printf("x=%d", n)
+//
+// Println ends its output with a newline and Printf does not, so the fix for
+// Println adds \n to the end of the format: inside the literal when the
+// format is an interpreted string literal, and as + "\n" otherwise. The fix
+// is withheld when the format is not a constant, since go vet flags Printf
+// calls with a non-constant format.
+package printlnsprintfcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "printlnsprintfcheck",
+	Doc:      "detect fmt.Sprintf passed to fmt.Print or fmt.Println",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) != 1 || call.Ellipsis.IsValid() {
+			return
+		}
+		name := fmtFunc(pass, call)
+		if name != "Print" && name != "Println" {
+			return
+		}
+		sprintf, ok := ast.Unparen(call.Args[0]).(*ast.CallExpr)
+		if !ok || fmtFunc(pass, sprintf) != "Sprintf" || len(sprintf.Args) == 0 || sprintf.Ellipsis.IsValid() {
+			return
+		}
+
+		msg := fmt.Sprintf("fmt.Sprintf passed to fmt.%s; use fmt.Printf with the format and arguments directly", name)
+		if name == "Println" {
+			msg += `, ending the format with \n`
+		}
+		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+		if edits := buildFix(pass, call, sprintf, name == "Println"); edits != nil {
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{Message: "use fmt.Printf", TextEdits: edits},
+			}
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// fmtFunc returns the name of the fmt function call calls, or "".
+func fmtFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "fmt" || fn.Signature().Recv() != nil {
+		return ""
+	}
+	return fn.Name()
+}
+
+// buildFix returns TextEdits renaming the print call to Printf and removing
+// the fmt.Sprintf call around its arguments, adding a newline to the format
+// when newline is set. It returns nil when the format is not a constant.
+func buildFix(pass *analysis.Pass, call, sprintf *ast.CallExpr, newline bool) []analysis.TextEdit {
+	format := sprintf.Args[0]
+	if tv, ok := pass.TypesInfo.Types[format]; !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+	var name *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.SelectorExpr:
+		name = fun.Sel
+	case *ast.Ident:
+		name = fun
+	default:
+		return nil
+	}
+
+	edits := []analysis.TextEdit{
+		{Pos: name.Pos(), End: name.End(), NewText: []byte("Printf")},
+		{Pos: call.Lparen + 1, End: sprintf.Lparen + 1},
+		{Pos: sprintf.Rparen, End: call.Rparen},
+	}
+	if newline {
+		if lit, ok := format.(*ast.BasicLit); ok && lit.Kind == token.STRING && lit.Value[0] == '"' {
+			edits = append(edits, analysis.TextEdit{Pos: lit.End() - 1, End: lit.End() - 1, NewText: []byte(`\n`)})
+		} else {
+			edits = append(edits, analysis.TextEdit{Pos: format.End(), End: format.End(), NewText: []byte(` + "\n"`)})
+		}
+	}
+	return edits
+}
@@ -0,0 +1,13 @@
+package printlnsprintfcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/printlnsprintfcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestPrintlnSprintfCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, printlnsprintfcheck.Analyzer, "printlnsprintftest")
+}
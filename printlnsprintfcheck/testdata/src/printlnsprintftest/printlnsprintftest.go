@@ -0,0 +1,36 @@
+package printlnsprintftest
+
+import "fmt"
+
+const prefixFormat = "%s: %d"
+
+func printLines(n int, name string) {
+	fmt.Println(fmt.Sprintf("x=%d", n))             // want `fmt.Sprintf passed to fmt.Println; use fmt.Printf with the format and arguments directly, ending the format with \\n`
+	fmt.Println(fmt.Sprintf("%s=%d", name, n))      // want `fmt.Sprintf passed to fmt.Println`
+	fmt.Println(fmt.Sprintf(prefixFormat, name, n)) // want `fmt.Sprintf passed to fmt.Println`
+	fmt.Println(fmt.Sprintf(`raw "%s"`, name))      // want `fmt.Sprintf passed to fmt.Println`
+	fmt.Println(fmt.Sprintf("a=%d "+"b=%d", n, n))  // want `fmt.Sprintf passed to fmt.Println`
+	fmt.Println(fmt.Sprintf("done"))                // want `fmt.Sprintf passed to fmt.Println`
+	fmt.Println(fmt.Sprintf("x=%d",                 // want `fmt.Sprintf passed to fmt.Println`
+		n))
+}
+
+func printPlain(n int) {
+	fmt.Print(fmt.Sprintf("x=%d\n", n)) // want `fmt.Sprintf passed to fmt.Print; use fmt.Printf with the format and arguments directly$`
+	fmt.Print(fmt.Sprintf("x=%d", n))   // want `fmt.Sprintf passed to fmt.Print;`
+}
+
+// A non-constant format is reported without a fix.
+func dynamic(format string, n int) {
+	fmt.Println(fmt.Sprintf(format, n)) // want `fmt.Sprintf passed to fmt.Println`
+}
+
+// Not flagged.
+func notFlagged(n int, args []any) {
+	fmt.Println("x", fmt.Sprintf("%d", n))
+	fmt.Println(fmt.Sprint(n))
+	fmt.Printf("%s\n", fmt.Sprintf("x=%d", n))
+	fmt.Println(fmt.Sprintf("%d %d", args...))
+	s := fmt.Sprintf("x=%d", n)
+	fmt.Println(s)
+}
@@ -0,0 +1,95 @@
+// Package rangeblankcheck defines an analyzer that detects blank identifiers
+// in range clauses that can be dropped.
+//
+// # Analyzer rangeblankcheck
+//
+// rangeblankcheck: detect redundant blank identifiers in range clauses
+//
+// This analyzer flags range clauses whose trailing variables are all the
+// blank identifier:
+//
+//	for i, _ := range s {
+//	    use(i)
+//	}
+//	for _ = range s {
+//	    tick()
+//	}
+//
+// The blanks can be omitted:
+//
+//	for i := range s {
+//	    use(i)
+//	}
+//	for range s {
+//	    tick()
+//	}
+//
+// A blank key followed by a named value, as in for _, v := range s, is
+// required to reach the value and is not flagged.
+package rangeblankcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "rangeblankcheck",
+	Doc:      "detect redundant blank identifiers in range clauses",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		rng := n.(*ast.RangeStmt)
+		if rng.Key == nil {
+			return
+		}
+
+		xStr := types.ExprString(rng.X)
+		var msg string
+		var edit analysis.TextEdit
+		switch {
+		case isBlank(rng.Key) && (rng.Value == nil || isBlank(rng.Value)):
+			// for _ = range x, for _, _ = range x: drop the whole
+			// variable list and the assignment token.
+			msg = fmt.Sprintf("redundant blank identifiers in range clause; use for range %s", xStr)
+			edit = analysis.TextEdit{Pos: rng.Key.Pos(), End: rng.Range}
+		case rng.Value != nil && isBlank(rng.Value):
+			// for i, _ := range x: drop the blank value.
+			keyStr := types.ExprString(rng.Key)
+			msg = fmt.Sprintf("redundant blank value in range clause; use for %s %s range %s", keyStr, rng.Tok, xStr)
+			edit = analysis.TextEdit{Pos: rng.Key.End(), End: rng.Value.End()}
+		default:
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     rng.Pos(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{Message: msg, TextEdits: []analysis.TextEdit{edit}},
+			},
+		})
+	})
+
+	return nil, nil
+}
+
+// isBlank reports whether expr is the blank identifier.
+func isBlank(expr ast.Expr) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
@@ -0,0 +1,13 @@
+package rangeblankcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/rangeblankcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRangeBlankCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, rangeblankcheck.Analyzer, "rangeblanktest")
+}
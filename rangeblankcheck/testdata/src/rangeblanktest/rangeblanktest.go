@@ -0,0 +1,47 @@
+package rangeblanktest
+
+import "fmt"
+
+func indexOnly(s []string) {
+	for i, _ := range s { // want `redundant blank value in range clause; use for i := range s`
+		fmt.Println(i)
+	}
+}
+
+func indexOnlyAssign(m map[string]int) {
+	var k string
+	for k, _ = range m { // want `redundant blank value in range clause; use for k = range m`
+		fmt.Println(k)
+	}
+}
+
+func blankKey(s []int) {
+	for _ = range s { // want `redundant blank identifiers in range clause; use for range s`
+		fmt.Println("tick")
+	}
+}
+
+func blankBoth(m map[int]bool) {
+	for _, _ = range m { // want `redundant blank identifiers in range clause; use for range m`
+		fmt.Println("tick")
+	}
+}
+
+// The blank key is needed to reach the value.
+func valueOnly(s []string) {
+	for _, v := range s {
+		fmt.Println(v)
+	}
+}
+
+func keyOnly(s []string) {
+	for i := range s {
+		fmt.Println(i)
+	}
+}
+
+func bare(s []string) {
+	for range s {
+		fmt.Println("tick")
+	}
+}
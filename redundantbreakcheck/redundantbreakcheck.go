@@ -0,0 +1,93 @@
+// Package redundantbreakcheck defines an analyzer that detects break
+// statements at the end of switch and select clauses.
+//
+// # Analyzer redundantbreakcheck
+//
+// redundantbreakcheck: detect redundant break at the end of a case clause
+//
+// Go case clauses do not fall through, so an unlabeled break as the last
+// statement of a case or default clause has no effect:
+//
+//	switch x {
+//	case 1:
+//	    doThing()
+//	    break
+//	}
+//
+// The break can be removed:
+//
+//	switch x {
+//	case 1:
+//	    doThing()
+//	}
+//
+// Labeled breaks are not flagged, since they may exit an enclosing loop.
+// Breaks nested inside a statement within the clause are not the last
+// statement of the clause and are not flagged either.
+package redundantbreakcheck
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "redundantbreakcheck",
+	Doc:      "detect redundant break at the end of a case clause",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const msg = "redundant break at the end of a case clause"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var body []ast.Stmt
+		var colon token.Pos
+		switch clause := n.(type) {
+		case *ast.CaseClause:
+			body, colon = clause.Body, clause.Colon
+		case *ast.CommClause:
+			body, colon = clause.Body, clause.Colon
+		}
+
+		if len(body) == 0 {
+			return
+		}
+		br, ok := body[len(body)-1].(*ast.BranchStmt)
+		if !ok || br.Tok != token.BREAK || br.Label != nil {
+			return
+		}
+
+		// The break is removed back to the end of whatever precedes it.
+		prevEnd := colon + 1
+		if len(body) > 1 {
+			prevEnd = body[len(body)-2].End()
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     br.Pos(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message:   msg,
+					TextEdits: []analysis.TextEdit{editutil.DeleteStmt(pass, br, prevEnd)},
+				},
+			},
+		})
+	})
+
+	return nil, nil
+}
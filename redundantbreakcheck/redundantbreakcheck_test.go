@@ -0,0 +1,13 @@
+package redundantbreakcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/redundantbreakcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRedundantBreakCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, redundantbreakcheck.Analyzer, "redundantbreaktest")
+}
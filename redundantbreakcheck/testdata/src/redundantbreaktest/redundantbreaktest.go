@@ -0,0 +1,73 @@
+package redundantbreaktest
+
+func doThing() {}
+
+func example(x int, v any, ch chan int) {
+	// Should be flagged: trailing break in a case.
+	switch x {
+	case 1:
+		doThing()
+		break // want "redundant break at the end of a case clause"
+	case 2:
+		doThing()
+	}
+
+	// Should be flagged: trailing break in default.
+	switch {
+	default:
+		doThing()
+		break // want "redundant break at the end of a case clause"
+	}
+
+	// Should be flagged: type switch.
+	switch v.(type) {
+	case int:
+		break // want "redundant break at the end of a case clause"
+	}
+
+	// Should be flagged: select clause.
+	select {
+	case <-ch:
+		doThing()
+		break // want "redundant break at the end of a case clause"
+	default:
+	}
+}
+
+func noMatch(xs []int) {
+	// Labeled break exits the loop — should NOT be flagged.
+Outer:
+	for _, x := range xs {
+		switch x {
+		case 0:
+			doThing()
+			break Outer
+		}
+	}
+
+	// Break in the middle of a clause — should NOT be flagged.
+	for _, x := range xs {
+		switch x {
+		case 1:
+			if x > 0 {
+				break
+			}
+			doThing()
+		}
+	}
+
+	// Break inside a nested loop within the case — should NOT be flagged.
+	switch len(xs) {
+	case 2:
+		for range xs {
+			doThing()
+			break
+		}
+	}
+
+	// Break at the end of a loop body (not a case clause) — should NOT be flagged.
+	for range xs {
+		doThing()
+		break
+	}
+}
@@ -0,0 +1,90 @@
+// Package redundantcontinuecheck defines an analyzer that detects continue
+// statements at the end of loop bodies.
+//
+// # Analyzer redundantcontinuecheck
+//
+// redundantcontinuecheck: detect redundant continue at the end of a loop body
+//
+// An unlabeled continue as the last statement of a for or range body has no
+// effect, since the loop moves on to the next iteration anyway:
+//
+//	for _, x := range xs {
+//	    process(x)
+//	    continue
+//	}
+//
+// The continue can be removed:
+//
+//	for _, x := range xs {
+//	    process(x)
+//	}
+//
+// Labeled continues are not flagged, since they may target an enclosing
+// loop. A continue nested inside an if, switch, or select within the body
+// is not the last statement of the body and is not flagged either.
+package redundantcontinuecheck
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "redundantcontinuecheck",
+	Doc:      "detect redundant continue at the end of a loop body",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const msg = "redundant continue at the end of a loop body"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ForStmt)(nil),
+		(*ast.RangeStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var body *ast.BlockStmt
+		switch loop := n.(type) {
+		case *ast.ForStmt:
+			body = loop.Body
+		case *ast.RangeStmt:
+			body = loop.Body
+		}
+
+		if body == nil || len(body.List) == 0 {
+			return
+		}
+		cont, ok := body.List[len(body.List)-1].(*ast.BranchStmt)
+		if !ok || cont.Tok != token.CONTINUE || cont.Label != nil {
+			return
+		}
+
+		// The continue is removed back to the end of whatever precedes it.
+		prevEnd := body.Lbrace + 1
+		if len(body.List) > 1 {
+			prevEnd = body.List[len(body.List)-2].End()
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     cont.Pos(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{
+				{
+					Message:   msg,
+					TextEdits: []analysis.TextEdit{editutil.DeleteStmt(pass, cont, prevEnd)},
+				},
+			},
+		})
+	})
+
+	return nil, nil
+}
@@ -0,0 +1,13 @@
+package redundantcontinuecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/redundantcontinuecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRedundantContinueCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, redundantcontinuecheck.Analyzer, "redundantcontinuetest")
+}
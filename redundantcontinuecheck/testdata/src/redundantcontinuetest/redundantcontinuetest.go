@@ -0,0 +1,59 @@
+package redundantcontinuetest
+
+func process(int) {}
+
+func example(xs []int) {
+	// Should be flagged: trailing continue in a range body.
+	for _, x := range xs {
+		process(x)
+		continue // want "redundant continue at the end of a loop body"
+	}
+
+	// Should be flagged: trailing continue in a three-clause for.
+	for i := 0; i < len(xs); i++ {
+		process(xs[i])
+		continue // want "redundant continue at the end of a loop body"
+	}
+
+	// Should be flagged: continue is the only statement.
+	for range xs {
+		continue // want "redundant continue at the end of a loop body"
+	}
+}
+
+func noMatch(xs []int, ch chan int) {
+	// Continue in the middle of the body skips the rest — should NOT be flagged.
+	for _, x := range xs {
+		if x < 0 {
+			continue
+		}
+		process(x)
+	}
+
+	// Labeled continue targets the outer loop — should NOT be flagged.
+Outer:
+	for _, x := range xs {
+		for range xs {
+			process(x)
+			continue Outer
+		}
+	}
+
+	// Continue at the end of a switch case inside the loop — the switch is
+	// the last statement, not the continue — should NOT be flagged.
+	for _, x := range xs {
+		switch x {
+		case 0:
+			continue
+		}
+	}
+
+	// Continue at the end of a select case inside the loop — should NOT be flagged.
+	for range xs {
+		select {
+		case <-ch:
+			continue
+		default:
+		}
+	}
+}
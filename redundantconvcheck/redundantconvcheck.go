@@ -0,0 +1,165 @@
+// Package redundantconvcheck defines an analyzer that detects type
+// conversions that have no effect.
+//
+// # Analyzer redundantconvcheck
+//
+// redundantconvcheck: detect redundant conversions in comparisons with untyped constants
+//
+// This analyzer flags a conversion used as one operand of a comparison when
+// the other operand is an untyped constant and the operand being converted
+// already has the same underlying type:
+//
+//	var n int
+//	if int(n) == 5 { ... }
+//
+// These can be replaced with:
+//
+//	if n == 5 { ... }
+//
+// Removing the conversion changes which type the untyped constant is
+// converted to, so the analyzer only fires when the operand and the
+// conversion target share an underlying type. Comparisons against typed
+// operands and conversions that widen or narrow the operand are left alone.
+package redundantconvcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "redundantconvcheck",
+	Doc:      "detect redundant conversions in comparisons with untyped constants",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		binExpr := n.(*ast.BinaryExpr)
+
+		switch binExpr.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+		default:
+			return
+		}
+
+		// The conversion may appear on either side.
+		if conv, ok := binExpr.X.(*ast.CallExpr); ok && isUntypedConst(pass, binExpr.Y) {
+			checkConversion(pass, conv, binExpr.Op)
+		}
+		if conv, ok := binExpr.Y.(*ast.CallExpr); ok && isUntypedConst(pass, binExpr.X) {
+			checkConversion(pass, conv, binExpr.Op)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkConversion reports conv if it is a conversion T(x) where x is a
+// non-constant value whose underlying type is identical to that of T. op is
+// the comparison conv is an operand of.
+func checkConversion(pass *analysis.Pass, conv *ast.CallExpr, op token.Token) {
+	if len(conv.Args) != 1 || conv.Ellipsis.IsValid() {
+		return
+	}
+
+	// The callee must be a type, making this a conversion.
+	funTV, ok := pass.TypesInfo.Types[conv.Fun]
+	if !ok || !funTV.IsType() {
+		return
+	}
+
+	arg := conv.Args[0]
+	argTV, ok := pass.TypesInfo.Types[arg]
+	if !ok || argTV.Value != nil {
+		return // constant operand — the conversion picks its type
+	}
+
+	if !types.Identical(argTV.Type.Underlying(), funTV.Type.Underlying()) {
+		return
+	}
+
+	// Interfaces compare dynamically; leave them alone.
+	if types.IsInterface(funTV.Type) {
+		return
+	}
+
+	typeStr := types.ExprString(conv.Fun)
+	argStr := types.ExprString(arg)
+	msg := fmt.Sprintf("conversion to %s is redundant in comparison with an untyped constant; use %s",
+		typeStr, argStr)
+
+	// Drop T( and ). Keep the parentheses when the operand is a binary
+	// expression that binds no tighter than the comparison.
+	var edits []analysis.TextEdit
+	if needsParens(arg, op) {
+		edits = []analysis.TextEdit{
+			{Pos: conv.Fun.Pos(), End: conv.Lparen, NewText: nil},
+		}
+	} else {
+		edits = []analysis.TextEdit{
+			{Pos: conv.Pos(), End: arg.Pos(), NewText: nil},
+			{Pos: arg.End(), End: conv.End(), NewText: nil},
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     conv.Pos(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{
+			{
+				Message:   msg,
+				TextEdits: edits,
+			},
+		},
+	})
+}
+
+// isUntypedConst reports whether expr is a constant expression built only
+// from untyped operands (literals and untyped named constants). The type
+// checker records the final, converted type for such operands, so this
+// is decided from the syntax and the declared constant types.
+func isUntypedConst(pass *analysis.Pass, expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		c, ok := pass.TypesInfo.ObjectOf(e).(*types.Const)
+		if !ok {
+			return false
+		}
+		basic, ok := c.Type().(*types.Basic)
+		return ok && basic.Info()&types.IsUntyped != 0
+	case *ast.SelectorExpr:
+		// Qualified constant, e.g. math.MaxInt32.
+		return isUntypedConst(pass, e.Sel)
+	case *ast.ParenExpr:
+		return isUntypedConst(pass, e.X)
+	case *ast.UnaryExpr:
+		return isUntypedConst(pass, e.X)
+	case *ast.BinaryExpr:
+		return isUntypedConst(pass, e.X) && isUntypedConst(pass, e.Y)
+	default:
+		return false
+	}
+}
+
+// needsParens reports whether expr must stay parenthesized when it becomes an
+// operand of the comparison op.
+func needsParens(expr ast.Expr, op token.Token) bool {
+	binExpr, ok := expr.(*ast.BinaryExpr)
+	return ok && binExpr.Op.Precedence() <= op.Precedence()
+}
@@ -0,0 +1,13 @@
+package redundantconvcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/redundantconvcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRedundantConvCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, redundantconvcheck.Analyzer, "redundantconvtest")
+}
@@ -0,0 +1,63 @@
+package redundantconvtest
+
+import "math"
+
+type Celsius float64
+
+const limit = 10
+
+const typedLimit int = 10
+
+func example(n int, c Celsius, ok, done bool) {
+	// Should be flagged: n is already an int.
+	_ = int(n) == 5 // want "conversion to int is redundant in comparison with an untyped constant; use n"
+
+	// Should be flagged: constant on the left.
+	_ = 5 < int(n) // want "conversion to int is redundant in comparison with an untyped constant; use n"
+
+	// Should be flagged: untyped named constant.
+	_ = int(n) != limit // want "conversion to int is redundant in comparison with an untyped constant; use n"
+
+	// Should be flagged: untyped constant expression.
+	_ = int(n) >= limit*2+1 // want "conversion to int is redundant in comparison with an untyped constant; use n"
+
+	// Should be flagged: same underlying type, constant converts to Celsius.
+	_ = float64(c) > 36.6 // want "conversion to float64 is redundant in comparison with an untyped constant; use c"
+
+	// Should be flagged: untyped qualified constant.
+	_ = int(n) < math.MaxInt32 // want "conversion to int is redundant in comparison with an untyped constant; use n"
+
+	// Should be flagged: logical operand keeps its parentheses.
+	_ = bool(ok && done) == true // want "conversion to bool is redundant in comparison with an untyped constant; use ok && done"
+
+	// Should be flagged: comparison operand keeps its parentheses.
+	_ = true == bool(n < limit) // want "conversion to bool is redundant in comparison with an untyped constant; use n < limit"
+
+	// Should be flagged: arithmetic binds tighter and needs no parentheses.
+	_ = int(n+1) == 3 // want "conversion to int is redundant in comparison with an untyped constant; use n \\+ 1"
+}
+
+func noMatch(n int, small int32, f float64, c Celsius) {
+	// Widening conversion — removing it would compare as int32 and the
+	// constant would overflow. Should NOT be flagged.
+	_ = int64(small) == 1<<40
+
+	// Different underlying type — the conversion matters. Should NOT be flagged.
+	_ = float64(n) == 2.5
+
+	// Typed other operand — should NOT be flagged.
+	_ = float64(c) == f
+
+	// Typed named constant — should NOT be flagged.
+	_ = int(n) == typedLimit
+
+	// Untyped qualified constant, but the operand is converted from a
+	// different type. Should NOT be flagged.
+	_ = float64(n) < math.Pi
+
+	// Constant operand — the conversion picks its type. Should NOT be flagged.
+	_ = float64(3) == 3
+
+	// Not a comparison — should NOT be flagged.
+	_ = int(n) + 5
+}
@@ -0,0 +1,232 @@
+// Package redundantzerocheck defines an analyzer that detects assignments of
+// a zero value to something that was just zero-initialized.
+//
+// # Analyzer redundantzerocheck
+//
+// redundantzerocheck: detect zero-value assignments right after zero initialization
+//
+// This analyzer flags assignments that store a type's zero value into a
+// variable or struct field that is already zero because it was just
+// declared or built from a composite literal that omits the field:
+//
+//	p := Point{Y: 1}
+//	p.X = 0      // X was omitted from the literal, so it is already 0
+//
+//	var name string
+//	name = ""    // already ""
+//
+// Zero values are recognized per type: 0 for numbers, "" for strings, false
+// for booleans, nil for pointers, slices, maps, channels, functions, and
+// interfaces, and T{} for structs and arrays.
+//
+// Field assignments are tracked through the run of assignments directly
+// following the initialization, so p.X = 0 is still flagged after p.Y = 2,
+// but not after p.X = 5. Fields promoted from embedded structs are not
+// tracked, since reaching them may go through a nil pointer.
+//
+// The assignment sometimes documents intent, so diagnostics are
+// report-only.
+package redundantzerocheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "redundantzerocheck",
+	Doc:      "detect zero-value assignments right after zero initialization",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		block := n.(*ast.BlockStmt)
+		for i, stmt := range block.List {
+			obj, lit, ok := zeroInit(pass, stmt)
+			if !ok {
+				continue
+			}
+			checkFollowing(pass, obj, lit, block.List[i+1:])
+		}
+	})
+
+	return nil, nil
+}
+
+// zeroInit matches a statement that declares a variable with a zero value or
+// a struct literal that may leave fields zero:
+//
+//	var x T
+//	x := T{...}
+//	x := &T{...}
+//	var x = T{...}
+//
+// It returns the variable and, for the composite literal forms, the literal.
+func zeroInit(pass *analysis.Pass, stmt ast.Stmt) (types.Object, *ast.CompositeLit, bool) {
+	var name *ast.Ident
+	var value ast.Expr
+	switch stmt := stmt.(type) {
+	case *ast.AssignStmt:
+		if stmt.Tok != token.DEFINE || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return nil, nil, false
+		}
+		name, _ = stmt.Lhs[0].(*ast.Ident)
+		value = stmt.Rhs[0]
+
+	case *ast.DeclStmt:
+		gd, ok := stmt.Decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR || len(gd.Specs) != 1 {
+			return nil, nil, false
+		}
+		spec := gd.Specs[0].(*ast.ValueSpec)
+		if len(spec.Names) != 1 || len(spec.Values) > 1 {
+			return nil, nil, false
+		}
+		name = spec.Names[0]
+		if len(spec.Values) == 0 {
+			obj := pass.TypesInfo.Defs[name]
+			return obj, nil, obj != nil
+		}
+		value = spec.Values[0]
+	}
+	if name == nil || name.Name == "_" {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Defs[name]
+	if obj == nil {
+		return nil, nil, false
+	}
+
+	if unary, ok := value.(*ast.UnaryExpr); ok && unary.Op == token.AND {
+		value = unary.X
+	}
+	lit, ok := value.(*ast.CompositeLit)
+	if !ok {
+		return nil, nil, false
+	}
+	if _, ok := pass.TypesInfo.TypeOf(lit).Underlying().(*types.Struct); !ok {
+		return nil, nil, false
+	}
+	return obj, lit, true
+}
+
+// checkFollowing reports zero-value assignments to obj, or to fields of obj
+// that lit left unset, among the assignments at the start of stmts.
+func checkFollowing(pass *analysis.Pass, obj types.Object, lit *ast.CompositeLit, stmts []ast.Stmt) {
+	// Track which fields are still zero. Without a literal, fields are only
+	// tracked for struct variables; a pointer declared with var x *T is nil.
+	_, isStruct := obj.Type().Underlying().(*types.Struct)
+	trackFields := lit != nil || isStruct
+	setFields := make(map[string]bool)
+	if lit != nil {
+		for _, elt := range lit.Elts {
+			kv, ok := elt.(*ast.KeyValueExpr)
+			if !ok {
+				// Positional literal: every field is set.
+				return
+			}
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				setFields[key.Name] = true
+			}
+		}
+	}
+
+	for _, stmt := range stmts {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			return
+		}
+		lhs, rhs := assign.Lhs[0], assign.Rhs[0]
+
+		// var x T followed by x = zero. Any assignment to the whole
+		// variable ends the run.
+		if ident, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == obj {
+			if lit == nil && len(setFields) == 0 && isZero(pass, rhs, obj.Type()) {
+				pass.Reportf(assign.Pos(), "assignment of zero value to %s, which is already zero", ident.Name)
+			}
+			return
+		}
+		if !trackFields {
+			return
+		}
+
+		sel, ok := lhs.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		recv, ok := sel.X.(*ast.Ident)
+		if !ok || pass.TypesInfo.ObjectOf(recv) != obj {
+			return
+		}
+		selection := pass.TypesInfo.Selections[sel]
+		if selection == nil || selection.Kind() != types.FieldVal || len(selection.Index()) != 1 {
+			return
+		}
+		// The assigned value must not read the variable being built.
+		if mentions(pass, rhs, obj) {
+			return
+		}
+
+		field := sel.Sel.Name
+		if !setFields[field] && isZero(pass, rhs, selection.Type()) {
+			pass.Reportf(assign.Pos(), "assignment of zero value to %s.%s, which is already zero", recv.Name, field)
+		}
+		setFields[field] = true
+	}
+}
+
+// isZero reports whether expr is the zero value of t.
+func isZero(pass *analysis.Pass, expr ast.Expr, t types.Type) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	if !ok {
+		return false
+	}
+	if tv.IsNil() {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		if tv.Value == nil {
+			return false
+		}
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return tv.Value.Kind() == constant.Bool && !constant.BoolVal(tv.Value)
+		case u.Info()&types.IsString != 0:
+			return tv.Value.Kind() == constant.String && constant.StringVal(tv.Value) == ""
+		case u.Info()&types.IsNumeric != 0:
+			return constant.Sign(tv.Value) == 0
+		}
+	case *types.Struct, *types.Array:
+		lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+		return ok && len(lit.Elts) == 0
+	}
+	return false
+}
+
+// mentions reports whether expr refers to obj.
+func mentions(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == obj {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,13 @@
+package redundantzerocheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/redundantzerocheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRedundantZeroCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, redundantzerocheck.Analyzer, "redundantzerotest")
+}
@@ -0,0 +1,83 @@
+package redundantzerotest
+
+type Point struct {
+	X, Y  int
+	Label string
+	Tags  []string
+	Next  *Point
+	Ok    bool
+	Inner struct{ A int }
+}
+
+type Base struct{ ID int }
+
+type Derived struct {
+	*Base
+	Name string
+}
+
+func example() {
+	// Should be flagged: field omitted from the literal.
+	p := Point{Y: 1}
+	p.X = 0 // want `assignment of zero value to p\.X, which is already zero`
+
+	// Should be flagged: every kind of zero value on an empty literal.
+	q := &Point{}
+	q.Y = 2
+	q.Label = ""                // want `assignment of zero value to q\.Label, which is already zero`
+	q.Tags = nil                // want `assignment of zero value to q\.Tags, which is already zero`
+	q.Next = nil                // want `assignment of zero value to q\.Next, which is already zero`
+	q.Ok = false                // want `assignment of zero value to q\.Ok, which is already zero`
+	q.Inner = struct{ A int }{} // want `assignment of zero value to q\.Inner, which is already zero`
+
+	// Should be flagged: var declaration then zero assignment.
+	var name string
+	name = "" // want `assignment of zero value to name, which is already zero`
+
+	// Should be flagged: struct var declaration then zero field.
+	var r Point
+	r.X = 0 // want `assignment of zero value to r\.X, which is already zero`
+
+	_, _, _, _ = p, q, name, r
+}
+
+func noMatch(n int) {
+	// Field set in the literal — should NOT be flagged.
+	p := Point{X: 3}
+	p.X = 0
+
+	// Non-zero assignment — should NOT be flagged.
+	q := Point{}
+	q.X = 5
+
+	// Field reassigned after a non-zero value — should NOT be flagged.
+	r := Point{}
+	r.X = n
+	r.X = 0
+
+	// Positional literal sets every field — should NOT be flagged.
+	type pair struct{ A, B int }
+	s := pair{1, 2}
+	s.A = 0
+
+	// Not adjacent — should NOT be flagged.
+	t := Point{}
+	n++
+	t.X = 0
+
+	// Promoted through an embedded pointer — should NOT be flagged.
+	d := Derived{}
+	d.Name = "x"
+	d.ID = 0
+
+	// Pointer declared without a literal is nil — should NOT be flagged.
+	var u *Point
+	u = &Point{}
+	u.X = 0
+
+	// Initialized with a non-zero value — should NOT be flagged.
+	count := 1
+	count = 0
+
+	_, _, _, _, _, _, _, _ = p, q, r, s, t, d, u, count
+}
@@ -0,0 +1,121 @@
+// Package regexpcompilecheck defines an analyzer that detects regular
+// expressions with constant patterns compiled inside function bodies.
+//
+// # Analyzer regexpcompilecheck
+//
+// regexpcompilecheck: detect constant regexps compiled on every function call
+//
+// This analyzer flags calls to regexp.MustCompile, regexp.Compile, and their
+// POSIX variants whose pattern is a constant string, when the call appears
+// inside a function body:
+//
+//	func isID(s string) bool {
+//	    re := regexp.MustCompile(`^[a-z]+[0-9]*$`)
+//	    return re.MatchString(s)
+//	}
+//
+// The pattern is recompiled every time the function runs. Since it never
+// changes, it can be compiled once in a package-level variable:
+//
+//	var idRE = regexp.MustCompile(`^[a-z]+[0-9]*$`)
+//
+// No auto-fix is provided, since choosing a variable name and placement is
+// up to the author. Calls in init functions, which run once, and calls with
+// a pattern computed at run time are not flagged.
+package regexpcompilecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "regexpcompilecheck",
+	Doc:      "detect constant regexps compiled on every function call",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// compileFuncs are the regexp functions that compile a pattern.
+var compileFuncs = map[string]bool{
+	"MustCompile":      true,
+	"Compile":          true,
+	"MustCompilePOSIX": true,
+	"CompilePOSIX":     true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		call := n.(*ast.CallExpr)
+		path, name := pkgFunc(pass, call)
+		if path != "regexp" || !compileFuncs[name] || len(call.Args) != 1 {
+			return true
+		}
+		if !isConstString(pass, call.Args[0]) || !inFunctionBody(stack) {
+			return true
+		}
+
+		pass.Reportf(call.Pos(),
+			"regexp.%s with a constant pattern compiles it on every call; hoist it to a package-level variable", name)
+		return true
+	})
+
+	return nil, nil
+}
+
+// inFunctionBody reports whether the last node in stack is inside a
+// function body that may run more than once. Package-level initializers and
+// init functions run once and are not considered.
+func inFunctionBody(stack []ast.Node) bool {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.FuncLit:
+			return true
+		case *ast.FuncDecl:
+			return n.Recv != nil || n.Name.Name != "init"
+		}
+	}
+	return false
+}
+
+// isConstString reports whether expr is a constant string expression.
+func isConstString(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.Value != nil && tv.Value.Kind() == constant.String
+}
+
+// pkgFunc returns the import path and function name of a qualified call
+// like pkg.Func(...), or empty strings if call is not one.
+func pkgFunc(pass *analysis.Pass, call *ast.CallExpr) (path, name string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return "", ""
+	}
+
+	return pkgName.Imported().Path(), sel.Sel.Name
+}
@@ -0,0 +1,13 @@
+package regexpcompilecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/regexpcompilecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRegexpCompileCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, regexpcompilecheck.Analyzer, "regexpcompiletest")
+}
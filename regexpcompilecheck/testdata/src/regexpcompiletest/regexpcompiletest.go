@@ -0,0 +1,53 @@
+package regexpcompiletest
+
+import (
+	"regexp"
+	re "regexp"
+)
+
+const idPattern = `^[a-z]+[0-9]*$`
+
+// Package-level compile runs once — should NOT be flagged.
+var wordRE = regexp.MustCompile(`\w+`)
+
+func isID(s string) bool {
+	// Should be flagged: compiled on every call.
+	r := regexp.MustCompile(`^[a-z]+[0-9]*$`) // want `regexp\.MustCompile with a constant pattern compiles it on every call; hoist it to a package-level variable`
+	return r.MatchString(s)
+}
+
+func isIDConst(s string) bool {
+	// Should be flagged: named constant pattern, Compile variant.
+	r, err := regexp.Compile(idPattern) // want `regexp\.Compile with a constant pattern compiles it on every call`
+	return err == nil && r.MatchString(s)
+}
+
+func aliased(s string) bool {
+	// Should be flagged: aliased import and POSIX variant.
+	return re.MustCompilePOSIX("a+b").MatchString(s) // want `regexp\.MustCompilePOSIX with a constant pattern compiles it on every call`
+}
+
+var matcher = func(s string) bool {
+	// Should be flagged: function literals run on every call.
+	return regexp.MustCompile("x+").MatchString(s) // want `regexp\.MustCompile with a constant pattern compiles it on every call`
+}
+
+type T struct{}
+
+func (T) init() {
+	// Should be flagged: a method named init is an ordinary method.
+	_ = regexp.MustCompile("y") // want `regexp\.MustCompile with a constant pattern compiles it on every call`
+}
+
+var numRE *regexp.Regexp
+
+func init() {
+	// init runs once — should NOT be flagged.
+	numRE = regexp.MustCompile(`[0-9]+`)
+}
+
+func dynamic(pattern string) bool {
+	// Pattern computed at run time — should NOT be flagged.
+	r := regexp.MustCompile(pattern)
+	return r.MatchString("x") && wordRE.MatchString("y")
+}
@@ -0,0 +1,244 @@
+// Package repeatsearchcheck defines an analyzer that detects a substring
+// search repeated to find where a match already known to exist is.
+//
+// # Analyzer repeatsearchcheck
+//
+// repeatsearchcheck: detect strings.Contains guards followed by strings.Index
+//
+// This analyzer flags if statements guarded by strings.Contains whose body
+// calls strings.Index with the same arguments:
+//
+//	if strings.Contains(s, sep) {
+//	    i := strings.Index(s, sep)
+//	    key, value = s[:i], s[i+len(sep):]
+//	}
+//
+// Both calls scan s for sep. Index alone answers both questions:
+//
+//	if i := strings.Index(s, sep); i >= 0 {
+//	    key, value = s[:i], s[i+len(sep):]
+//	}
+//
+// The bytes package and the ContainsRune/IndexRune, ContainsAny/IndexAny,
+// and ContainsFunc/IndexFunc pairs are covered too. A suggested fix is
+// offered when the condition is the Contains call alone and the body starts
+// with i := strings.Index(...). When the Contains result is combined with
+// other conditions or the Index call appears later, the diagnostic is
+// report-only.
+package repeatsearchcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/editutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "repeatsearchcheck",
+	Doc:      "detect strings.Contains guards followed by strings.Index",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// indexFuncs maps each Contains function to the Index function searching
+// for the same thing.
+var indexFuncs = map[string]string{
+	"Contains":     "Index",
+	"ContainsRune": "IndexRune",
+	"ContainsAny":  "IndexAny",
+	"ContainsFunc": "IndexFunc",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		ifStmt := n.(*ast.IfStmt)
+		for _, cond := range conjuncts(ifStmt.Cond) {
+			contains, ok := ast.Unparen(cond).(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			pkg, name := searchFunc(pass, contains)
+			indexName, ok := indexFuncs[name]
+			if !ok || len(contains.Args) != 2 || !stable(pass, contains.Args[0]) || !stable(pass, contains.Args[1]) {
+				continue
+			}
+			index := findIndex(pass, ifStmt.Body, contains, pkg, indexName)
+			if index == nil {
+				continue
+			}
+			report(pass, ifStmt, contains, index)
+			return
+		}
+	})
+
+	return nil, nil
+}
+
+// conjuncts returns the operands of a chain of && in cond, or cond itself.
+func conjuncts(cond ast.Expr) []ast.Expr {
+	if bin, ok := ast.Unparen(cond).(*ast.BinaryExpr); ok && bin.Op == token.LAND {
+		return append(conjuncts(bin.X), conjuncts(bin.Y)...)
+	}
+	return []ast.Expr{cond}
+}
+
+// searchFunc returns the package path and name of the strings or bytes
+// function call calls, or empty strings.
+func searchFunc(pass *analysis.Pass, call *ast.CallExpr) (pkg, name string) {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Signature().Recv() != nil {
+		return "", ""
+	}
+	if path := fn.Pkg().Path(); path != "strings" && path != "bytes" {
+		return "", ""
+	}
+	return fn.Pkg().Path(), fn.Name()
+}
+
+// findIndex returns the first call in body to the Index function of pkg
+// named indexName with the same arguments as contains, or nil. The search
+// stops at an assignment to either argument and skips function literals.
+func findIndex(pass *analysis.Pass, body *ast.BlockStmt, contains *ast.CallExpr, pkg, indexName string) *ast.CallExpr {
+	var found *ast.CallExpr
+	stopped := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if found != nil || stopped {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if sameExpr(pass, lhs, contains.Args[0]) || sameExpr(pass, lhs, contains.Args[1]) {
+					stopped = true
+					return false
+				}
+			}
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if p, name := searchFunc(pass, n); p == pkg && name == indexName && len(n.Args) == 2 &&
+				sameExpr(pass, n.Args[0], contains.Args[0]) && sameExpr(pass, n.Args[1], contains.Args[1]) {
+				found = n
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// report reports the repeated search, with a fix when the body starts by
+// declaring the index.
+func report(pass *analysis.Pass, ifStmt *ast.IfStmt, contains, index *ast.CallExpr) {
+	indexStr := types.ExprString(index)
+	diag := analysis.Diagnostic{
+		Pos:     contains.Pos(),
+		Message: fmt.Sprintf("%s repeats the search of %s; call it once and test the index against 0", indexStr, types.ExprString(contains)),
+	}
+	if name, edits := buildFix(pass, ifStmt, contains, index); edits != nil {
+		diag.Message = fmt.Sprintf("%s repeats the search of %s; use if %s := %s; %s >= 0",
+			indexStr, types.ExprString(contains), name, indexStr, name)
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{Message: "search once with " + types.ExprString(index.Fun), TextEdits: edits},
+		}
+	}
+	pass.Report(diag)
+}
+
+// buildFix returns the index name and TextEdits moving i := Index(...) from
+// the start of the body into the if header, in place of the Contains
+// condition. It returns nil edits when the if is in any other form.
+func buildFix(pass *analysis.Pass, ifStmt *ast.IfStmt, contains, index *ast.CallExpr) (string, []analysis.TextEdit) {
+	if ifStmt.Init != nil || ast.Unparen(ifStmt.Cond) != contains || len(ifStmt.Body.List) == 0 {
+		return "", nil
+	}
+	first, ok := ifStmt.Body.List[0].(*ast.AssignStmt)
+	if !ok || first.Tok != token.DEFINE || len(first.Lhs) != 1 || len(first.Rhs) != 1 || first.Rhs[0] != ast.Expr(index) {
+		return "", nil
+	}
+	i, ok := first.Lhs[0].(*ast.Ident)
+	if !ok || i.Name == "_" {
+		return "", nil
+	}
+
+	// Declaring i in the header puts it in scope of the else branch too,
+	// where it could shadow a variable of the same name.
+	if ifStmt.Else != nil && mentions(ifStmt.Else, i.Name) {
+		return "", nil
+	}
+
+	del, ok := editutil.DeleteLines(pass, first.Pos(), first.End())
+	if !ok {
+		return "", nil
+	}
+	tokFile := pass.Fset.File(index.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return "", nil
+	}
+	indexSrc := src[tokFile.Offset(index.Pos()):tokFile.Offset(index.End())]
+	return i.Name, []analysis.TextEdit{
+		{Pos: ifStmt.Cond.Pos(), End: ifStmt.Cond.End(), NewText: fmt.Appendf(nil, "%s := %s; %s >= 0", i.Name, indexSrc, i.Name)},
+		del,
+	}
+}
+
+// stable reports whether evaluating expr twice yields the same value without
+// side effects: a constant, a variable, or a field selection on one.
+func stable(pass *analysis.Pass, expr ast.Expr) bool {
+	expr = ast.Unparen(expr)
+	if pass.TypesInfo.Types[expr].Value != nil {
+		return true
+	}
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return stable(pass, expr.X)
+	}
+	return false
+}
+
+// sameExpr reports whether a and b denote the same value: the same constant,
+// or the same identifier or selector chain referring to the same objects.
+func sameExpr(pass *analysis.Pass, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	if va, vb := pass.TypesInfo.Types[a].Value, pass.TypesInfo.Types[b].Value; va != nil || vb != nil {
+		return va != nil && vb != nil && constant.Compare(va, token.EQL, vb)
+	}
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) != nil && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameExpr(pass, a.X, b.X)
+	}
+	return false
+}
+
+// mentions reports whether n contains an identifier named name.
+func mentions(n ast.Node, name string) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == name {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,13 @@
+package repeatsearchcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/repeatsearchcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRepeatSearchCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, repeatsearchcheck.Analyzer, "repeatsearchtest")
+}
@@ -0,0 +1,86 @@
+package repeatsearchtest
+
+import (
+	"bytes"
+	"strings"
+	"unicode"
+)
+
+func split(s, sep string) (key, value string) {
+	if strings.Contains(s, sep) { // want `strings.Index\(s, sep\) repeats the search of strings.Contains\(s, sep\); use if i := strings.Index\(s, sep\); i >= 0`
+		i := strings.Index(s, sep)
+		key, value = s[:i], s[i+len(sep):]
+	}
+	return key, value
+}
+
+func constant(s string) string {
+	if strings.Contains(s, "=") { // want `strings.Index\(s, "="\) repeats the search`
+		eq := strings.Index(s, "=")
+		return s[:eq]
+	} else {
+		return s
+	}
+}
+
+func runes(b []byte) []byte {
+	if bytes.ContainsRune(b, '/') { // want `bytes.IndexRune\(b, '/'\) repeats the search of bytes.ContainsRune\(b, '/'\)`
+		slash := bytes.IndexRune(b, '/')
+		return b[slash+1:]
+	}
+	return b
+}
+
+func funcs(s string) string {
+	if strings.ContainsFunc(s, unicode.IsSpace) { // want `strings.IndexFunc\(s, unicode.IsSpace\) repeats the search`
+		i := strings.IndexFunc(s, unicode.IsSpace)
+		return s[:i]
+	}
+	return s
+}
+
+// Report-only: the Contains result is combined with another condition.
+func combined(s string, ok bool) int {
+	if ok && strings.Contains(s, ":") { // want `strings.Index\(s, ":"\) repeats the search of strings.Contains\(s, ":"\); call it once and test the index against 0`
+		return strings.Index(s, ":")
+	}
+	return -1
+}
+
+// Report-only: the Index call is not the first statement.
+func later(s string, log func(string)) string {
+	if strings.Contains(s, "#") { // want `strings.Index\(s, "#"\) repeats the search of strings.Contains\(s, "#"\); call it once`
+		log(s)
+		i := strings.Index(s, "#")
+		return s[:i]
+	}
+	return s
+}
+
+// Report-only: the else branch uses the same name.
+func shadow(s string, i int) int {
+	if strings.Contains(s, ".") { // want `strings.Index\(s, "."\) repeats the search of strings.Contains\(s, "."\); call it once`
+		i := strings.Index(s, ".")
+		return i
+	} else {
+		return i
+	}
+}
+
+// Not flagged: different arguments, or s changes in between.
+func notFlagged(s, t string) int {
+	if strings.Contains(s, ",") {
+		return strings.Index(t, ",")
+	}
+	if strings.Contains(s, ",") {
+		return strings.Index(s, ";")
+	}
+	if strings.Contains(s, ",") {
+		s = strings.TrimSpace(s)
+		return strings.Index(s, ",")
+	}
+	if strings.Contains(s, ",") {
+		return strings.LastIndex(s, ",")
+	}
+	return 0
+}
@@ -0,0 +1,279 @@
+// Package respbodycheck defines an analyzer that detects HTTP response
+// bodies that are read but never closed.
+//
+// # Analyzer respbodycheck
+//
+// respbodycheck: detect HTTP response bodies that are used but never closed
+//
+// This analyzer flags responses from net/http client calls whose Body is
+// used but never closed in the enclosing function:
+//
+//	resp, err := http.Get(url)
+//	if err != nil {
+//	    return err
+//	}
+//	data, err := io.ReadAll(resp.Body) // connection is never released
+//
+// The fix is to close the body once the error has been checked:
+//
+//	defer resp.Body.Close()
+//
+// Recognized calls are http.Get, http.Head, http.Post, http.PostForm, and
+// the methods of the same names plus Do on *http.Client. A response is
+// considered handled when resp.Body.Close() is called anywhere in the
+// function (including in a defer or closure), when resp.Body is passed to a
+// deferred call, when resp itself is passed on, returned, or stored, or when
+// resp.Body is returned, stored, or passed as an argument whose type has a
+// Close method, such as io.ReadCloser, since the closing responsibility then
+// lies elsewhere. No auto-fix is provided.
+package respbodycheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "respbodycheck",
+	Doc:      "detect HTTP response bodies that are used but never closed",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// clientFuncs lists the net/http package functions and *http.Client methods
+// that return a *http.Response.
+var clientFuncs = map[string]bool{
+	"Get":      true,
+	"Head":     true,
+	"Post":     true,
+	"PostForm": true,
+	"Do":       true, // *http.Client only
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.AssignStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		assign := n.(*ast.AssignStmt)
+
+		if len(assign.Rhs) != 1 || len(assign.Lhs) == 0 {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok || !isHTTPClientCall(pass, call) {
+			return true
+		}
+		respIdent, ok := assign.Lhs[0].(*ast.Ident)
+		if !ok || respIdent.Name == "_" {
+			return true
+		}
+		resp := pass.TypesInfo.ObjectOf(respIdent)
+		if resp == nil {
+			return true
+		}
+
+		body := enclosingFuncBody(stack)
+		if body == nil {
+			return true
+		}
+
+		u := scanUses(pass, body, resp, respIdent)
+		if u.bodyUsed && !u.closed && !u.escapes {
+			pass.Reportf(assign.Pos(),
+				"response body of %s is used but never closed; add defer %s.Body.Close()",
+				respIdent.Name, respIdent.Name)
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// isHTTPClientCall reports whether call is one of the net/http functions or
+// *http.Client methods listed in clientFuncs.
+func isHTTPClientCall(pass *analysis.Pass, call *ast.CallExpr) bool {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !clientFuncs[sel.Sel.Name] {
+		return false
+	}
+
+	// Package-level function: http.Get(...).
+	if ident, ok := sel.X.(*ast.Ident); ok {
+		if pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName); ok {
+			return pkgName.Imported().Path() == "net/http" && sel.Sel.Name != "Do"
+		}
+	}
+
+	// Method on *http.Client: client.Do(req).
+	fn, ok := pass.TypesInfo.ObjectOf(sel.Sel).(*types.Func)
+	if !ok {
+		return false
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "net/http" && obj.Name() == "Client"
+}
+
+// enclosingFuncBody returns the body of the innermost function containing
+// the last node in stack.
+func enclosingFuncBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// respUses summarizes how a response variable is used in a function body.
+type respUses struct {
+	bodyUsed bool // resp.Body appears somewhere
+	closed   bool // resp.Body.Close() is called, or resp.Body is handed to a deferred call
+	escapes  bool // resp is passed, returned, or stored, or resp.Body is handed off
+}
+
+// scanUses walks body and classifies every reference to resp other than
+// the defining identifier def.
+func scanUses(pass *analysis.Pass, body *ast.BlockStmt, resp types.Object, def *ast.Ident) respUses {
+	var u respUses
+	var stack []ast.Node
+	inDefer := 0
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil {
+			if _, ok := stack[len(stack)-1].(*ast.DeferStmt); ok {
+				inDefer--
+			}
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		var parent ast.Node
+		if len(stack) > 0 {
+			parent = stack[len(stack)-1]
+		}
+		stack = append(stack, n)
+		if _, ok := n.(*ast.DeferStmt); ok {
+			inDefer++
+		}
+
+		ident, ok := n.(*ast.Ident)
+		if !ok || ident == def || pass.TypesInfo.ObjectOf(ident) != resp {
+			return true
+		}
+
+		switch p := parent.(type) {
+		case *ast.SelectorExpr:
+			if p.Sel.Name != "Body" {
+				return true // resp.StatusCode, resp.Header, ...
+			}
+			u.bodyUsed = true
+			if inDefer > 0 {
+				u.closed = true
+			}
+			// resp.Body.Close()
+			if len(stack) >= 4 {
+				if closeSel, ok := stack[len(stack)-3].(*ast.SelectorExpr); ok && closeSel.Sel.Name == "Close" {
+					if closeCall, ok := stack[len(stack)-4].(*ast.CallExpr); ok && closeCall.Fun == closeSel {
+						u.closed = true
+					}
+				}
+			}
+			if len(stack) >= 3 && bodyEscapes(pass, p, stack[len(stack)-3]) {
+				u.escapes = true
+			}
+		case *ast.BinaryExpr:
+			// resp != nil — a plain check.
+		case *ast.AssignStmt:
+			// resp, err = ... on the left is a reassignment; on the right it
+			// hands the response to another variable.
+			for _, rhs := range p.Rhs {
+				if rhs == ident {
+					u.escapes = true
+				}
+			}
+		default:
+			// Call arguments, return results, composite literals, channel
+			// sends, and so on.
+			u.escapes = true
+		}
+		return true
+	})
+
+	return u
+}
+
+// bodyEscapes reports whether the resp.Body selector sel, whose parent
+// node is parent, hands the body to code that becomes responsible for
+// closing it: it is returned, stored, sent, or passed as an argument whose
+// parameter type has a Close method.
+func bodyEscapes(pass *analysis.Pass, sel *ast.SelectorExpr, parent ast.Node) bool {
+	switch p := parent.(type) {
+	case *ast.ReturnStmt, *ast.CompositeLit, *ast.KeyValueExpr, *ast.ValueSpec:
+		return true
+	case *ast.SendStmt:
+		return p.Value == sel
+	case *ast.AssignStmt:
+		// _ = resp.Body stores nothing.
+		for i, rhs := range p.Rhs {
+			if rhs != sel || len(p.Lhs) != len(p.Rhs) {
+				continue
+			}
+			if ident, ok := p.Lhs[i].(*ast.Ident); !ok || ident.Name != "_" {
+				return true
+			}
+		}
+	case *ast.CallExpr:
+		sig, ok := pass.TypesInfo.TypeOf(p.Fun).(*types.Signature)
+		if !ok {
+			return false
+		}
+		for i, arg := range p.Args {
+			if arg != sel {
+				continue
+			}
+			var param types.Type
+			switch {
+			case sig.Variadic() && i >= sig.Params().Len()-1:
+				param = sig.Params().At(sig.Params().Len() - 1).Type()
+				if s, ok := param.(*types.Slice); ok && !p.Ellipsis.IsValid() {
+					param = s.Elem()
+				}
+			case i < sig.Params().Len():
+				param = sig.Params().At(i).Type()
+			default:
+				return false
+			}
+			// An io.Reader parameter cannot close the body; an io.ReadCloser
+			// one takes ownership of it.
+			closer, _, _ := types.LookupFieldOrMethod(param, false, nil, "Close")
+			_, ok := closer.(*types.Func)
+			return ok
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package respbodycheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/respbodycheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRespBodyCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, respbodycheck.Analyzer, "respbodytest")
+}
@@ -0,0 +1,149 @@
+package respbodytest
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+func unclosedGet(url string) ([]byte, error) {
+	resp, err := http.Get(url) // want `response body of resp is used but never closed; add defer resp\.Body\.Close\(\)`
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func unclosedPost(url string) error {
+	r, err := http.Post(url, "text/plain", strings.NewReader("x")) // want `response body of r is used but never closed`
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(io.Discard, r.Body)
+	return err
+}
+
+func unclosedClientDo(c *http.Client, req *http.Request) (int, error) {
+	resp, err := c.Do(req) // want `response body of resp is used but never closed`
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, 10)
+	n, _ := resp.Body.Read(buf)
+	return n, nil
+}
+
+func closedDefer(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+func closedExplicit(c *http.Client, url string) error {
+	resp, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	_, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return err
+}
+
+func closedInClosure(url string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	_, err = io.ReadAll(resp.Body)
+	return err
+}
+
+func closeHelper(c io.Closer) { _ = c.Close() }
+
+func closedViaDeferredHelper(url string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer closeHelper(resp.Body)
+	_, err = io.ReadAll(resp.Body)
+	return err
+}
+
+func bodyNeverUsed(url string) (int, error) {
+	// Body not used at all — not flagged (only used-but-unclosed bodies are).
+	resp, err := http.Head(url)
+	if err != nil {
+		return 0, err
+	}
+	return resp.StatusCode, nil
+}
+
+func returned(url string) (*http.Response, error) {
+	// Response handed to the caller — closing is their responsibility.
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	_ = resp.Body
+	return resp, nil
+}
+
+func handle(*http.Response) {}
+
+func passedOn(url string) {
+	// Response passed to another function — not flagged.
+	resp, err := http.Get(url)
+	if err != nil || resp == nil {
+		return
+	}
+	_ = resp.Body
+	handle(resp)
+}
+
+func bodyReturned(url string) (io.ReadCloser, error) {
+	// Body handed to the caller — closing is their responsibility.
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+type stream struct{ body io.ReadCloser }
+
+func bodyStored(url string) (*stream, error) {
+	// Body stored in a value that owns it — not flagged.
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	return &stream{body: resp.Body}, nil
+}
+
+func consume(rc io.ReadCloser) { defer rc.Close() }
+
+func bodyPassedOn(url string) {
+	// Body passed to a function taking an io.ReadCloser — not flagged.
+	resp, err := http.Get(url)
+	if err != nil {
+		return
+	}
+	consume(resp.Body)
+}
+
+func readAll(r io.Reader) { _, _ = io.ReadAll(r) }
+
+func bodyPassedAsReader(url string) {
+	resp, err := http.Get(url) // want `response body of resp is used but never closed`
+	if err != nil {
+		return
+	}
+	readAll(resp.Body)
+}
@@ -0,0 +1,248 @@
+// Package runecountcheck defines an analyzer that detects range loops that
+// only count the runes of a string.
+//
+// # Analyzer runecountcheck
+//
+// runecountcheck: detect range loops over strings that count runes
+//
+// This analyzer flags a counter initialized to zero and immediately followed
+// by a range loop over a string whose body does nothing but increment it:
+//
+//	count := 0
+//	for range s {
+//	    count++
+//	}
+//
+// Ranging over a string visits its runes, so the loop counts them, which
+// utf8.RuneCountInString does directly:
+//
+//	count := utf8.RuneCountInString(s)
+//
+// A string of a named type is converted to string first. len(s) would
+// count bytes instead. Loops over slices, arrays, and maps,
+// where len is the answer, are left to countcheck. The fix adds the
+// "unicode/utf8" import when needed; it is withheld when comments would be
+// deleted with the loop, when the file imports unicode/utf8 under another
+// name, or when the name utf8 refers to something else at the loop.
+package runecountcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "runecountcheck",
+	Doc:      "detect range loops over strings that count runes",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	// Track which files have already had a "unicode/utf8" import edit.
+	importEditAdded := map[string]bool{}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i := 0; i+1 < len(list); i++ {
+			decl, counter, ok := zeroCounter(pass, list[i])
+			if !ok {
+				continue
+			}
+			rng, ok := list[i+1].(*ast.RangeStmt)
+			if !ok || !countsRunes(pass, rng, counter) {
+				continue
+			}
+			report(pass, decl, rng, counter, importEditAdded)
+		}
+	})
+
+	return nil, nil
+}
+
+// zeroCounter matches count := 0 and returns the statement and the counter.
+func zeroCounter(pass *analysis.Pass, stmt ast.Stmt) (*ast.AssignStmt, types.Object, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	lit, ok := assign.Rhs[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT || lit.Value != "0" {
+		return nil, nil, false
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	if obj == nil {
+		return nil, nil, false
+	}
+	return assign, obj, true
+}
+
+// countsRunes reports whether rng ranges over a string, binds no variables,
+// and only increments counter by one.
+func countsRunes(pass *analysis.Pass, rng *ast.RangeStmt, counter types.Object) bool {
+	if !isBlank(rng.Key) || !isBlank(rng.Value) || len(rng.Body.List) != 1 {
+		return false
+	}
+	t := pass.TypesInfo.TypeOf(rng.X)
+	if t == nil {
+		return false
+	}
+	if basic, ok := t.Underlying().(*types.Basic); !ok || basic.Info()&types.IsString == 0 {
+		return false
+	}
+
+	var target ast.Expr
+	switch stmt := rng.Body.List[0].(type) {
+	case *ast.IncDecStmt:
+		if stmt.Tok != token.INC {
+			return false
+		}
+		target = stmt.X
+	case *ast.AssignStmt:
+		if stmt.Tok != token.ADD_ASSIGN || len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return false
+		}
+		lit, ok := stmt.Rhs[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT || lit.Value != "1" {
+			return false
+		}
+		target = stmt.Lhs[0]
+	default:
+		return false
+	}
+	ident, ok := target.(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == counter
+}
+
+// isBlank reports whether a range variable is absent or the blank
+// identifier.
+func isBlank(expr ast.Expr) bool {
+	if expr == nil {
+		return true
+	}
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == "_"
+}
+
+// report reports the counting loop rng, with a fix initializing the counter
+// to the rune count directly when no comments would be lost and utf8 can be
+// referred to.
+func report(pass *analysis.Pass, decl *ast.AssignStmt, rng *ast.RangeStmt, counter types.Object, importEditAdded map[string]bool) {
+	arg := types.ExprString(rng.X)
+	if !isPlainString(pass, rng.X) {
+		arg = "string(" + arg + ")"
+	}
+	runes := "utf8.RuneCountInString(" + arg + ")"
+	diag := analysis.Diagnostic{
+		Pos:     decl.Pos(),
+		Message: fmt.Sprintf("loop counting the runes of %s can be %s := %s", types.ExprString(rng.X), counter.Name(), runes),
+	}
+	file := importutil.FindFileForPos(pass, decl.Pos())
+	if file == nil || !utf8Available(pass, file, decl.Pos()) {
+		pass.Report(diag)
+		return
+	}
+	if edits := buildFix(pass, file, decl, rng); edits != nil {
+		fileName := pass.Fset.File(decl.Pos()).Name()
+		if !importEditAdded[fileName] {
+			if ie := importutil.AddImportEdit(pass, file, "unicode/utf8"); ie != nil {
+				edits = append(edits, *ie)
+				importEditAdded[fileName] = true
+			}
+		}
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{Message: fmt.Sprintf("replace the loop with %s := %s", counter.Name(), runes), TextEdits: edits},
+		}
+	}
+	pass.Report(diag)
+}
+
+// buildFix returns TextEdits replacing the zero in decl with the rune count
+// of the ranged string and deleting rng. It returns nil if a comment other
+// than one ending the declaration's line would be deleted.
+func buildFix(pass *analysis.Pass, file *ast.File, decl *ast.AssignStmt, rng *ast.RangeStmt) []analysis.TextEdit {
+	tokFile := pass.Fset.File(decl.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+
+	// Delete from the end of the declaration's line, keeping its comment.
+	del := decl.End()
+	if line := tokFile.Line(decl.End()); line != tokFile.Line(rng.Pos()) {
+		del = tokFile.LineStart(line+1) - 1
+	}
+	for _, cg := range file.Comments {
+		if cg.Pos() > del && cg.Pos() < rng.End() {
+			return nil
+		}
+	}
+
+	x := string(src[tokFile.Offset(rng.X.Pos()):tokFile.Offset(rng.X.End())])
+	if !isPlainString(pass, rng.X) {
+		x = "string(" + x + ")"
+	}
+	return []analysis.TextEdit{
+		{Pos: decl.Rhs[0].Pos(), End: decl.Rhs[0].End(), NewText: []byte("utf8.RuneCountInString(" + x + ")")},
+		{Pos: del, End: rng.End()},
+	}
+}
+
+// isPlainString reports whether expr can be passed as a string without a
+// conversion: its type is string, or it is an untyped constant.
+func isPlainString(pass *analysis.Pass, expr ast.Expr) bool {
+	t := pass.TypesInfo.TypeOf(expr)
+	if basic, ok := t.(*types.Basic); ok && basic.Info()&types.IsUntyped != 0 {
+		return true
+	}
+	return types.Identical(t, types.Typ[types.String])
+}
+
+// utf8Available reports whether utf8.RuneCountInString can be written at
+// pos in file: unicode/utf8 is either not imported, or imported under its
+// own name, and the name is not shadowed.
+func utf8Available(pass *analysis.Pass, file *ast.File, pos token.Pos) bool {
+	for _, imp := range file.Imports {
+		if imp.Path.Value == `"unicode/utf8"` && imp.Name != nil && imp.Name.Name != "utf8" {
+			return false
+		}
+	}
+	scope := pass.TypesInfo.Scopes[file].Innermost(pos)
+	if scope == nil {
+		return false
+	}
+	_, obj := scope.LookupParent("utf8", pos)
+	if obj == nil {
+		return true
+	}
+	pkgName, ok := obj.(*types.PkgName)
+	return ok && pkgName.Imported().Path() == "unicode/utf8"
+}
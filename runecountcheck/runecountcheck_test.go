@@ -0,0 +1,13 @@
+package runecountcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/runecountcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestRuneCountCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, runecountcheck.Analyzer, "runecounttest")
+}
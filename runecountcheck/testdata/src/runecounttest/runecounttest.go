@@ -0,0 +1,67 @@
+package runecounttest
+
+type label string
+
+func countString(s string) int {
+	count := 0 // want `loop counting the runes of s can be count := utf8\.RuneCountInString\(s\)`
+	for range s {
+		count++
+	}
+	return count
+}
+
+func countNamed(l label) int {
+	n := 0 // want `loop counting the runes of l can be n := utf8\.RuneCountInString\(string\(l\)\)`
+	for _ = range l {
+		n += 1
+	}
+	return n
+}
+
+func countExpr(parts []string) int {
+	total := 0 // want `loop counting the runes of parts\[0\] can be total := utf8\.RuneCountInString\(parts\[0\]\)`
+	for range parts[0] {
+		total++
+	}
+	return total
+}
+
+// A comment inside the loop would be lost, so there is no fix.
+func countCommented(s string) int {
+	count := 0 // want `loop counting the runes of s can be count := utf8\.RuneCountInString\(s\)`
+	for range s {
+		// one per rune
+		count++
+	}
+	return count
+}
+
+func notFlagged(s string, items []string, b []byte) int {
+	// Slice range — should NOT be flagged: len(items) counts it (countcheck).
+	n := 0
+	for range items {
+		n++
+	}
+
+	// Byte slice range — should NOT be flagged: it visits bytes.
+	m := 0
+	for range b {
+		m++
+	}
+
+	// Index bound — should NOT be flagged.
+	k := 0
+	for i := range s {
+		k = i
+		k++
+	}
+
+	// Conditional increment — should NOT be flagged.
+	c := 0
+	for _, r := range s {
+		if r == 'a' {
+			c++
+		}
+	}
+	return n + m + k + c
+}
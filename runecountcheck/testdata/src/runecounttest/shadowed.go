@@ -0,0 +1,12 @@
+package runecounttest
+
+// Should be flagged without a fix: utf8 is a local variable here.
+func countShadowed(s string) int {
+	utf8 := 0
+	_ = utf8
+	count := 0 // want `loop counting the runes of s can be count := utf8\.RuneCountInString\(s\)`
+	for range s {
+		count++
+	}
+	return count
+}
@@ -0,0 +1,163 @@
+// Package scannererrcheck defines an analyzer that detects bufio.Scanner
+// loops whose error is never checked.
+//
+// # Analyzer scannererrcheck
+//
+// scannererrcheck: detect bufio.Scanner loops not followed by a check of Err
+//
+// This analyzer flags a for sc.Scan() loop on a *bufio.Scanner when the
+// function never calls sc.Err():
+//
+//	sc := bufio.NewScanner(r)
+//	for sc.Scan() {
+//	    count++
+//	}
+//	return count
+//
+// Scan returns false both at the end of the input and on a read error, or
+// when a token is longer than the buffer, so without a check of Err the
+// loop stops early and the result is silently truncated. Check it after
+// the loop:
+//
+//	if err := sc.Err(); err != nil {
+//	    return 0, err
+//	}
+//
+// Only scanners held in a local variable of the function are checked, since
+// a scanner that comes from a parameter or a field may be checked by its
+// owner. A call of Err anywhere in the function, including a deferred one,
+// counts as a check, and any use of the scanner after the loop counts as
+// handing it on. Diagnostics are report-only.
+package scannererrcheck
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "scannererrcheck",
+	Doc:      "detect bufio.Scanner loops not followed by a check of Err",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.ForStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		loop := n.(*ast.ForStmt)
+		if loop.Init != nil || loop.Post != nil {
+			return true
+		}
+		sc, ok := scanReceiver(pass, loop.Cond)
+		if !ok {
+			return true
+		}
+		body := enclosingFuncBody(stack)
+		if body == nil || sc.Pos() < body.Pos() || sc.Pos() >= body.End() {
+			return true
+		}
+		if handled(pass, body, sc, loop) {
+			return true
+		}
+		pass.Reportf(loop.Pos(),
+			"%s.Scan loop without a check of %s.Err; a read error ends the loop like the end of input and silently truncates it",
+			sc.Name(), sc.Name())
+		return true
+	})
+
+	return nil, nil
+}
+
+// scanReceiver matches sc.Scan() on a *bufio.Scanner held in a variable and
+// returns the variable.
+func scanReceiver(pass *analysis.Pass, cond ast.Expr) (types.Object, bool) {
+	call, ok := ast.Unparen(cond).(*ast.CallExpr)
+	if !ok || !isScannerMethod(pass, call, "Scan") {
+		return nil, false
+	}
+	id, ok := ast.Unparen(call.Fun.(*ast.SelectorExpr).X).(*ast.Ident)
+	if !ok {
+		return nil, false
+	}
+	v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+	return v, ok
+}
+
+// handled reports whether body calls sc.Err() or uses sc after loop.
+func handled(pass *analysis.Pass, body *ast.BlockStmt, sc types.Object, loop *ast.ForStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if isScannerMethod(pass, n, "Err") && isObj(pass, n.Fun.(*ast.SelectorExpr).X, sc) {
+				found = true
+			}
+		case *ast.Ident:
+			if n.Pos() > loop.End() && pass.TypesInfo.Uses[n] == sc {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isScannerMethod reports whether call calls the method name of
+// *bufio.Scanner.
+func isScannerMethod(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	if _, ok := call.Fun.(*ast.SelectorExpr); !ok {
+		return false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Name() != name {
+		return false
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return false
+	}
+	ptr, ok := recv.Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "bufio" && obj.Name() == "Scanner"
+}
+
+// enclosingFuncBody returns the body of the innermost function containing
+// the last node in stack.
+func enclosingFuncBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(id) == obj
+}
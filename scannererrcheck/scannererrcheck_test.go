@@ -0,0 +1,13 @@
+package scannererrcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/scannererrcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestScannerErrCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, scannererrcheck.Analyzer, "scannererrtest")
+}
@@ -0,0 +1,79 @@
+package scannererrtest
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+func countLines(r io.Reader) int {
+	// Should be flagged: Err is never checked.
+	sc := bufio.NewScanner(r)
+	count := 0
+	for sc.Scan() { // want `sc\.Scan loop without a check of sc\.Err; a read error ends the loop like the end of input and silently truncates it`
+		count++
+	}
+	return count
+}
+
+func firstMatch(r io.Reader, prefix string) string {
+	// Should be flagged: returning from inside the loop does not check Err
+	// when the loop ends.
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() { // want `scanner\.Scan loop without a check of scanner\.Err`
+		if line := scanner.Text(); strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	return ""
+}
+
+func countLinesChecked(r io.Reader) (int, error) {
+	// Err checked after the loop — should NOT be flagged.
+	sc := bufio.NewScanner(r)
+	count := 0
+	for sc.Scan() {
+		count++
+	}
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func returnErr(r io.Reader) ([]string, error) {
+	// Err returned — should NOT be flagged.
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	return lines, sc.Err()
+}
+
+func handedOn(r io.Reader, finish func(*bufio.Scanner) error) error {
+	// The scanner is passed on after the loop — should NOT be flagged.
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+	}
+	return finish(sc)
+}
+
+func fromParam(sc *bufio.Scanner) int {
+	// The caller owns the scanner — should NOT be flagged.
+	n := 0
+	for sc.Scan() {
+		n++
+	}
+	return n
+}
+
+type reader struct {
+	sc *bufio.Scanner
+}
+
+func (r *reader) drain() {
+	// Scanner in a field — should NOT be flagged.
+	for r.sc.Scan() {
+	}
+}
@@ -18,6 +18,9 @@
 //
 //	slices.BinarySearch(s, target)
 //
+// Only sort.Search is checked; the sorting functions of package sort are left
+// to sortmigrate.
+//
 // Available since Go 1.21.
 package searchmigrate
 
@@ -0,0 +1,235 @@
+// Package shiftoverflowcheck defines an analyzer that detects constant
+// shifts by a loop variable that overflow the type they produce.
+//
+// # Analyzer shiftoverflowcheck
+//
+// shiftoverflowcheck: detect 1 << n overflowing its type within a bounded loop
+//
+// In a shift whose left operand is an untyped constant and whose count is
+// not constant, the constant takes its type from the context. A narrow
+// context type silently truncates the result once the count grows:
+//
+//	var masks [10]uint8
+//	for n := 0; n < 10; n++ {
+//	    masks[n] = 1 << n // uint8: 0 for n >= 8
+//	}
+//
+// This analyzer flags such shifts when the count is the variable of an
+// enclosing loop with constant bounds, so the largest count is known:
+//
+//	for n := lo; n < hi; n++    for n := lo; n <= hi; n++    for n := range hi
+//
+// The loop variable must not be modified in the body. Shifts whose count is
+// a constant are left to go vet's shift check, and constant overflow is
+// already a compile error. No auto-fix is provided.
+package shiftoverflowcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "shiftoverflowcheck",
+	Doc:      "detect 1 << n overflowing its type within a bounded loop",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BinaryExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+
+		shift := n.(*ast.BinaryExpr)
+		if shift.Op != token.SHL {
+			return true
+		}
+		base := pass.TypesInfo.Types[shift.X].Value
+		if base == nil || constant.Sign(base) <= 0 {
+			return true
+		}
+		count, ok := shift.Y.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		countObj := pass.TypesInfo.ObjectOf(count)
+		if countObj == nil {
+			return true
+		}
+
+		// Untyped shifts are constant, and constant overflow is already
+		// a compile error; Sizeof does not accept untyped types.
+		basic, ok := pass.TypesInfo.TypeOf(shift).Underlying().(*types.Basic)
+		if !ok || basic.Info()&types.IsInteger == 0 || basic.Info()&types.IsUntyped != 0 {
+			return true
+		}
+
+		maxCount, ok := loopMax(pass, stack, countObj)
+		if !ok || maxCount < 0 || maxCount > 1024 {
+			return true
+		}
+		maxVal := maxValue(pass, basic)
+		if constant.Compare(constant.Shift(base, token.SHL, uint(maxCount)), token.LEQ, maxVal) {
+			return true
+		}
+
+		// Find the first count that overflows.
+		first := int64(0)
+		for constant.Compare(constant.Shift(base, token.SHL, uint(first)), token.LEQ, maxVal) {
+			first++
+		}
+		pass.Reportf(shift.Pos(), "%s overflows %s for %s >= %d; the loop runs %s up to %d",
+			types.ExprString(shift), types.TypeString(pass.TypesInfo.TypeOf(shift), types.RelativeTo(pass.Pkg)),
+			count.Name, first, count.Name, maxCount)
+		return true
+	})
+
+	return nil, nil
+}
+
+// maxValue returns the largest value of the integer type t.
+func maxValue(pass *analysis.Pass, t *types.Basic) constant.Value {
+	bits := uint(8 * pass.TypesSizes.Sizeof(t))
+	if t.Info()&types.IsUnsigned == 0 {
+		bits--
+	}
+	one := constant.MakeInt64(1)
+	return constant.BinaryOp(constant.Shift(one, token.SHL, bits), token.SUB, one)
+}
+
+// loopMax returns the largest value the loop variable obj takes, if obj is
+// the variable of an enclosing loop in stack with constant bounds that does
+// not otherwise modify it.
+func loopMax(pass *analysis.Pass, stack []ast.Node, obj types.Object) (int64, bool) {
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch loop := stack[i].(type) {
+		case *ast.FuncLit, *ast.FuncDecl:
+			return 0, false
+
+		case *ast.RangeStmt:
+			key, ok := loop.Key.(*ast.Ident)
+			if !ok || pass.TypesInfo.Defs[key] != obj || loop.Tok != token.DEFINE {
+				continue
+			}
+			hi, ok := constInt(pass, loop.X)
+			if !ok || assigns(pass, loop.Body, obj) {
+				return 0, false
+			}
+			return hi - 1, true
+
+		case *ast.ForStmt:
+			init, ok := loop.Init.(*ast.AssignStmt)
+			if !ok || init.Tok != token.DEFINE || len(init.Lhs) != 1 || len(init.Rhs) != 1 {
+				continue
+			}
+			key, ok := init.Lhs[0].(*ast.Ident)
+			if !ok || pass.TypesInfo.Defs[key] != obj {
+				continue
+			}
+			if _, ok := constInt(pass, init.Rhs[0]); !ok {
+				return 0, false
+			}
+			inc, ok := loop.Post.(*ast.IncDecStmt)
+			if !ok || inc.Tok != token.INC || !isObj(pass, inc.X, obj) {
+				return 0, false
+			}
+			hi, ok := upperBound(pass, loop.Cond, obj)
+			if !ok || assigns(pass, loop.Body, obj) {
+				return 0, false
+			}
+			return hi, true
+		}
+	}
+	return 0, false
+}
+
+// upperBound returns the largest value of obj for which cond holds, if cond
+// is obj < c, obj <= c, c > obj, or c >= obj with a constant c.
+func upperBound(pass *analysis.Pass, cond ast.Expr, obj types.Object) (int64, bool) {
+	bin, ok := cond.(*ast.BinaryExpr)
+	if !ok {
+		return 0, false
+	}
+	op := bin.Op
+	bound := bin.Y
+	if isObj(pass, bin.Y, obj) {
+		bound = bin.X
+		switch op {
+		case token.GTR:
+			op = token.LSS
+		case token.GEQ:
+			op = token.LEQ
+		default:
+			return 0, false
+		}
+	} else if !isObj(pass, bin.X, obj) {
+		return 0, false
+	}
+
+	hi, ok := constInt(pass, bound)
+	if !ok {
+		return 0, false
+	}
+	switch op {
+	case token.LSS:
+		return hi - 1, true
+	case token.LEQ:
+		return hi, true
+	}
+	return 0, false
+}
+
+// constInt returns the value of expr if it is an integer constant.
+func constInt(pass *analysis.Pass, expr ast.Expr) (int64, bool) {
+	v := pass.TypesInfo.Types[expr].Value
+	if v == nil {
+		return 0, false
+	}
+	return constant.Int64Val(constant.ToInt(v))
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(ident) == obj
+}
+
+// assigns reports whether body assigns to obj, increments or decrements it,
+// or takes its address.
+func assigns(pass *analysis.Pass, body *ast.BlockStmt, obj types.Object) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isObj(pass, lhs, obj) {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if isObj(pass, n.X, obj) {
+				found = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isObj(pass, n.X, obj) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
@@ -0,0 +1,13 @@
+package shiftoverflowcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/shiftoverflowcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestShiftOverflowCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, shiftoverflowcheck.Analyzer, "shiftoverflowtest")
+}
@@ -0,0 +1,61 @@
+package shiftoverflowtest
+
+const numBits = 10
+
+func example() {
+	// Should be flagged: uint8 holds 1 << 7 at most.
+	var masks [numBits]uint8
+	for n := 0; n < numBits; n++ {
+		masks[n] = 1 << n // want `1 << n overflows uint8 for n >= 8; the loop runs n up to 9`
+	}
+
+	// Should be flagged: signed type, <= bound.
+	var x int8
+	for n := 0; n <= 7; n++ {
+		x = 1 << n // want `1 << n overflows int8 for n >= 7; the loop runs n up to 7`
+	}
+
+	// Should be flagged: range over an integer, larger base.
+	var y uint16
+	for n := range 16 {
+		y += 3 << n // want `3 << n overflows uint16 for n >= 15; the loop runs n up to 15`
+	}
+
+	_, _, _ = masks, x, y
+}
+
+func noMatch(bit uint) {
+	// Fits: uint8 holds 1 << 7 — should NOT be flagged.
+	var masks [8]uint8
+	for n := 0; n < 8; n++ {
+		masks[n] = 1 << n
+	}
+
+	// Wide enough type — should NOT be flagged.
+	var big uint64
+	for n := 0; n < 64; n++ {
+		big |= 1 << n
+	}
+
+	// Count not bounded by a loop — should NOT be flagged.
+	var m uint8 = 1 << bit
+
+	// Loop variable modified in the body — should NOT be flagged.
+	var z uint8
+	for n := 0; n < 10; n++ {
+		z = 1 << n
+		n++
+	}
+
+	// Non-constant bound — should NOT be flagged.
+	var w uint8
+	for n := 0; n < int(bit); n++ {
+		w = 1 << n
+	}
+
+	_, _, _, _, _ = masks, big, m, z, w
+}
+
+// Untyped constant shift — should NOT be flagged (nor crash the analyzer).
+const shiftCount = 3
+const untypedShift = 1 << shiftCount
@@ -0,0 +1,170 @@
+// Package singleselectcheck defines an analyzer that detects select
+// statements with a single communication case and no default.
+//
+// # Analyzer singleselectcheck
+//
+// singleselectcheck: detect single-case select that can be a plain channel operation
+//
+// This analyzer flags select statements with exactly one case and no
+// default clause:
+//
+//	select {
+//	case v := <-ch:
+//	    use(v)
+//	}
+//
+// Such a select blocks exactly like the bare channel operation, so it can
+// be replaced with:
+//
+//	v := <-ch
+//	use(v)
+//
+// A single case with a default clause is a non-blocking operation and is
+// not flagged. The auto-fix is omitted when the case body contains a break
+// that targets the select, when the select is labeled, or when moving the
+// case's declarations into the enclosing block could clash with or shadow
+// another name.
+package singleselectcheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "singleselectcheck",
+	Doc:      "detect single-case select that can be a plain channel operation",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+const msg = "select with a single case and no default can be replaced with the channel operation"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.SelectStmt)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		sel := n.(*ast.SelectStmt)
+
+		if len(sel.Body.List) != 1 {
+			return true
+		}
+		clause := sel.Body.List[0].(*ast.CommClause)
+		if clause.Comm == nil {
+			return true // select { default: } — not a communication
+		}
+
+		diag := analysis.Diagnostic{Pos: sel.Pos(), Message: msg}
+
+		_, labeled := stack[len(stack)-2].(*ast.LabeledStmt)
+		if !labeled && !breaksSelect(clause.Body) && !declConflicts(pass, clause) {
+			if edit := buildFix(pass, sel, clause); edit != nil {
+				diag.SuggestedFixes = []analysis.SuggestedFix{
+					{Message: msg, TextEdits: []analysis.TextEdit{*edit}},
+				}
+			}
+		}
+
+		pass.Report(diag)
+		return true
+	})
+
+	return nil, nil
+}
+
+// buildFix returns a TextEdit replacing sel with the communication statement
+// followed by the case body, dedented by one level.
+func buildFix(pass *analysis.Pass, sel *ast.SelectStmt, clause *ast.CommClause) *analysis.TextEdit {
+	tokFile := pass.Fset.File(sel.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	text := func(from, to token.Pos) []byte {
+		return src[tokFile.Offset(from):tokFile.Offset(to)]
+	}
+
+	// Indentation of the line the select starts on.
+	lineStart := tokFile.LineStart(tokFile.Line(sel.Pos()))
+	indent := text(lineStart, sel.Pos())
+
+	var buf bytes.Buffer
+	buf.Write(text(clause.Comm.Pos(), clause.Comm.End()))
+
+	// Copy everything after the colon, comments included, re-indenting each
+	// line from the case body's indentation to the select's.
+	body := bytes.Trim(text(clause.Colon+1, clause.End()), "\n")
+	var bodyIndent []byte
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		buf.WriteByte('\n')
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue // keep blank lines, without trailing whitespace
+		}
+		if bodyIndent == nil {
+			bodyIndent = line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+		}
+		buf.Write(indent)
+		if bytes.HasPrefix(line, bodyIndent) {
+			buf.Write(line[len(bodyIndent):])
+		} else {
+			buf.Write(bytes.TrimLeft(line, " \t"))
+		}
+	}
+
+	return &analysis.TextEdit{
+		Pos:     sel.Pos(),
+		End:     sel.End(),
+		NewText: buf.Bytes(),
+	}
+}
+
+// breaksSelect reports whether stmts contain an unlabeled break that would
+// exit the select. Breaks inside nested loops, switches, selects, and
+// function literals target those instead.
+func breaksSelect(stmts []ast.Stmt) bool {
+	found := false
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt,
+				*ast.SelectStmt, *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				if n.Tok == token.BREAK && n.Label == nil {
+					found = true
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// declConflicts reports whether any name declared in the case (by the
+// communication or at the top level of its body) is already visible from
+// the enclosing block. Moving the declaration out of the case would then
+// redeclare it or change what later references resolve to.
+func declConflicts(pass *analysis.Pass, clause *ast.CommClause) bool {
+	scope := pass.TypesInfo.Scopes[clause]
+	if scope == nil {
+		return true
+	}
+	for _, name := range scope.Names() {
+		if _, obj := scope.Parent().LookupParent(name, token.NoPos); obj != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package singleselectcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/singleselectcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSingleSelectCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, singleselectcheck.Analyzer, "singleselecttest")
+}
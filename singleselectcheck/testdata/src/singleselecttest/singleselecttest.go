@@ -0,0 +1,76 @@
+package singleselecttest
+
+func use(int) {}
+
+func example(ch chan int, done chan struct{}) {
+	// Should be flagged: single receive.
+	select { // want "select with a single case and no default can be replaced with the channel operation"
+	case <-done:
+	}
+
+	// Should be flagged: receive with assignment and a body.
+	select { // want "select with a single case and no default can be replaced with the channel operation"
+	case got := <-ch:
+		// Keep comments in the body.
+		use(got)
+		use(got + 1)
+	}
+
+	// Should be flagged: send.
+	select { // want "select with a single case and no default can be replaced with the channel operation"
+	case ch <- 1:
+		use(1)
+	}
+}
+
+func reportOnly(ch chan int) {
+	v := 0
+
+	// Should be flagged without a fix: v is already declared in this block.
+	select { // want "select with a single case and no default can be replaced with the channel operation"
+	case v := <-ch:
+		use(v)
+	}
+	use(v)
+
+	// Should be flagged without a fix: break exits the select.
+	for {
+		select { // want "select with a single case and no default can be replaced with the channel operation"
+		case x := <-ch:
+			if x == 0 {
+				break
+			}
+			use(x)
+		}
+	}
+}
+
+func noMatch(ch chan int) {
+	// Single case with default — non-blocking, should NOT be flagged.
+	select {
+	case v := <-ch:
+		use(v)
+	default:
+	}
+
+	// Two cases — should NOT be flagged.
+	select {
+	case <-ch:
+	case ch <- 1:
+	}
+
+	// Empty select — blocks forever, should NOT be flagged.
+	if false {
+		select {}
+	}
+}
+
+func nestedBody(ch chan int) {
+	// Should be flagged: nested blocks keep their relative indentation.
+	select { // want "select with a single case and no default can be replaced with the channel operation"
+	case n := <-ch:
+		if n > 0 {
+			use(n)
+		}
+	}
+}
@@ -18,8 +18,33 @@
 //   - sort.Float64sAreSorted(s)    -> slices.IsSorted(s)
 //
 // For sort.Slice, sort.SliceStable, and sort.SliceIsSorted, auto-fix is provided
-// when the callback is a simple single-return comparison (e.g. s[i] < s[j] or
-// s[i].Field < s[j].Field). Complex callbacks remain report-only.
+// when the callback is a simple single-return comparison (e.g. s[i] < s[j],
+// s[i].Field < s[j].Field, or len(s[i]) < len(s[j])). Complex callbacks remain
+// report-only. A callback comparing whole integer or string elements in
+// ascending order is dropped entirely, since it is the natural order:
+// sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) becomes
+// slices.Sort(s), as does one returning cmp.Compare(s[i], s[j]) < 0.
+// Callbacks that already return strings.Compare(x, y) < 0,
+// bytes.Compare(x, y) < 0, or cmp.Compare(x, y) < 0 keep their Compare call as
+// the comparator result.
+// Callbacks returning a method call on one element with the other, such as
+// s[i].Less(s[j]) or s[i].Before(s[j]), become a comparator calling the method
+// both ways, provided the method takes exactly the element type.
+//
+// sort.Sort and sort.Stable calls are reported, without a fix, when their
+// argument is a slice type of this package whose Less method compares a
+// single key of the indexed elements: the type and its Len, Less, and Swap
+// methods can give way to slices.SortFunc or slices.SortStableFunc.
+//
+// sort.Search is left to searchmigrate.
+//
+// Calls through a dot import of sort are reported without a fix, as are
+// callbacks over elements whose type is a struct, function, or non-empty
+// interface literal, which the comparator would have to spell out.
+//
+// With -warn-float-compare, callback fixes that compare floating-point values
+// carry a note in the diagnostic: cmp.Compare orders NaN before all other
+// values, whereas a < comparison leaves the position of NaN undefined.
 //
 // Available since Go 1.21.
 package sortmigrate
@@ -27,8 +52,10 @@ package sortmigrate
 import (
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
+	"slices"
 	"strings"
 
 	"github.com/albertocavalcante/go-analyzers/internal/importutil"
@@ -44,6 +71,19 @@ var Analyzer = &analysis.Analyzer{
 	Run:      run,
 }
 
+// warnFloatCompare enables a NaN-ordering caveat on callback fixes that
+// compare floating-point values.
+var warnFloatCompare bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&warnFloatCompare, "warn-float-compare", false,
+		"note in the diagnostic when a sort.Slice callback fix compares floats, since cmp.Compare orders NaN differently than <")
+}
+
+// floatCaveat is appended to the diagnostic message when warnFloatCompare is
+// set and the rewritten callback compares floating-point values.
+const floatCaveat = " (note: cmp.Compare orders NaN before all other values, while < leaves NaN order undefined)"
+
 // migrations maps sort package function names to their slices package replacements.
 var migrations = map[string]string{
 	"Strings":           "slices.Sort",
@@ -66,6 +106,13 @@ var callbackMigrations = map[string]bool{
 	"SliceIsSorted": true,
 }
 
+// interfaceMigrations maps the sort functions taking a sort.Interface to the
+// slices function that replaces them when the Less method compares one key.
+var interfaceMigrations = map[string]string{
+	"Sort":   "slices.SortFunc",
+	"Stable": "slices.SortStableFunc",
+}
+
 // pendingDiag holds a diagnostic and its associated edits before import edits
 // are attached. This allows collecting all needed imports per file first,
 // then creating a single combined import TextEdit to avoid conflicts.
@@ -74,6 +121,7 @@ type pendingDiag struct {
 	edits   []analysis.TextEdit
 	imports []string // packages needed (e.g., "slices", "cmp")
 	file    string   // file name from Fset
+	fixMsg  string   // SuggestedFix message; the diagnostic message if empty
 }
 
 func run(pass *analysis.Pass) (any, error) {
@@ -88,6 +136,20 @@ func run(pass *analysis.Pass) (any, error) {
 	insp.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
 
+		// With a dot import (import . "sort"), calls are bare identifiers.
+		// The fix would introduce slices.X names into a file that may already
+		// use them unqualified, so these are report-only.
+		if ident, ok := call.Fun.(*ast.Ident); ok {
+			fn, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+			if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sort" || fn.Pkg() == pass.Pkg {
+				return
+			}
+			if replacement, ok := migrations[ident.Name]; ok {
+				pass.Reportf(call.Pos(), "sort.%s can be replaced with %s", ident.Name, replacement)
+			}
+			return
+		}
+
 		sel, ok := call.Fun.(*ast.SelectorExpr)
 		if !ok {
 			return
@@ -95,7 +157,7 @@ func run(pass *analysis.Pass) (any, error) {
 
 		funcName := sel.Sel.Name
 		replacement, ok := migrations[funcName]
-		if !ok {
+		if !ok && interfaceMigrations[funcName] == "" {
 			return
 		}
 
@@ -119,22 +181,76 @@ func run(pass *analysis.Pass) (any, error) {
 			return
 		}
 
+		if replacement, ok := interfaceMigrations[funcName]; ok {
+			reportInterfaceSort(pass, call, funcName, replacement)
+			return
+		}
+
 		msg := fmt.Sprintf("sort.%s can be replaced with %s", funcName, replacement)
 		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
 		fileName := pass.Fset.File(call.Pos()).Name()
 
 		if callbackMigrations[funcName] {
+			// A callback comparing whole integer or string elements in
+			// ascending order is the natural order, so the callback goes away.
+			if whole, edits := tryBuildWholeSortFix(pass, call, sel, funcName); edits != nil {
+				diag.Message = fmt.Sprintf("sort.%s can be replaced with %s", funcName, whole)
+				pending = append(pending, pendingDiag{
+					diag:    diag,
+					edits:   edits,
+					imports: []string{"slices"},
+					file:    fileName,
+					fixMsg:  fmt.Sprintf("use %s(%s)", whole, types.ExprString(call.Args[0])),
+				})
+				return
+			}
+
+			// The fix message names the slice argument, so the code action
+			// label matches the call it produces.
+			fixMsg := fmt.Sprintf("use %s(%s, ...)", replacement, types.ExprString(call.Args[0]))
+
+			// A callback already returning strings.Compare, bytes.Compare, or
+			// cmp.Compare keeps its comparison and needs no cmp import.
+			if edits := tryBuildCompareFix(pass, call, sel, replacement); edits != nil {
+				pending = append(pending, pendingDiag{
+					diag:    diag,
+					edits:   edits,
+					imports: []string{"slices"},
+					file:    fileName,
+					fixMsg:  fixMsg,
+				})
+				return
+			}
+
+			// A callback calling a method that compares an element with
+			// another, as in s[i].Less(s[j]), is called both ways.
+			if edits := tryBuildLessMethodFix(pass, call, sel, replacement); edits != nil {
+				pending = append(pending, pendingDiag{
+					diag:    diag,
+					edits:   edits,
+					imports: []string{"slices"},
+					file:    fileName,
+					fixMsg:  fixMsg,
+				})
+				return
+			}
+
 			// Try to build auto-fix for the callback.
-			edits := tryBuildSliceFix(pass, call, sel, replacement)
+			edits, related := tryBuildSliceFix(pass, call, sel, replacement)
 			if edits != nil {
+				if warnFloatCompare && comparesFloats(pass, call) {
+					diag.Message += floatCaveat
+				}
 				pending = append(pending, pendingDiag{
 					diag:    diag,
 					edits:   edits,
 					imports: []string{"cmp", "slices"},
 					file:    fileName,
+					fixMsg:  fixMsg,
 				})
 			} else {
 				// Complex callback — report-only, no auto-fix.
+				diag.Related = []analysis.RelatedInformation{*related}
 				pass.Report(diag)
 			}
 		} else {
@@ -163,7 +279,11 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	}
 
-	// Build a single combined import TextEdit per file.
+	// Build a single combined import TextEdit per file. Other analyzers
+	// adding "slices" alone, such as makecopy, produce the same edit when
+	// cmp is not needed, and drivers apply identical edits once. When cmp
+	// is added too, the edits differ at the same position, so one of the
+	// fixes is skipped as conflicting and applies on the next run.
 	fileImportEdits := map[string]*analysis.TextEdit{}
 	for fileName, pkgSet := range fileImports {
 		file := importutil.FindFileForPos(pass, filePosMap[fileName])
@@ -178,7 +298,7 @@ func run(pass *analysis.Pass) (any, error) {
 		if pkgSet["slices"] {
 			pkgs = append(pkgs, "slices")
 		}
-		if edit := importutil.AddMultipleImportsEdit(file, pkgs); edit != nil {
+		if edit := importutil.AddMultipleImportsEdit(pass, file, pkgs); edit != nil {
 			fileImportEdits[fileName] = edit
 		}
 	}
@@ -194,8 +314,12 @@ func run(pass *analysis.Pass) (any, error) {
 				importAttached[pd.file] = true
 			}
 		}
+		fixMsg := pd.fixMsg
+		if fixMsg == "" {
+			fixMsg = pd.diag.Message
+		}
 		pd.diag.SuggestedFixes = []analysis.SuggestedFix{
-			{Message: pd.diag.Message, TextEdits: allEdits},
+			{Message: fixMsg, TextEdits: allEdits},
 		}
 		pass.Report(pd.diag)
 	}
@@ -204,8 +328,9 @@ func run(pass *analysis.Pass) (any, error) {
 }
 
 // tryBuildSliceFix attempts to build TextEdits for sort.Slice/SliceStable/SliceIsSorted
-// calls when the callback is a simple single-return comparison. Returns nil if the
-// callback is too complex for auto-fix.
+// calls when the callback is a simple single-return comparison. If the callback
+// is too complex for auto-fix, it returns nil edits and related information
+// pointing at the part of the call that prevents the fix and saying why.
 //
 // Supported patterns (single return with binary </>/<=/>=):
 //   - sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
@@ -213,44 +338,319 @@ func run(pass *analysis.Pass) (any, error) {
 //   - sort.Slice(s, func(i, j int) bool { return s[i].Method() < s[j].Method() })
 //   - sort.Slice(s, func(i, j int) bool { return s[i] > s[j] })  (reversed)
 //   - sort.Slice(s, func(i, j int) bool { return s[j] < s[i] })  (swapped params)
-func tryBuildSliceFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, replacement string) []analysis.TextEdit {
+//   - sort.Slice(s, func(i, j int) bool { return len(s[i]) < len(s[j]) })
+//   - sort.Slice(s, func(i, j int) bool { return -s[i] < -s[j] })  (negated, descending)
+//   - sort.Slice(s, func(i, j int) bool { return m[s[i]] < m[s[j]] })  (map lookup)
+func tryBuildSliceFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, replacement string) ([]analysis.TextEdit, *analysis.RelatedInformation) {
 	if len(call.Args) != 2 {
-		return nil
+		return nil, unfixable(call, "sort call does not have two arguments")
 	}
 
 	sliceArg := call.Args[0]
 	funcLit, ok := call.Args[1].(*ast.FuncLit)
 	if !ok {
-		return nil
+		return nil, unfixable(call.Args[1], "callback is not a function literal")
 	}
 
-	// Must be func(i, j int) bool — extract param names.
-	params := funcLit.Type.Params
-	if params == nil {
-		return nil
+	iParam, jParam, ok := callbackParams(funcLit.Type)
+	if !ok {
+		return nil, unfixable(funcLit.Type, "callback parameters are not (i, j int)")
+	}
+
+	// Body must be a single return statement, possibly wrapped (see bodyReturn).
+	retStmt := bodyReturn(funcLit.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return nil, unfixable(funcLit.Body, "callback body is not a single return statement")
+	}
+
+	// Return expression must be a binary comparison.
+	binExpr, ok := retStmt.Results[0].(*ast.BinaryExpr)
+	if !ok {
+		return nil, unfixable(retStmt.Results[0], "callback does not return a single <, <=, >, or >= comparison")
+	}
+	var opReversed bool
+	switch binExpr.Op {
+	case token.LSS, token.LEQ:
+		opReversed = false
+	case token.GTR, token.GEQ:
+		opReversed = true
+	default:
+		return nil, unfixable(binExpr, "callback does not return a single <, <=, >, or >= comparison")
+	}
+
+	// Slice arg must be a variable, or a field of one such as s.items.
+	sliceName, sliceRoot, ok := sliceOperand(pass, sliceArg)
+	if !ok {
+		return nil, unfixable(sliceArg, "slice argument is not a variable or a field of one")
+	}
+
+	// Negating both sides of a numeric comparison flips the direction:
+	// -s[i] < -s[j] sorts descending. Unwrap the minus on both sides and
+	// account for it when computing the direction below.
+	lhs, rhs := binExpr.X, binExpr.Y
+	lhsNeg, lhsIsNeg := negatedOperand(pass, lhs)
+	rhsNeg, rhsIsNeg := negatedOperand(pass, rhs)
+	if lhsIsNeg != rhsIsNeg {
+		return nil, unfixable(binExpr, "comparison operands have different shapes")
+	}
+	negated := lhsIsNeg
+	if negated {
+		lhs, rhs = lhsNeg, rhsNeg
+	}
+
+	// Comparing by length: len(s[i]) < len(s[j]). Unwrap the builtin len on
+	// both sides and re-wrap the generated operands below.
+	lhsLen, lhsIsLen := builtinLenArg(pass, lhs)
+	rhsLen, rhsIsLen := builtinLenArg(pass, rhs)
+	if lhsIsLen != rhsIsLen {
+		return nil, unfixable(binExpr, "comparison operands have different shapes")
+	}
+	byLen := lhsIsLen
+	if byLen {
+		lhs, rhs = lhsLen, rhsLen
+	}
+
+	// Sorting by a map lookup: m[s[i]] < m[s[j]]. Unwrap the lookup on both
+	// sides and re-wrap the generated operands below. The map must be the
+	// same expression on both sides.
+	lhsMap, lhsKey, lhsIsMap := mapLookup(pass, lhs)
+	rhsMap, rhsKey, rhsIsMap := mapLookup(pass, rhs)
+	if lhsIsMap != rhsIsMap {
+		return nil, unfixable(binExpr, "comparison operands have different shapes")
+	}
+	var mapStr string
+	if lhsIsMap {
+		if !sameExpr(pass, lhsMap, rhsMap) || mentionsAny(lhsMap, sliceRoot, iParam, jParam, "a", "b") {
+			return nil, unfixable(binExpr, "map lookups use different maps or names the new comparator would shadow")
+		}
+		if !byLen && !isOrdered(pass.TypesInfo.TypeOf(binExpr.X)) {
+			return nil, unfixable(binExpr, "map values are not ordered")
+		}
+		mapStr = types.ExprString(lhsMap)
+		lhs, rhs = lhsKey, rhsKey
+	}
+
+	// Extract chains from both sides of the comparison.
+	lhsChain, lhsParam, lhsOk := extractChain(lhs, sliceName)
+	rhsChain, rhsParam, rhsOk := extractChain(rhs, sliceName)
+	if !lhsOk || !rhsOk {
+		return nil, unfixable(binExpr, "comparison operands are not elements of the slice or their fields")
+	}
+
+	// Determine param ordering: normal (i on LHS, j on RHS) or swapped.
+	// Swapped params reverse the sort direction, same as using > instead of <.
+	//   s[i] < s[j]  → ascending      s[j] < s[i]  → descending
+	//   s[i] > s[j]  → descending     s[j] > s[i]  → ascending
+	var paramsSwapped bool
+	if lhsParam == iParam && rhsParam == jParam {
+		paramsSwapped = false
+	} else if lhsParam == jParam && rhsParam == iParam {
+		paramsSwapped = true
+	} else {
+		return nil, unfixable(binExpr, "comparison does not use each callback parameter on one side")
+	}
+
+	// Chains must be identical (comparing the same field/method on both elements).
+	if lhsChain != rhsChain {
+		return nil, unfixable(binExpr, "comparison operands access different fields")
+	}
+	if !chainArgsMatch(pass, lhs, rhs, iParam, jParam, "a", "b") {
+		return nil, unfixable(binExpr, "method arguments differ between the operands or use the callback parameters")
+	}
+
+	// Descending when an odd number of operator, params, and negation are
+	// reversed (XOR).
+	descending := opReversed != paramsSwapped != negated
+
+	elemTypeStr, ok := elemTypeString(pass, call.Pos(), sliceArg)
+	if !ok {
+		return nil, unfixable(sliceArg, "element type cannot be written in this file")
+	}
+
+	// Build cmp.Compare arguments.
+	chain := lhsChain
+	aExpr := "a" + chain
+	bExpr := "b" + chain
+	if mapStr != "" {
+		aExpr = mapStr + "[" + aExpr + "]"
+		bExpr = mapStr + "[" + bExpr + "]"
 	}
-	var iParam, jParam string
+	if byLen {
+		aExpr = "len(" + aExpr + ")"
+		bExpr = "len(" + bExpr + ")"
+	}
+	if descending {
+		aExpr, bExpr = bExpr, aExpr
+	}
+
+	newFunc := fmt.Sprintf("func(a, b %s) int { return cmp.Compare(%s, %s) }", elemTypeStr, aExpr, bExpr)
+
+	return []analysis.TextEdit{
+		{
+			Pos:     sel.Pos(),
+			End:     sel.Sel.End(),
+			NewText: []byte(replacement),
+		},
+		{
+			Pos:     funcLit.Pos(),
+			End:     funcLit.End(),
+			NewText: []byte(newFunc),
+		},
+	}, nil
+}
+
+// wholeSortMigrations maps callback sort functions to the slices function
+// that replaces them when the callback compares whole elements in ascending
+// order.
+var wholeSortMigrations = map[string]string{
+	"Slice":         "slices.Sort",
+	"SliceStable":   "slices.Sort",
+	"SliceIsSorted": "slices.IsSorted",
+}
+
+// tryBuildWholeSortFix builds TextEdits that drop the callback of a
+// sort.Slice/SliceStable/SliceIsSorted call comparing whole elements in
+// ascending order:
+//
+//	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })  →  slices.Sort(s)
+//
+// It returns the replacement function and the edits, or nil edits when the
+// callback is anything else. Only integer and string elements qualify: equal
+// values of those types are indistinguishable, so dropping stability is safe,
+// while floats keep the SortFunc form (and its -warn-float-compare note)
+// because -0 and +0 compare equal but differ.
+func tryBuildWholeSortFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, funcName string) (string, []analysis.TextEdit) {
+	whole, ok := wholeSortMigrations[funcName]
+	if !ok || len(call.Args) != 2 {
+		return "", nil
+	}
+	sliceArg := call.Args[0]
+	sliceName, _, ok := sliceOperand(pass, sliceArg)
+	if !ok {
+		return "", nil
+	}
+	// sort.Slice accepts an array, but slices.Sort does not.
+	if t := pass.TypesInfo.TypeOf(sliceArg); t == nil {
+		return "", nil
+	} else if _, ok := t.Underlying().(*types.Slice); !ok {
+		return "", nil
+	}
+	funcLit, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return "", nil
+	}
+	iParam, jParam, ok := callbackParams(funcLit.Type)
+	if !ok {
+		return "", nil
+	}
+	retStmt := bodyReturn(funcLit.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return "", nil
+	}
+	binExpr, ok := retStmt.Results[0].(*ast.BinaryExpr)
+	if !ok {
+		return "", nil
+	}
+	// cmp.Compare(s[i], s[j]) < 0 orders integers and strings as s[i] < s[j].
+	lhs, rhs := binExpr.X, binExpr.Y
+	if x, y, ok := cmpCompareArgs(pass, binExpr); ok {
+		lhs, rhs = x, y
+	}
+
+	lhsChain, lhsParam, lhsOk := extractChain(lhs, sliceName)
+	rhsChain, rhsParam, rhsOk := extractChain(rhs, sliceName)
+	if !lhsOk || !rhsOk || lhsChain != "" || rhsChain != "" {
+		return "", nil
+	}
+
+	// Ascending is s[i] < s[j] or s[j] > s[i].
 	switch {
-	case len(params.List) == 1 && len(params.List[0].Names) == 2:
-		iParam = params.List[0].Names[0].Name
-		jParam = params.List[0].Names[1].Name
-	case len(params.List) == 2 && len(params.List[0].Names) == 1 && len(params.List[1].Names) == 1:
-		iParam = params.List[0].Names[0].Name
-		jParam = params.List[1].Names[0].Name
+	case (binExpr.Op == token.LSS || binExpr.Op == token.LEQ) && lhsParam == iParam && rhsParam == jParam:
+	case (binExpr.Op == token.GTR || binExpr.Op == token.GEQ) && lhsParam == jParam && rhsParam == iParam:
 	default:
+		return "", nil
+	}
+
+	t := pass.TypesInfo.TypeOf(lhs)
+	if t == nil {
+		return "", nil
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsInteger|types.IsString) == 0 {
+		return "", nil
+	}
+
+	return whole, []analysis.TextEdit{
+		{
+			Pos:     sel.Pos(),
+			End:     sel.Sel.End(),
+			NewText: []byte(whole),
+		},
+		{
+			Pos: sliceArg.End(),
+			End: call.Rparen,
+		},
+	}
+}
+
+// cmpCompareArgs returns the arguments of expr if it is cmp.Compare(x, y)
+// compared against zero.
+func cmpCompareArgs(pass *analysis.Pass, expr *ast.BinaryExpr) (x, y ast.Expr, ok bool) {
+	if tv := pass.TypesInfo.Types[expr.Y]; tv.Value == nil || constant.Sign(tv.Value) != 0 {
+		return nil, nil, false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 2 {
+		return nil, nil, false
+	}
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || fun.Sel.Name != "Compare" {
+		return nil, nil, false
+	}
+	pkgIdent, ok := fun.X.(*ast.Ident)
+	if !ok {
+		return nil, nil, false
+	}
+	if pkgName, ok := pass.TypesInfo.ObjectOf(pkgIdent).(*types.PkgName); !ok || pkgName.Imported().Path() != "cmp" {
+		return nil, nil, false
+	}
+	return call.Args[0], call.Args[1], true
+}
+
+// tryBuildCompareFix builds TextEdits for sort.Slice/SliceStable/SliceIsSorted
+// calls whose callback already delegates to strings.Compare, bytes.Compare,
+// or cmp.Compare:
+//
+//	sort.Slice(s, func(i, j int) bool { return strings.Compare(s[i].Name, s[j].Name) < 0 })
+//
+// The Compare call becomes the body of the int-returning comparator, with the
+// arguments swapped for > 0 or swapped params. It returns nil for any other
+// callback.
+func tryBuildCompareFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, replacement string) []analysis.TextEdit {
+	if len(call.Args) != 2 {
 		return nil
 	}
 
-	// Body must be a single return statement.
-	if funcLit.Body == nil || len(funcLit.Body.List) != 1 {
+	sliceArg := call.Args[0]
+	sliceName, _, ok := sliceOperand(pass, sliceArg)
+	if !ok {
+		return nil
+	}
+	funcLit, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
 		return nil
 	}
-	retStmt, ok := funcLit.Body.List[0].(*ast.ReturnStmt)
-	if !ok || len(retStmt.Results) != 1 {
+	iParam, jParam, ok := callbackParams(funcLit.Type)
+	if !ok {
 		return nil
 	}
 
-	// Return expression must be a binary comparison.
+	retStmt := bodyReturn(funcLit.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return nil
+	}
+
+	// The result must be Compare(x, y) compared against zero.
 	binExpr, ok := retStmt.Results[0].(*ast.BinaryExpr)
 	if !ok {
 		return nil
@@ -264,24 +664,37 @@ func tryBuildSliceFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.Selector
 	default:
 		return nil
 	}
-
-	// Slice arg must be a simple identifier.
-	sliceIdent, ok := sliceArg.(*ast.Ident)
+	if tv := pass.TypesInfo.Types[binExpr.Y]; tv.Value == nil || constant.Sign(tv.Value) != 0 {
+		return nil
+	}
+	cmpCall, ok := binExpr.X.(*ast.CallExpr)
+	if !ok || len(cmpCall.Args) != 2 {
+		return nil
+	}
+	cmpSel, ok := cmpCall.Fun.(*ast.SelectorExpr)
+	if !ok || cmpSel.Sel.Name != "Compare" {
+		return nil
+	}
+	pkgIdent, ok := cmpSel.X.(*ast.Ident)
+	if !ok || pkgIdent.Name == "a" || pkgIdent.Name == "b" {
+		return nil // the comparator's parameters would shadow the package
+	}
+	pkgName, ok := pass.TypesInfo.ObjectOf(pkgIdent).(*types.PkgName)
 	if !ok {
 		return nil
 	}
-
-	// Extract chains from both sides of the comparison.
-	lhsChain, lhsParam, lhsOk := extractChain(binExpr.X, sliceIdent.Name)
-	rhsChain, rhsParam, rhsOk := extractChain(binExpr.Y, sliceIdent.Name)
-	if !lhsOk || !rhsOk {
+	if path := pkgName.Imported().Path(); path != "strings" && path != "bytes" && path != "cmp" {
 		return nil
 	}
 
-	// Determine param ordering: normal (i on LHS, j on RHS) or swapped.
-	// Swapped params reverse the sort direction, same as using > instead of <.
-	//   s[i] < s[j]  → ascending      s[j] < s[i]  → descending
-	//   s[i] > s[j]  → descending     s[j] > s[i]  → ascending
+	lhsChain, lhsParam, lhsOk := extractChain(cmpCall.Args[0], sliceName)
+	rhsChain, rhsParam, rhsOk := extractChain(cmpCall.Args[1], sliceName)
+	if !lhsOk || !rhsOk || lhsChain != rhsChain {
+		return nil
+	}
+	if !chainArgsMatch(pass, cmpCall.Args[0], cmpCall.Args[1], iParam, jParam, "a", "b") {
+		return nil
+	}
 	var paramsSwapped bool
 	if lhsParam == iParam && rhsParam == jParam {
 		paramsSwapped = false
@@ -291,24 +704,283 @@ func tryBuildSliceFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.Selector
 		return nil
 	}
 
-	// Chains must be identical (comparing the same field/method on both elements).
-	if lhsChain != rhsChain {
+	elemTypeStr, ok := elemTypeString(pass, call.Pos(), sliceArg)
+	if !ok {
 		return nil
 	}
 
-	// Descending when exactly one of operator or params is reversed (XOR).
-	descending := opReversed != paramsSwapped
+	aExpr := "a" + lhsChain
+	bExpr := "b" + lhsChain
+	if opReversed != paramsSwapped {
+		aExpr, bExpr = bExpr, aExpr
+	}
+	newFunc := fmt.Sprintf("func(a, b %s) int { return %s.Compare(%s, %s) }", elemTypeStr, pkgIdent.Name, aExpr, bExpr)
 
-	// Infer the element type from the slice argument.
+	return []analysis.TextEdit{
+		{
+			Pos:     sel.Pos(),
+			End:     sel.Sel.End(),
+			NewText: []byte(replacement),
+		},
+		{
+			Pos:     funcLit.Pos(),
+			End:     funcLit.End(),
+			NewText: []byte(newFunc),
+		},
+	}
+}
+
+// tryBuildLessMethodFix builds TextEdits for sort.Slice/SliceStable/SliceIsSorted
+// calls whose callback returns a method call comparing one element with the
+// other, such as s[i].Less(s[j]) or s[i].Before(s[j]). The method must take
+// exactly the element type and return bool. The comparator calls it both ways:
+//
+//	func(a, b T) int {
+//	    if a.Less(b) {
+//	        return -1
+//	    }
+//	    if b.Less(a) {
+//	        return 1
+//	    }
+//	    return 0
+//	}
+//
+// so that a negative result means exactly what the callback returned. It
+// returns nil edits when the callback has any other shape.
+func tryBuildLessMethodFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, replacement string) []analysis.TextEdit {
+	if len(call.Args) != 2 {
+		return nil
+	}
+	sliceArg := call.Args[0]
+	sliceName, _, ok := sliceOperand(pass, sliceArg)
+	if !ok {
+		return nil
+	}
+	funcLit, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return nil
+	}
+	iParam, jParam, ok := callbackParams(funcLit.Type)
+	if !ok {
+		return nil
+	}
+	retStmt := bodyReturn(funcLit.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return nil
+	}
+
+	// The result must be s[x].M(s[y]) with x and y the two parameters.
+	lessCall, ok := retStmt.Results[0].(*ast.CallExpr)
+	if !ok || len(lessCall.Args) != 1 || lessCall.Ellipsis.IsValid() {
+		return nil
+	}
+	method, ok := lessCall.Fun.(*ast.SelectorExpr)
+	if !ok || method.Sel.Name == "a" || method.Sel.Name == "b" {
+		return nil
+	}
+	recvChain, recvParam, ok := extractChain(method.X, sliceName)
+	if !ok || recvChain != "" {
+		return nil
+	}
+	argChain, argParam, ok := extractChain(lessCall.Args[0], sliceName)
+	if !ok || argChain != "" {
+		return nil
+	}
+	var x, y string
+	switch {
+	case recvParam == iParam && argParam == jParam:
+		x, y = "a", "b"
+	case recvParam == jParam && argParam == iParam:
+		x, y = "b", "a"
+	default:
+		return nil
+	}
+
+	// The method must compare with exactly another element.
+	selection, ok := pass.TypesInfo.Selections[method]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	sig, ok := selection.Type().(*types.Signature)
+	if !ok || sig.Variadic() || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return nil
+	}
+	if !types.Identical(sig.Results().At(0).Type(), types.Typ[types.Bool]) {
+		return nil
+	}
+	elemType := pass.TypesInfo.TypeOf(method.X)
+	if elemType == nil || !types.Identical(sig.Params().At(0).Type(), elemType) {
+		return nil
+	}
+
+	elemTypeStr, ok := elemTypeString(pass, call.Pos(), sliceArg)
+	if !ok {
+		return nil
+	}
+
+	// The comparator spans several lines, indented like the line the
+	// callback starts on.
+	tokFile := pass.Fset.File(funcLit.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	lineStart := tokFile.Offset(tokFile.LineStart(tokFile.Line(funcLit.Pos())))
+	line := src[lineStart:tokFile.Offset(funcLit.Pos())]
+	indent := string(line[:len(line)-len(strings.TrimLeft(string(line), " \t"))])
+
+	m := method.Sel.Name
+	newFunc := fmt.Sprintf("func(a, b %s) int {\n"+
+		"%[2]s\tif %[3]s.%[5]s(%[4]s) {\n%[2]s\t\treturn -1\n%[2]s\t}\n"+
+		"%[2]s\tif %[4]s.%[5]s(%[3]s) {\n%[2]s\t\treturn 1\n%[2]s\t}\n"+
+		"%[2]s\treturn 0\n%[2]s}", elemTypeStr, indent, x, y, m)
+
+	return []analysis.TextEdit{
+		{
+			Pos:     sel.Pos(),
+			End:     sel.Sel.End(),
+			NewText: []byte(replacement),
+		},
+		{
+			Pos:     funcLit.Pos(),
+			End:     funcLit.End(),
+			NewText: []byte(newFunc),
+		},
+	}
+}
+
+// reportInterfaceSort reports sort.Sort and sort.Stable calls on a slice type
+// of this package whose Less method compares a single key of the indexed
+// elements, as in
+//
+//	func (q byPriority) Less(i, j int) bool { return q[i].priority < q[j].priority }
+//
+// Such a type and its Len, Less, and Swap methods exist only to be sorted,
+// and slices.SortFunc with cmp.Compare on the key replaces all of them.
+// Removing the type touches every place it is used, so the call is reported
+// without a fix, with related information pointing at the Less method.
+func reportInterfaceSort(pass *analysis.Pass, call *ast.CallExpr, funcName, replacement string) {
+	if len(call.Args) != 1 {
+		return
+	}
+	t := pass.TypesInfo.TypeOf(call.Args[0])
+	if t == nil {
+		return
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+	if _, ok := named.Underlying().(*types.Slice); !ok {
+		return
+	}
+	obj, _, _ := types.LookupFieldOrMethod(named, true, pass.Pkg, "Less")
+	less, ok := obj.(*types.Func)
+	if !ok {
+		return
+	}
+
+	decl := funcDecl(pass, less)
+	if decl == nil || decl.Recv == nil || len(decl.Recv.List) != 1 || len(decl.Recv.List[0].Names) != 1 {
+		return
+	}
+	recv := decl.Recv.List[0].Names[0].Name
+	iParam, jParam, ok := callbackParams(decl.Type)
+	if !ok {
+		return
+	}
+	retStmt := bodyReturn(decl.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return
+	}
+	binExpr, ok := retStmt.Results[0].(*ast.BinaryExpr)
+	if !ok || (binExpr.Op != token.LSS && binExpr.Op != token.GTR) {
+		return
+	}
+	lhsChain, lhsParam, lhsOk := extractChain(binExpr.X, recv)
+	rhsChain, rhsParam, rhsOk := extractChain(binExpr.Y, recv)
+	if !lhsOk || !rhsOk || lhsChain != rhsChain || lhsParam == rhsParam {
+		return
+	}
+	if !chainArgsMatch(pass, binExpr.X, binExpr.Y, iParam, jParam) {
+		return
+	}
+	if (lhsParam != iParam && lhsParam != jParam) || (rhsParam != iParam && rhsParam != jParam) {
+		return
+	}
+	if !isOrdered(pass.TypesInfo.TypeOf(binExpr.X)) {
+		return
+	}
+
+	key := "elements"
+	if lhsChain != "" {
+		key = lhsChain
+	}
+	pass.Report(analysis.Diagnostic{
+		Pos: call.Pos(),
+		Message: fmt.Sprintf("sort.%s on %s, whose Less compares %s, can be replaced with %s",
+			funcName, named.Obj().Name(), key, replacement),
+		Related: []analysis.RelatedInformation{
+			{Pos: decl.Pos(), End: decl.End(), Message: "Less compares a single key; the Len, Less, and Swap methods can go"},
+		},
+	})
+}
+
+// funcDecl returns the declaration of fn among the files of the package, or
+// nil if fn is declared elsewhere.
+func funcDecl(pass *analysis.Pass, fn *types.Func) *ast.FuncDecl {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && pass.TypesInfo.Defs[fd.Name] == fn {
+				return fd
+			}
+		}
+	}
+	return nil
+}
+
+// unfixable returns related information explaining why the callback
+// migration is report-only, positioned at n.
+func unfixable(n ast.Node, reason string) *analysis.RelatedInformation {
+	return &analysis.RelatedInformation{Pos: n.Pos(), End: n.End(), Message: reason}
+}
+
+// callbackParams returns the parameter names of a func(i, j int) bool
+// callback or Less method.
+func callbackParams(funcType *ast.FuncType) (iParam, jParam string, ok bool) {
+	params := funcType.Params
+	if params == nil {
+		return "", "", false
+	}
+	switch {
+	case len(params.List) == 1 && len(params.List[0].Names) == 2:
+		return params.List[0].Names[0].Name, params.List[0].Names[1].Name, true
+	case len(params.List) == 2 && len(params.List[0].Names) == 1 && len(params.List[1].Names) == 1:
+		return params.List[0].Names[0].Name, params.List[1].Names[0].Name, true
+	}
+	return "", "", false
+}
+
+// elemTypeString returns the element type of sliceArg as it would be written
+// in the file containing pos. It reports false if sliceArg is not a slice or
+// the type refers to a package the file does not import under its own name,
+// or is a type literal too unwieldy to repeat in a comparator.
+func elemTypeString(pass *analysis.Pass, pos token.Pos, sliceArg ast.Expr) (string, bool) {
 	sliceType := pass.TypesInfo.TypeOf(sliceArg)
 	if sliceType == nil {
-		return nil
+		return "", false
 	}
 	sliceT, ok := sliceType.Underlying().(*types.Slice)
 	if !ok {
-		return nil
+		return "", false
 	}
 	elemType := sliceT.Elem()
+	if !simpleType(elemType) {
+		return "", false
+	}
 	// Use a qualifier that returns the package name (not path) for valid Go source.
 	// types.RelativeTo returns the full path (e.g., "io/fs"), but source code uses
 	// the package name (e.g., "fs").
@@ -324,73 +996,326 @@ func tryBuildSliceFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.Selector
 	// verify that package is already imported without an alias. We can't add
 	// arbitrary package imports, but we can proceed if it's already available.
 	if strings.Contains(elemTypeStr, ".") {
-		if !externalTypeImported(pass, call.Pos(), elemType) {
-			return nil
+		if !externalTypeImported(pass, pos, elemType) {
+			return "", false
+		}
+	}
+	return elemTypeStr, true
+}
+
+// simpleType reports whether t is built only from named, basic, and
+// type-parameter types. Struct, function, and non-empty interface literals
+// are rejected: written out in a comparator signature they are unwieldy,
+// and a struct literal may name unexported fields of another package.
+func simpleType(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic, *types.TypeParam:
+		return true
+	case *types.Named:
+		for i := range t.TypeArgs().Len() {
+			if !simpleType(t.TypeArgs().At(i)) {
+				return false
+			}
 		}
+		return true
+	case *types.Alias:
+		for i := range t.TypeArgs().Len() {
+			if !simpleType(t.TypeArgs().At(i)) {
+				return false
+			}
+		}
+		return true
+	case *types.Pointer:
+		return simpleType(t.Elem())
+	case *types.Slice:
+		return simpleType(t.Elem())
+	case *types.Array:
+		return simpleType(t.Elem())
+	case *types.Map:
+		return simpleType(t.Key()) && simpleType(t.Elem())
+	case *types.Interface:
+		return t.Empty()
 	}
+	return false
+}
 
-	// Build cmp.Compare arguments.
-	chain := lhsChain
-	aExpr := "a" + chain
-	bExpr := "b" + chain
-	if descending {
-		aExpr, bExpr = bExpr, aExpr
+// bodyReturn returns the return statement of a callback body that consists
+// of nothing else. Leading empty statements are skipped, and a body whose only
+// statement is a nested block is unwrapped, so these are accepted:
+//
+//	{ return s[i] < s[j] }
+//	{ ; return s[i] < s[j] }
+//	{ { return s[i] < s[j] } }
+//
+// It returns nil for any body with other statements.
+func bodyReturn(body *ast.BlockStmt) *ast.ReturnStmt {
+	if body == nil {
+		return nil
+	}
+	stmts := body.List
+	for len(stmts) > 0 {
+		if _, ok := stmts[0].(*ast.EmptyStmt); !ok {
+			break
+		}
+		stmts = stmts[1:]
+	}
+	if len(stmts) != 1 {
+		return nil
 	}
+	switch stmt := stmts[0].(type) {
+	case *ast.ReturnStmt:
+		return stmt
+	case *ast.BlockStmt:
+		return bodyReturn(stmt)
+	}
+	return nil
+}
 
-	newFunc := fmt.Sprintf("func(a, b %s) int { return cmp.Compare(%s, %s) }", elemTypeStr, aExpr, bExpr)
+// builtinLenArg returns the argument of expr if expr is a call to the builtin
+// len with one argument. It reports false for any other expression, including
+// calls to a user-defined function that shadows len.
+func builtinLenArg(pass *analysis.Pass, expr ast.Expr) (ast.Expr, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil, false
+	}
+	lenFun, ok := call.Fun.(*ast.Ident)
+	if !ok || lenFun.Name != "len" {
+		return nil, false
+	}
+	if obj := pass.TypesInfo.ObjectOf(lenFun); obj != nil && obj.Pkg() != nil {
+		return nil, false // not the builtin
+	}
+	return call.Args[0], true
+}
 
-	return []analysis.TextEdit{
-		{
-			Pos:     sel.Pos(),
-			End:     sel.Sel.End(),
-			NewText: []byte(replacement),
-		},
-		{
-			Pos:     funcLit.Pos(),
-			End:     funcLit.End(),
-			NewText: []byte(newFunc),
-		},
+// mapLookup splits expr into the map and key if expr indexes a map.
+func mapLookup(pass *analysis.Pass, expr ast.Expr) (m, key ast.Expr, ok bool) {
+	idx, ok := expr.(*ast.IndexExpr)
+	if !ok {
+		return nil, nil, false
+	}
+	t := pass.TypesInfo.TypeOf(idx.X)
+	if t == nil {
+		return nil, nil, false
+	}
+	if _, ok := t.Underlying().(*types.Map); !ok {
+		return nil, nil, false
 	}
+	return idx.X, idx.Index, true
 }
 
-// externalTypeImported checks whether the package of an external named type is
-// already imported (without alias) in the file containing pos. This allows
-// auto-fixing sort.Slice calls where the element type is from another package
-// that the file already uses (e.g., []fs.DirEntry when "io/fs" is imported).
-func externalTypeImported(pass *analysis.Pass, pos token.Pos, elemType types.Type) bool {
-	// Unwrap pointer if present (e.g., *pkg.Type → pkg.Type).
-	if ptr, ok := elemType.(*types.Pointer); ok {
-		elemType = ptr.Elem()
+// sameExpr reports whether a and b are the same identifier or selector
+// chain, referring to the same objects.
+func sameExpr(pass *analysis.Pass, a, b ast.Expr) bool {
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameExpr(pass, a.X, b.X)
+	}
+	return false
+}
+
+// mentionsAny reports whether expr contains an identifier with one of names.
+// The generated comparator declares its own parameters, so a captured
+// expression must not use names it would shadow.
+func mentionsAny(expr ast.Expr, names ...string) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && slices.Contains(names, ident.Name) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isOrdered reports whether t is a basic type that supports < and cmp.Compare.
+func isOrdered(t types.Type) bool {
+	if t == nil {
+		return false
 	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsOrdered != 0
+}
 
-	named, ok := elemType.(*types.Named)
+// negatedOperand returns the operand of expr if expr is a unary minus applied
+// to a signed integer or floating-point value. Unsigned values are rejected
+// because negation wraps around rather than reversing their order.
+//
+// For floats the flip is exact. For signed integers it holds for every value
+// except the minimum, whose negation overflows back to itself.
+func negatedOperand(pass *analysis.Pass, expr ast.Expr) (ast.Expr, bool) {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.SUB {
+		return nil, false
+	}
+	t := pass.TypesInfo.TypeOf(unary.X)
+	if t == nil {
+		return nil, false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return nil, false
+	}
+	info := basic.Info()
+	if info&types.IsFloat == 0 && (info&types.IsInteger == 0 || info&types.IsUnsigned != 0) {
+		return nil, false
+	}
+	return unary.X, true
+}
+
+// comparesFloats reports whether the callback of a sort.Slice call is a func
+// literal returning a single comparison of floating-point values.
+func comparesFloats(pass *analysis.Pass, call *ast.CallExpr) bool {
+	if len(call.Args) != 2 {
+		return false
+	}
+	funcLit, ok := call.Args[1].(*ast.FuncLit)
+	if !ok {
+		return false
+	}
+	retStmt := bodyReturn(funcLit.Body)
+	if retStmt == nil || len(retStmt.Results) != 1 {
+		return false
+	}
+	binExpr, ok := retStmt.Results[0].(*ast.BinaryExpr)
 	if !ok {
 		return false
 	}
 
-	typePkg := named.Obj().Pkg()
-	if typePkg == nil || typePkg == pass.Pkg {
-		return true // built-in or same package — always available
+	t := pass.TypesInfo.TypeOf(binExpr.X)
+	if t == nil {
+		return false
 	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsFloat != 0
+}
 
+// externalTypeImported checks whether every package that elemType refers to
+// is already imported (without alias) in the file containing pos. This allows
+// auto-fixing sort.Slice calls where the element type is from another package
+// that the file already uses (e.g., []fs.DirEntry when "io/fs" is imported).
+// Type arguments count too: Box[fs.FileMode] needs "io/fs" even when Box is
+// declared in the current package.
+func externalTypeImported(pass *analysis.Pass, pos token.Pos, elemType types.Type) bool {
 	file := importutil.FindFileForPos(pass, pos)
 	if file == nil {
 		return false
 	}
 
-	targetPath := typePkg.Path()
+	// Skip aliased imports — the generated code uses the canonical package
+	// name from types.TypeString, which won't match an alias.
+	imported := map[string]bool{}
 	for _, imp := range file.Imports {
-		// Skip aliased imports — the generated code uses the canonical package
-		// name from types.TypeString, which won't match an alias.
-		if imp.Name != nil {
-			continue
+		if imp.Name == nil {
+			imported[strings.Trim(imp.Path.Value, `"`)] = true
 		}
-		path := strings.Trim(imp.Path.Value, `"`)
-		if path == targetPath {
+	}
+	available := func(obj types.Object) bool {
+		pkg := obj.Pkg()
+		// Built-in or same package — always available.
+		return pkg == nil || pkg == pass.Pkg || imported[pkg.Path()]
+	}
+
+	var visit func(t types.Type) bool
+	visit = func(t types.Type) bool {
+		switch t := t.(type) {
+		case interface {
+			Obj() *types.TypeName
+			TypeArgs() *types.TypeList
+		}: // *types.Named or *types.Alias
+			if !available(t.Obj()) {
+				return false
+			}
+			for targ := range t.TypeArgs().Types() {
+				if !visit(targ) {
+					return false
+				}
+			}
+			return true
+		case *types.Pointer:
+			return visit(t.Elem())
+		case *types.Slice:
+			return visit(t.Elem())
+		case *types.Array:
+			return visit(t.Elem())
+		case *types.Map:
+			return visit(t.Key()) && visit(t.Elem())
+		case *types.Chan:
+			return visit(t.Elem())
+		case *types.Basic, *types.TypeParam:
 			return true
 		}
+		// Struct, function, and interface literals are rare as element
+		// types; leave them report-only rather than walk them.
+		return false
 	}
-	return false
+	return visit(elemType)
+}
+
+// chainArgsMatch reports whether the method calls in two chains accepted by
+// extractChain pass the same arguments, such as origin in
+// s[i].Distance(origin) < s[j].Distance(origin), and none of the arguments
+// mentions names. Callers pass the callback parameters, whose values differ
+// between the sides, and the comparator parameters a and b, which would
+// shadow the argument in the rewritten callback.
+func chainArgsMatch(pass *analysis.Pass, lhs, rhs ast.Expr, names ...string) bool {
+	switch l := lhs.(type) {
+	case *ast.SelectorExpr:
+		r, ok := rhs.(*ast.SelectorExpr)
+		return ok && chainArgsMatch(pass, l.X, r.X, names...)
+	case *ast.CallExpr:
+		r, ok := rhs.(*ast.CallExpr)
+		if !ok || len(l.Args) != len(r.Args) {
+			return false
+		}
+		for i := range l.Args {
+			if mentionsAny(l.Args[i], names...) || !sameArg(pass, l.Args[i], r.Args[i]) {
+				return false
+			}
+		}
+		return chainArgsMatch(pass, l.Fun, r.Fun, names...)
+	}
+	return true
+}
+
+// sameArg reports whether a and b are the same constant or refer to the same
+// variable.
+func sameArg(pass *analysis.Pass, a, b ast.Expr) bool {
+	if va, vb := pass.TypesInfo.Types[a].Value, pass.TypesInfo.Types[b].Value; va != nil && vb != nil {
+		return constant.Compare(va, token.EQL, vb)
+	}
+	return sameExpr(pass, a, b)
+}
+
+// sliceOperand returns the slice argument of a sort call as the callback
+// indexes it, with the name of the variable at its root. The argument must
+// be a variable, or a chain of field selections on one such as s.items, so
+// that each evaluation in the callback yields the same slice.
+func sliceOperand(pass *analysis.Pass, expr ast.Expr) (name, root string, ok bool) {
+	x := expr
+	for {
+		sel, isSel := x.(*ast.SelectorExpr)
+		if !isSel {
+			break
+		}
+		if selection := pass.TypesInfo.Selections[sel]; selection == nil || selection.Kind() != types.FieldVal {
+			return "", "", false
+		}
+		x = sel.X
+	}
+	ident, isIdent := x.(*ast.Ident)
+	if !isIdent {
+		return "", "", false
+	}
+	if _, isVar := pass.TypesInfo.Uses[ident].(*types.Var); !isVar {
+		return "", "", false
+	}
+	return types.ExprString(expr), ident.Name, true
 }
 
 // extractChain walks an expression tree rooted at sliceName[param] and returns
@@ -402,12 +1327,15 @@ func externalTypeImported(pass *analysis.Pass, pos token.Pos, elemType types.Typ
 //	s[i].Name      → (".Name",     "i", true)
 //	s[i].Name()    → (".Name()",   "i", true)
 //	s[i].F.M()     → (".F.M()",    "i", true)
+//	s[i].D(o)      → (".D(o)",     "i", true)
 //	other          → ("",          "",  false)
+//
+// Method arguments are rendered as written; chainArgsMatch checks that they
+// are the same on both sides of a comparison.
 func extractChain(expr ast.Expr, sliceName string) (chain string, param string, ok bool) {
 	switch e := expr.(type) {
 	case *ast.IndexExpr:
-		ident, isIdent := e.X.(*ast.Ident)
-		if !isIdent || ident.Name != sliceName {
+		if types.ExprString(e.X) != sliceName {
 			return "", "", false
 		}
 		idx, isIdent := e.Index.(*ast.Ident)
@@ -424,14 +1352,18 @@ func extractChain(expr ast.Expr, sliceName string) (chain string, param string,
 		return chain + "." + e.Sel.Name, param, true
 
 	case *ast.CallExpr:
-		if len(e.Args) != 0 {
+		if e.Ellipsis.IsValid() {
 			return "", "", false
 		}
 		chain, param, ok := extractChain(e.Fun, sliceName)
 		if !ok {
 			return "", "", false
 		}
-		return chain + "()", param, true
+		args := make([]string, len(e.Args))
+		for i, arg := range e.Args {
+			args[i] = types.ExprString(arg)
+		}
+		return chain + "(" + strings.Join(args, ", ") + ")", param, true
 
 	default:
 		return "", "", false
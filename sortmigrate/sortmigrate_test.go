@@ -1,6 +1,14 @@
 package sortmigrate_test
 
 import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/albertocavalcante/go-analyzers/sortmigrate"
@@ -11,3 +19,219 @@ func TestSortMigrate(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.RunWithSuggestedFixes(t, testdata, sortmigrate.Analyzer, "sorttest")
 }
+
+// importEdit is an edit adding the slices import, with the position of the
+// diagnostic that carries it.
+type importEdit struct {
+	diag token.Position
+	text string
+}
+
+// importEdits returns the edits adding the slices import in results, keyed by
+// the base name of the file each edit applies to.
+func importEdits(results []*analysistest.Result) map[string][]importEdit {
+	edits := map[string][]importEdit{}
+	for _, result := range results {
+		fset := result.Pass.Fset
+		for _, diag := range result.Diagnostics {
+			for _, fix := range diag.SuggestedFixes {
+				for _, edit := range fix.TextEdits {
+					if !strings.Contains(string(edit.NewText), `"slices"`) {
+						continue
+					}
+					file := filepath.Base(fset.File(edit.Pos).Name())
+					edits[file] = append(edits[file], importEdit{diag: fset.Position(diag.Pos), text: string(edit.NewText)})
+				}
+			}
+		}
+	}
+	return edits
+}
+
+func TestSortMigrateWarnFloatCompare(t *testing.T) {
+	if err := sortmigrate.Analyzer.Flags.Set("warn-float-compare", "true"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = sortmigrate.Analyzer.Flags.Set("warn-float-compare", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, sortmigrate.Analyzer, "sortfloattest")
+}
+
+// A file with both a plain and a callback migration gets a single import
+// edit adding cmp and slices together.
+func TestSortMigrateMixedImports(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, sortmigrate.Analyzer, "sorttest")
+
+	edits := importEdits(results)["mixed.go"]
+	if len(edits) != 1 {
+		t.Fatalf("got %d import edits in mixed.go, want 1: %v", len(edits), edits)
+	}
+	if got := edits[0].text; !strings.Contains(got, `"cmp"`) || strings.Index(got, `"cmp"`) > strings.Index(got, `"slices"`) {
+		t.Errorf("import edit %q does not add cmp before slices", got)
+	}
+}
+
+// Report-only callbacks carry no fix, so a file whose only sort call is
+// report-only gets no import edit either.
+func TestSortMigrateReportOnlyFile(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, sortmigrate.Analyzer, "sorttest")
+
+	found := false
+	for _, result := range results {
+		fset := result.Pass.Fset
+		for _, diag := range result.Diagnostics {
+			if filepath.Base(fset.File(diag.Pos).Name()) != "report_only.go" {
+				continue
+			}
+			found = true
+			if len(diag.SuggestedFixes) != 0 {
+				t.Errorf("report_only.go:%d: got %d suggested fixes, want none", fset.Position(diag.Pos).Line, len(diag.SuggestedFixes))
+			}
+		}
+	}
+	if !found {
+		t.Error("no diagnostic in report_only.go")
+	}
+	if edits := importEdits(results)["report_only.go"]; len(edits) != 0 {
+		t.Errorf("got import edits in report_only.go, want none: %v", edits)
+	}
+}
+
+// Comparators on named slice types take the element type, so the fixed
+// file must still type-check.
+func TestSortMigrateNamedSliceGoldenTypeChecks(t *testing.T) {
+	path := filepath.Join(analysistest.TestData(), "src", "sorttest", "named_slice.go.golden")
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	if _, err := conf.Check("sorttest", fset, []*ast.File{file}, nil); err != nil {
+		t.Errorf("fixed named_slice.go does not type-check: %v", err)
+	}
+}
+
+// spaceImportSrc indents its imports with spaces, which gofmt would undo,
+// so it is written out by the test rather than kept in testdata.
+const spaceImportSrc = `package spaceimporttest
+
+import (
+    "fmt"
+    "sort"
+)
+
+func Print(names []string) {
+	sort.Slice(names, func(i, j int) bool { return names[i] > names[j] }) // want ` + "`sort\\.Slice can be replaced with slices\\.SortFunc`" + `
+	fmt.Println(names)
+}
+`
+
+// Imports added to a grouped import are indented like the existing specs.
+func TestSortMigrateImportIndent(t *testing.T) {
+	dir, cleanup, err := analysistest.WriteFiles(map[string]string{
+		"spaceimporttest/spaceimporttest.go": spaceImportSrc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(cleanup)
+
+	results := analysistest.Run(t, dir, sortmigrate.Analyzer, "spaceimporttest")
+
+	edits := importEdits(results)["spaceimporttest.go"]
+	want := "    \"cmp\"\n    \"slices\"\n"
+	if len(edits) != 1 || edits[0].text != want {
+		t.Errorf("import edits %v, want one %q", edits, want)
+	}
+}
+
+// Each file of a package gets its own import edit, attached to its first
+// diagnostic, even when several files need the same import.
+func TestSortMigrateImportPerFile(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.RunWithSuggestedFixes(t, testdata, sortmigrate.Analyzer, "sortmultifiletest")
+
+	edits := importEdits(results)
+	want := map[string][]int{"a.go": {6}, "b.go": {14}}
+	for file, lines := range want {
+		var got []int
+		for _, edit := range edits[file] {
+			if diagFile := filepath.Base(edit.diag.Filename); diagFile != file {
+				t.Errorf("diagnostic in %s carries an import edit for %s", diagFile, file)
+			}
+			got = append(got, edit.diag.Line)
+		}
+		if !slices.Equal(got, lines) {
+			t.Errorf("%s: import edits on diagnostics at lines %v, want %v", file, got, lines)
+		}
+	}
+}
+
+// Report-only callback migrations point at what blocks the fix.
+func TestSortMigrateRelated(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, sortmigrate.Analyzer, "sortrelatedtest")
+
+	want := map[int]struct {
+		line, col int
+		message   string
+	}{
+		6:  {6, 36, "callback body is not a single return statement"},
+		17: {17, 16, "callback is not a function literal"},
+		21: {21, 13, "slice argument is not a variable or a field of one"},
+		27: {27, 45, "callback does not return a single <, <=, >, or >= comparison"},
+	}
+	for _, result := range results {
+		fset := result.Pass.Fset
+		for _, diag := range result.Diagnostics {
+			line := fset.Position(diag.Pos).Line
+			w, ok := want[line]
+			if !ok {
+				t.Errorf("unexpected diagnostic on line %d", line)
+				continue
+			}
+			delete(want, line)
+			if len(diag.Related) != 1 {
+				t.Errorf("line %d: got %d related entries, want 1", line, len(diag.Related))
+				continue
+			}
+			rel := diag.Related[0]
+			posn := fset.Position(rel.Pos)
+			if posn.Line != w.line || posn.Column != w.col || rel.Message != w.message {
+				t.Errorf("line %d: related %d:%d %q, want %d:%d %q",
+					line, posn.Line, posn.Column, rel.Message, w.line, w.col, w.message)
+			}
+		}
+	}
+	for line := range want {
+		t.Errorf("no diagnostic on line %d", line)
+	}
+}
+
+// Callback fix messages name the slice argument.
+func TestSortMigrateFixMessage(t *testing.T) {
+	testdata := analysistest.TestData()
+	results := analysistest.Run(t, testdata, sortmigrate.Analyzer, "sortfixmsgtest")
+
+	want := map[string]string{
+		"sort.Slice can be replaced with slices.SortFunc": "use slices.SortFunc(items, ...)",
+		"sort.Strings can be replaced with slices.Sort":   "sort.Strings can be replaced with slices.Sort",
+	}
+	for _, result := range results {
+		for _, diag := range result.Diagnostics {
+			if len(diag.SuggestedFixes) != 1 {
+				t.Errorf("%s: got %d fixes, want 1", diag.Message, len(diag.SuggestedFixes))
+				continue
+			}
+			if got := diag.SuggestedFixes[0].Message; got != want[diag.Message] {
+				t.Errorf("%s: fix message %q, want %q", diag.Message, got, want[diag.Message])
+			}
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package sortfixmsgtest
+
+import "sort"
+
+type Item struct {
+	Name string
+}
+
+func callback(items []Item) {
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func plain(names []string) {
+	sort.Strings(names) // want `sort\.Strings can be replaced with slices\.Sort`
+}
@@ -0,0 +1,43 @@
+package sortfloattest
+
+import "sort"
+
+type Result struct {
+	Name  string
+	Score float64
+}
+
+type Celsius float32
+
+// Float field: the fix is offered with a NaN-ordering caveat.
+func sortByScore() {
+	results := []Result{{Score: 2}, {Score: 1}}
+	sort.Slice(results, func(i, j int) bool { return results[i].Score < results[j].Score }) // want `sort\.Slice can be replaced with slices\.SortFunc \(note: cmp\.Compare orders NaN before all other values, while < leaves NaN order undefined\)`
+	_ = results
+}
+
+// Named float type, descending.
+func sortCelsiusDescending() {
+	temps := []Celsius{1, 3, 2}
+	sort.SliceStable(temps, func(i, j int) bool { return temps[i] > temps[j] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc \(note: cmp\.Compare orders NaN`
+	_ = temps
+}
+
+// Non-float field: no caveat.
+func sortByName() {
+	results := []Result{{Name: "b"}, {Name: "a"}}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name }) // want `sort\.Slice can be replaced with slices\.SortFunc$`
+	_ = results
+}
+
+// Complex callback: report-only, no caveat since no fix is offered.
+func sortComplex() {
+	results := []Result{{Score: 2}, {Score: 1}}
+	sort.Slice(results, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc$`
+		if results[i].Score == results[j].Score {
+			return results[i].Name < results[j].Name
+		}
+		return results[i].Score < results[j].Score
+	})
+	_ = results
+}
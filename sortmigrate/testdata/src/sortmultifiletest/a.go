@@ -0,0 +1,11 @@
+package sortmultifiletest
+
+import "sort"
+
+func sortNames(names []string) {
+	sort.Strings(names) // want `sort\.Strings can be replaced with slices\.Sort`
+}
+
+func sortIDs(ids []int) {
+	sort.Ints(ids) // want `sort\.Ints can be replaced with slices\.Sort`
+}
@@ -0,0 +1,19 @@
+//go:build go1.21
+
+package sortmultifiletest
+
+import (
+	"sort"
+	"strings"
+)
+
+func sortUpper(names []string) {
+	for i := range names {
+		names[i] = strings.ToUpper(names[i])
+	}
+	sort.Strings(names) // want `sort\.Strings can be replaced with slices\.Sort`
+}
+
+func sortScores(scores []float64) {
+	sort.Float64s(scores) // want `sort\.Float64s can be replaced with slices\.Sort`
+}
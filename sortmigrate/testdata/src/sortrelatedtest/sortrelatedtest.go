@@ -0,0 +1,28 @@
+package sortrelatedtest
+
+import "sort"
+
+func multiStatement(s []int) {
+	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc`
+		a, b := s[i], s[j]
+		return a < b
+	})
+}
+
+func less(s []int) func(i, j int) bool {
+	return func(i, j int) bool { return s[i] < s[j] }
+}
+
+func notInline(s []int) {
+	sort.Slice(s, less(s)) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func asserted(x any) {
+	sort.Slice(x.([]int), func(i, j int) bool { return x.([]int)[i] < x.([]int)[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// An equality is not a less function; it stays report-only rather than
+// become a comparator that orders differently.
+func equality(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] == s[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
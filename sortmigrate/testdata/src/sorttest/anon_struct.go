@@ -0,0 +1,31 @@
+package sorttest
+
+import "sort"
+
+// Slice of anonymous structs: the comparator would have to spell out
+// struct{ name string; rank int }, so report-only.
+func sliceAnonStruct() {
+	s := []struct {
+		name string
+		rank int
+	}{{"b", 2}, {"a", 1}}
+	sort.Slice(s, func(i, j int) bool { return s[i].rank < s[j].rank }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+type ranked[T any] struct {
+	items []struct {
+		value T
+		rank  int
+	}
+}
+
+// Anonymous struct element involving a type parameter, inside a generic
+// method: report-only as well.
+func (r *ranked[T]) sort() {
+	sort.Slice(r.items, func(i, j int) bool { return r.items[i].rank < r.items[j].rank }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Pointers to anonymous structs are no easier to spell.
+func slicePtrAnonStruct(s []*struct{ rank int }) {
+	sort.Slice(s, func(i, j int) bool { return s[i].rank < s[j].rank }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
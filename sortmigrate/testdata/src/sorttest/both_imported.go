@@ -0,0 +1,16 @@
+package sorttest
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// Both cmp and slices are already imported, so the callback migration
+// rewrites the call without adding any import.
+func bothImported(items []Item) {
+	sort.Slice(items, func(i, j int) bool { return items[i].Age < items[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+
+	// Existing usage to justify the imports.
+	_ = slices.Contains([]int{1}, cmp.Compare(1, 2))
+}
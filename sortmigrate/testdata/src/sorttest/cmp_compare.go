@@ -0,0 +1,40 @@
+package sorttest
+
+import (
+	"cmp"
+	"sort"
+)
+
+// Callback already delegating to cmp.Compare on a field.
+func sliceCmpCompareField() {
+	items := []Item{{Name: "b"}, {Name: "a"}}
+	sort.Slice(items, func(i, j int) bool { return cmp.Compare(items[i].Name, items[j].Name) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// Descending with > 0 on a field chain.
+func sliceCmpCompareDescending() {
+	items := []Item{{Age: 1}, {Age: 2}}
+	sort.SliceStable(items, func(i, j int) bool { return cmp.Compare(items[i].Age, items[j].Age) > 0 }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = items
+}
+
+// Whole integer elements in ascending order: the natural order.
+func sliceCmpCompareWhole() {
+	nums := []int{3, 1, 2}
+	sort.Slice(nums, func(i, j int) bool { return cmp.Compare(nums[i], nums[j]) < 0 }) // want `sort\.Slice can be replaced with slices\.Sort`
+	_ = nums
+}
+
+// Whole string elements, checked for order.
+func sliceCmpCompareWholeSorted() bool {
+	names := []string{"a", "b"}
+	return sort.SliceIsSorted(names, func(i, j int) bool { return cmp.Compare(names[i], names[j]) < 0 }) // want `sort\.SliceIsSorted can be replaced with slices\.IsSorted`
+}
+
+// Whole float elements keep the comparator: -0 and +0 compare equal.
+func sliceCmpCompareFloat() {
+	fs := []float64{2, 1}
+	sort.Slice(fs, func(i, j int) bool { return cmp.Compare(fs[i], fs[j]) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = fs
+}
@@ -0,0 +1,22 @@
+package sorttest
+
+import (
+	"net/url"
+	"sort"
+)
+
+// Pointers to a type from an imported package: the comparator takes
+// *url.URL.
+func slicePointerForeign(us []*url.URL) {
+	sort.Slice(us, func(i, j int) bool { return us[i].Host < us[j].Host }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Method on the pointed-to foreign type, descending.
+func slicePointerForeignMethod(us []*url.URL) {
+	sort.SliceStable(us, func(i, j int) bool { return us[i].String() > us[j].String() }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
+
+// Pointer to a generic type instantiated with a foreign type.
+func slicePointerGenericForeign(bs []*Box[*url.URL]) {
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Label < bs[j].Label }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
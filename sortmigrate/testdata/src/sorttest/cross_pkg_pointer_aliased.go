@@ -0,0 +1,13 @@
+package sorttest
+
+import (
+	"sort"
+
+	neturl "net/url"
+)
+
+// Pointers to a foreign type imported under an alias: *url.URL cannot be
+// written here, so report-only.
+func slicePointerForeignAliased(us []*neturl.URL) {
+	sort.Slice(us, func(i, j int) bool { return us[i].Host < us[j].Host }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
@@ -0,0 +1,16 @@
+package sorttest
+
+import "sort"
+
+// Calls wrapped in defer and go statements are rewritten in place.
+func deferredSort(s []string) {
+	defer sort.Strings(s) // want `sort\.Strings can be replaced with slices\.Sort`
+}
+
+func goSort(s []int) {
+	go sort.Ints(s) // want `sort\.Ints can be replaced with slices\.Sort`
+}
+
+func deferredCallback(items []Item) {
+	defer sort.Slice(items, func(i, j int) bool { return items[i].Age < items[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
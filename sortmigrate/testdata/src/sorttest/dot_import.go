@@ -0,0 +1,18 @@
+package sorttest
+
+import . "sort"
+
+// Dot-imported sort — report-only, since the fix would need qualified
+// slices names.
+func dotImported() {
+	strs := []string{"b", "a"}
+	Strings(strs) // want `sort\.Strings can be replaced with slices\.Sort`
+
+	ints := []int{2, 1}
+	Slice(ints, func(i, j int) bool { return ints[i] < ints[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+
+	_ = IntsAreSorted(ints) // want `sort\.IntsAreSorted can be replaced with slices\.IsSorted`
+
+	// sort.Search — NOT flagged (handled by searchmigrate).
+	_ = Search(len(ints), func(i int) bool { return ints[i] >= 1 })
+}
@@ -0,0 +1,31 @@
+package sorttest
+
+import (
+	"os"
+	"sort"
+)
+
+type Box[T any] struct {
+	Label string
+	Value T
+}
+
+func boxes[T any](v T) []Box[T] {
+	return []Box[T]{{Value: v}}
+}
+
+// Instantiated same-package generic element type.
+func sliceGeneric() {
+	bs := []Box[string]{{Label: "b"}, {Label: "a"}}
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Label < bs[j].Label }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = bs
+}
+
+// Instantiated with a type argument from a package the file does not
+// import: Box[fs.FileMode] cannot be written here, so report-only.
+func sliceGenericForeignArg() {
+	info, _ := os.Stat(".")
+	bs := boxes(info.Mode())
+	sort.Slice(bs, func(i, j int) bool { return bs[i].Label < bs[j].Label }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = bs
+}
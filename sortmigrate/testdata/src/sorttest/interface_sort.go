@@ -0,0 +1,56 @@
+package sorttest
+
+import "sort"
+
+type task struct {
+	name     string
+	priority int
+}
+
+// byPriority implements sort.Interface only to order tasks by priority.
+type byPriority []task
+
+func (q byPriority) Len() int           { return len(q) }
+func (q byPriority) Less(i, j int) bool { return q[i].priority < q[j].priority }
+func (q byPriority) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func sortTasks(tasks []task) {
+	sort.Sort(byPriority(tasks)) // want `sort\.Sort on byPriority, whose Less compares \.priority, can be replaced with slices\.SortFunc`
+}
+
+func stableTasks(q byPriority) {
+	sort.Stable(q) // want `sort\.Stable on byPriority, whose Less compares \.priority, can be replaced with slices\.SortStableFunc`
+}
+
+// byName compares whole elements, descending.
+type byName []string
+
+func (s byName) Len() int           { return len(s) }
+func (s byName) Less(i, j int) bool { return s[j] < s[i] }
+func (s byName) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+func sortNames(names []string) {
+	sort.Sort(byName(names)) // want `sort\.Sort on byName, whose Less compares elements, can be replaced with slices\.SortFunc`
+}
+
+// byKeys breaks ties on a second key, which is not a single comparison.
+type byKeys []task
+
+func (q byKeys) Len() int      { return len(q) }
+func (q byKeys) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q byKeys) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].name < q[j].name
+}
+
+func sortKeys(tasks []task) {
+	sort.Sort(byKeys(tasks))
+}
+
+// Library implementations are not ours to remove.
+func sortLibrary(s []int) {
+	sort.Sort(sort.IntSlice(s))
+	sort.Sort(sort.Reverse(sort.IntSlice(s)))
+}
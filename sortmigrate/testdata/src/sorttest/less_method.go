@@ -0,0 +1,51 @@
+package sorttest
+
+import (
+	"sort"
+	"time"
+)
+
+type Version interface {
+	Less(other Version) bool
+}
+
+type semver struct{ major, minor int }
+
+func (v semver) Less(other semver) bool {
+	return v.major < other.major || v.major == other.major && v.minor < other.minor
+}
+
+func sortVersions(vs []Version) {
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Less(vs[j]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func sortSemversDesc(vs []semver) {
+	sort.SliceStable(vs, func(i, j int) bool { // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+		return vs[j].Less(vs[i])
+	})
+}
+
+func timesSorted(ts []time.Time) bool {
+	return sort.SliceIsSorted(ts, func(i, j int) bool { return ts[i].Before(ts[j]) }) // want `sort\.SliceIsSorted can be replaced with slices\.IsSortedFunc`
+}
+
+type Weighted struct{ w int }
+
+func (x Weighted) Heavier(y *Weighted) bool { return x.w > y.w }
+
+func (x Weighted) Under(limit int) bool { return x.w < limit }
+
+// The method must take the element type itself.
+func sortPointerArg(ws []Weighted) {
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Heavier(&ws[j]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// A method comparing against something other than the other element.
+func sortUnder(ws []Weighted, limit int) {
+	sort.Slice(ws, func(i, j int) bool { return ws[i].Under(limit) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Both sides must be the callback parameters.
+func sortSelf(vs []semver) {
+	sort.Slice(vs, func(i, j int) bool { return vs[i].Less(vs[i]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
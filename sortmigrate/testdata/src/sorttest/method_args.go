@@ -0,0 +1,35 @@
+package sorttest
+
+import "sort"
+
+type Point struct{ X, Y int }
+
+func (p Point) Distance(q Point) int {
+	dx, dy := p.X-q.X, p.Y-q.Y
+	return dx*dx + dy*dy
+}
+
+func (p Point) Scaled(k int) Point { return Point{p.X * k, p.Y * k} }
+
+func byDistance(points []Point, origin Point) {
+	sort.Slice(points, func(i, j int) bool { return points[i].Distance(origin) < points[j].Distance(origin) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func byScaledX(points []Point) {
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Scaled(2).X > points[j].Scaled(2).X }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
+
+// The argument uses a callback parameter, so it differs between the sides.
+func byIndexDistance(points []Point) {
+	sort.Slice(points, func(i, j int) bool { return points[i].Distance(points[i]) < points[j].Distance(points[i]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Different arguments on the two sides.
+func byMixedDistance(points []Point, p, q Point) {
+	sort.Slice(points, func(i, j int) bool { return points[i].Distance(p) < points[j].Distance(q) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// An argument named a would be shadowed by the comparator parameter.
+func byDistanceFromA(points []Point, a Point) {
+	sort.Slice(points, func(i, j int) bool { return points[i].Distance(a) < points[j].Distance(a) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
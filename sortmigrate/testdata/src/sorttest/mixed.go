@@ -0,0 +1,13 @@
+package sorttest
+
+import "sort"
+
+// A plain migration needs only slices and a callback migration needs cmp as
+// well. The file gets one import edit adding both, in alphabetical order.
+func mixedPlainAndCallback() {
+	names := []string{"b", "a"}
+	sort.Strings(names) // want `sort\.Strings can be replaced with slices\.Sort`
+
+	items := []Item{{Age: 2}, {Age: 1}}
+	sort.Slice(items, func(i, j int) bool { return items[i].Age < items[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
@@ -0,0 +1,32 @@
+package sorttest
+
+import "sort"
+
+// This file is self-contained so that its golden output can be
+// type-checked on its own.
+
+type IntList []int
+
+type member struct {
+	name string
+	rank int
+}
+
+type Roster []member
+
+// Named slice of int, ascending: the natural order.
+func sortIntList(il IntList) {
+	sort.Slice(il, func(i, j int) bool { return il[i] < il[j] }) // want `sort\.Slice can be replaced with slices\.Sort`
+}
+
+// Named slice of int, descending: the comparator takes int, not IntList.
+func sortIntListDescending(il IntList) {
+	sort.Slice(il, func(i, j int) bool { return il[i] > il[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Named slice of structs: the comparator takes the element type.
+func sortRoster(r Roster) {
+	sort.SliceStable(r, func(i, j int) bool { return r[i].rank < r[j].rank }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
+
+var _ = sort.Ints
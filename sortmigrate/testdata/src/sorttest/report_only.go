@@ -0,0 +1,14 @@
+package sorttest
+
+import "sort"
+
+// The only sort call in this file is report-only, so nothing in the file
+// changes: no fix, and no cmp or slices import.
+func reportOnlyMultiKey(items []Item) {
+	sort.Slice(items, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc`
+		if items[i].Age != items[j].Age {
+			return items[i].Age < items[j].Age
+		}
+		return items[i].Name < items[j].Name
+	})
+}
@@ -0,0 +1,35 @@
+package sorttest
+
+import "sort"
+
+// An array passed to sort.Slice cannot be passed to slices.SortFunc, so the
+// migration stays report-only.
+func sortArray() {
+	var arr [3]Item
+	sort.Slice(arr, func(i, j int) bool { return arr[i].Age < arr[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func sortArrayWhole() {
+	arr := [3]int{3, 1, 2}
+	sort.Slice(arr, func(i, j int) bool { return arr[i] < arr[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Slicing the array gives a slice, but the callback indexes arr, not the
+// slice argument, so the callback cannot be rewritten.
+func sortArraySliced() {
+	var arr [3]Item
+	sort.Slice(arr[:], func(i, j int) bool { return arr[i].Age < arr[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// A slice of the array bound to a variable migrates like any slice.
+func sortArrayView() {
+	var arr [3]Item
+	s := arr[:]
+	sort.Slice(s, func(i, j int) bool { return s[i].Age < s[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Direct replacements take the sliced array as is.
+func sortIntsArray() {
+	arr := [3]int{3, 1, 2}
+	sort.Ints(arr[:]) // want `sort\.Ints can be replaced with slices\.Sort`
+}
@@ -50,7 +50,7 @@ func sliceSwappedParams() {
 // Swapped params + > operator: double reversal = ascending.
 func sliceSwappedParamsGTR() {
 	s := []int{1, 2, 3}
-	sort.Slice(s, func(i, j int) bool { return s[j] > s[i] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	sort.Slice(s, func(i, j int) bool { return s[j] > s[i] }) // want `sort\.Slice can be replaced with slices\.Sort$`
 	_ = s
 }
 
@@ -75,6 +75,20 @@ func sliceStableFieldAccess() {
 	_ = items
 }
 
+// SliceStable descending with the > operator.
+func sliceStableFieldAccessDescending() {
+	items := []Item{{Age: 1}, {Age: 2}}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Age > items[j].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = items
+}
+
+// SliceStable descending with swapped params.
+func sliceStableFieldAccessSwapped() {
+	items := []Item{{Age: 1}, {Age: 2}}
+	sort.SliceStable(items, func(i, j int) bool { return items[j].Age < items[i].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = items
+}
+
 // SliceIsSorted with method call.
 func sliceIsSortedMethodCall() {
 	entries := []Entry{{name: "b"}, {name: "a"}}
@@ -90,7 +104,7 @@ func sliceCrossPackageImported(entries []fs.DirEntry) {
 // Multiline callback with single return — should still be fixable.
 func sliceMultiline() {
 	s := []int{3, 1, 2}
-	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc`
+	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.Sort$`
 		return s[i] < s[j]
 	})
 	_ = s
@@ -132,3 +146,14 @@ func sliceDifferentSlice() {
 	_ = s
 	_ = other
 }
+
+type Scored struct {
+	Score float64
+}
+
+// Float field without -warn-float-compare: fixable, no caveat in the message.
+func sliceFloatField() {
+	items := []Scored{{Score: 2}, {Score: 1}}
+	sort.Slice(items, func(i, j int) bool { return items[i].Score < items[j].Score }) // want `sort\.Slice can be replaced with slices\.SortFunc$`
+	_ = items
+}
@@ -0,0 +1,49 @@
+package sorttest
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// Callback already delegating to strings.Compare.
+func sliceStringsCompare() {
+	items := []Item{{Name: "b"}, {Name: "a"}}
+	sort.Slice(items, func(i, j int) bool { return strings.Compare(items[i].Name, items[j].Name) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// Descending with > 0.
+func sliceStringsCompareDescending() {
+	names := []string{"a", "b"}
+	sort.SliceStable(names, func(i, j int) bool { return strings.Compare(names[i], names[j]) > 0 }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = names
+}
+
+// Swapped params are descending too.
+func sliceStringsCompareSwapped() {
+	items := []Item{{Name: "a"}, {Name: "b"}}
+	sort.Slice(items, func(i, j int) bool { return strings.Compare(items[j].Name, items[i].Name) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// bytes.Compare on byte slices.
+func sliceBytesCompare() {
+	keys := [][]byte{[]byte("b"), []byte("a")}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = keys
+}
+
+// Different fields on each side — report-only.
+func sliceStringsCompareMismatch() {
+	items := []Item{{Name: "a"}, {Name: "b"}}
+	sort.Slice(items, func(i, j int) bool { return strings.Compare(items[i].Name, items[j].Inner.Key) < 0 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// Compared against a value other than zero — report-only.
+func sliceStringsCompareNonZero() {
+	names := []string{"a", "b"}
+	sort.Slice(names, func(i, j int) bool { return strings.Compare(names[i], names[j]) < 1 }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = names
+}
@@ -0,0 +1,38 @@
+package sorttest
+
+import "sort"
+
+type inventory struct {
+	items []Item
+	named struct {
+		names []string
+	}
+}
+
+// Slice field of a receiver: the selector stays as the first argument.
+func (inv *inventory) sortByAge() {
+	sort.Slice(inv.items, func(i, j int) bool { return inv.items[i].Age < inv.items[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Field chain after the index.
+func (inv *inventory) sortByKey() {
+	sort.SliceStable(inv.items, func(i, j int) bool { return inv.items[i].Inner.Key > inv.items[j].Inner.Key }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
+
+// Nested field of a value: whole elements in the natural order.
+func sortInventoryNames(inv inventory) {
+	sort.Slice(inv.named.names, func(i, j int) bool { return inv.named.names[i] < inv.named.names[j] }) // want `sort\.Slice can be replaced with slices\.Sort`
+}
+
+// The callback indexes a different slice than the one being sorted, so
+// report-only.
+func (inv *inventory) sortMismatched(other []Item) {
+	sort.Slice(inv.items, func(i, j int) bool { return other[i].Age < other[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// A method result is evaluated on every callback call, so report-only.
+func (inv *inventory) list() []Item { return inv.items }
+
+func (inv *inventory) sortCall() {
+	sort.Slice(inv.list(), func(i, j int) bool { return inv.list()[i].Age < inv.list()[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
@@ -0,0 +1,52 @@
+package sorttest
+
+import "sort"
+
+// The generated comparator receives elements, not indices, so callbacks that
+// use i or j for anything other than indexing the sorted slice must stay
+// report-only.
+
+// Index used in an extra condition.
+func sliceIndexInCondition() {
+	s := []int{3, 1, 2}
+	threshold := 2
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] && i < threshold }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = s
+}
+
+// Comparing the indices themselves.
+func sliceCompareIndices() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { return i < j }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = s
+}
+
+// Index arithmetic inside the index expression.
+func sliceIndexArithmetic() {
+	s := []int{3, 1, 2, 4}
+	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j-1] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = s
+}
+
+// Index used to look up a parallel slice.
+func sliceParallelLookup() {
+	s := []int{3, 1, 2}
+	weights := []int{1, 2, 3}
+	sort.Slice(s, func(i, j int) bool { return s[i]*weights[i] < s[j]*weights[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = s
+}
+
+// Index passed as a method argument.
+func sliceIndexAsArgument() {
+	entries := []Entry{{name: "b"}, {name: "a"}}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].rank(i) < entries[j].rank(j) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = entries
+}
+
+// Nested indexing through the slice itself.
+func sliceNestedIndex() {
+	s := []int{2, 0, 1}
+	_ = sort.SliceIsSorted(s, func(i, j int) bool { return s[s[i]] < s[s[j]] }) // want `sort\.SliceIsSorted can be replaced with slices\.IsSortedFunc`
+}
+
+func (e Entry) rank(i int) int { return i }
@@ -0,0 +1,47 @@
+package sorttest
+
+import "sort"
+
+// Sort strings by length.
+func sliceByLen() {
+	words := []string{"ccc", "a", "bb"}
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) < len(words[j]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = words
+}
+
+// Sort nested slices by length.
+func sliceByLenNested() {
+	groups := [][]int{{1, 2, 3}, {1}, {1, 2}}
+	sort.Slice(groups, func(i, j int) bool { return len(groups[i]) < len(groups[j]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = groups
+}
+
+// Descending by length with the > operator.
+func sliceByLenDescending() {
+	words := []string{"a", "ccc", "bb"}
+	sort.SliceStable(words, func(i, j int) bool { return len(words[i]) > len(words[j]) }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = words
+}
+
+// Length of a field.
+func sliceByFieldLen() {
+	items := []Item{{Name: "bb"}, {Name: "a"}}
+	sort.Slice(items, func(i, j int) bool { return len(items[i].Name) < len(items[j].Name) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// len of the element on one side and of a re-slice on the other — the
+// operands differ, report-only.
+func sliceByLenMismatched() {
+	words := []string{"a", "bb"}
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) < len(words[j][1:]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = words
+}
+
+// Shadowed len — not the builtin, report-only.
+func sliceByShadowedLen() {
+	len := func(s string) int { return 0 }
+	words := []string{"a", "bb"}
+	sort.Slice(words, func(i, j int) bool { return len(words[i]) < len(words[j]) }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = words
+}
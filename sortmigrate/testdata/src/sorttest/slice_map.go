@@ -0,0 +1,47 @@
+package sorttest
+
+import "sort"
+
+// Sort keys by their mapped value, ascending.
+func sliceByMapValue() {
+	scores := map[string]int{"a": 2, "b": 1}
+	keys := []string{"a", "b"}
+	sort.Slice(keys, func(i, j int) bool { return scores[keys[i]] < scores[keys[j]] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = keys
+}
+
+// Descending with the > operator.
+func sliceByMapValueDescending() {
+	scores := map[string]int{"a": 2, "b": 1}
+	keys := []string{"a", "b"}
+	sort.SliceStable(keys, func(i, j int) bool { return scores[keys[i]] > scores[keys[j]] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = keys
+}
+
+type ranking struct {
+	byAge map[int]float64
+}
+
+// Map reached through a field, keyed by an element field.
+func sliceByMapFieldKey(r ranking) {
+	items := []Item{{Age: 1}, {Age: 2}}
+	sort.Slice(items, func(i, j int) bool { return r.byAge[items[i].Age] < r.byAge[items[j].Age] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = items
+}
+
+// Different maps on each side — report-only.
+func sliceByTwoMaps() {
+	m1 := map[string]int{}
+	m2 := map[string]int{}
+	keys := []string{"a", "b"}
+	sort.Slice(keys, func(i, j int) bool { return m1[keys[i]] < m2[keys[j]] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = keys
+}
+
+// Map named like a generated parameter would be shadowed — report-only.
+func sliceByShadowedMap() {
+	a := map[string]int{}
+	keys := []string{"a", "b"}
+	sort.Slice(keys, func(i, j int) bool { return a[keys[i]] < a[keys[j]] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = keys
+}
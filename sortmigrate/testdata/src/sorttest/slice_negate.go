@@ -0,0 +1,31 @@
+package sorttest
+
+import "sort"
+
+// Negating both sides sorts descending.
+func sliceNegated() {
+	scores := []int{1, 3, 2}
+	sort.Slice(scores, func(i, j int) bool { return -scores[i] < -scores[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = scores
+}
+
+// Negated field with swapped params — two flips, ascending.
+func sliceNegatedSwapped() {
+	items := []Scored{{Score: 2}, {Score: 1}}
+	sort.SliceStable(items, func(i, j int) bool { return -items[j].Score < -items[i].Score }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	_ = items
+}
+
+// Negation on only one side — report-only.
+func sliceNegatedOneSided() {
+	scores := []int{1, 3, 2}
+	sort.Slice(scores, func(i, j int) bool { return -scores[i] < scores[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = scores
+}
+
+// Unsigned negation wraps around instead of reversing — report-only.
+func sliceNegatedUnsigned() {
+	sizes := []uint{1, 3, 2}
+	sort.Slice(sizes, func(i, j int) bool { return -sizes[i] < -sizes[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = sizes
+}
@@ -0,0 +1,44 @@
+package sorttest
+
+import "sort"
+
+// Non-strict operators combined with swapped params. Direction follows the
+// same XOR rule as < and >: descending when exactly one of (operator is >=,
+// params are swapped) holds.
+
+// s[i] <= s[j]: ascending.
+func sliceLEQ() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { return s[i] <= s[j] }) // want `sort\.Slice can be replaced with slices\.Sort$`
+	_ = s
+}
+
+// s[j] <= s[i]: swapped params, descending.
+func sliceLEQSwapped() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { return s[j] <= s[i] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = s
+}
+
+// s[i] >= s[j]: reversed operator, descending.
+func sliceGEQ() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { return s[i] >= s[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	_ = s
+}
+
+// s[j] >= s[i]: reversed operator and swapped params, ascending.
+func sliceGEQSwapped() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { return s[j] >= s[i] }) // want `sort\.Slice can be replaced with slices\.Sort$`
+	_ = s
+}
+
+// Same four combinations on a field, through SliceStable.
+func sliceStableNonStrictFields() {
+	items := []Item{{Age: 2}, {Age: 1}}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Age <= items[j].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	sort.SliceStable(items, func(i, j int) bool { return items[j].Age <= items[i].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Age >= items[j].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	sort.SliceStable(items, func(i, j int) bool { return items[j].Age >= items[i].Age }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
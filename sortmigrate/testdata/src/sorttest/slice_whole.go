@@ -0,0 +1,29 @@
+package sorttest
+
+import "sort"
+
+type label string
+
+// Whole string elements, ascending: the callback is the natural order.
+func sliceWholeStrings() {
+	names := []string{"b", "a"}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] }) // want `sort\.Slice can be replaced with slices\.Sort$`
+}
+
+// Named types with an ordered underlying type work with slices.Sort too.
+func sliceWholeNamed() {
+	labels := []label{"b", "a"}
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i] < labels[j] }) // want `sort\.SliceStable can be replaced with slices\.Sort$`
+}
+
+// Descending keeps the SortFunc form.
+func sliceWholeDescending() {
+	s := []int{1, 2, 3}
+	sort.Slice(s, func(i, j int) bool { return s[i] > s[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Floats keep the SortFunc form: -0 and +0 compare equal but differ.
+func sliceWholeFloats() {
+	fs := []float64{2, 1}
+	sort.SliceStable(fs, func(i, j int) bool { return fs[i] < fs[j] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
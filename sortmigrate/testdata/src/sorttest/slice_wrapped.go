@@ -0,0 +1,40 @@
+package sorttest
+
+import "sort"
+
+// Return wrapped in a nested block.
+func sliceNestedBlock() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.Sort$`
+		{
+			return s[i] < s[j]
+		}
+	})
+}
+
+// Leading empty statement.
+func sliceLeadingEmpty() {
+	s := []string{"b", "a"}
+	sort.SliceStable(s, func(i, j int) bool { ; return s[i] > s[j] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+}
+
+// A nested block with more than the return — report-only.
+func sliceNestedBlockMulti() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc`
+		{
+			x := s[i]
+			return x < s[j]
+		}
+	})
+}
+
+// A block followed by a return — report-only.
+func sliceBlockThenReturn() {
+	s := []int{3, 1, 2}
+	sort.Slice(s, func(i, j int) bool { // want `sort\.Slice can be replaced with slices\.SortFunc`
+		{
+		}
+		return s[i] < s[j]
+	})
+}
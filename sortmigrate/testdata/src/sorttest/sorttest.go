@@ -39,14 +39,14 @@ func sortedChecks() {
 func sliceFuncs() {
 	s := []int{3, 1, 2}
 
-	// Should be flagged: sort.Slice -> slices.SortFunc.
-	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+	// Should be flagged: sort.Slice -> slices.Sort, since it compares whole elements.
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.Slice can be replaced with slices\.Sort$`
 
-	// Should be flagged: sort.SliceStable -> slices.SortStableFunc.
-	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.SliceStable can be replaced with slices\.SortStableFunc`
+	// Should be flagged: sort.SliceStable -> slices.Sort; equal ints are indistinguishable.
+	sort.SliceStable(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.SliceStable can be replaced with slices\.Sort$`
 
-	// Should be flagged: sort.SliceIsSorted -> slices.IsSortedFunc.
-	_ = sort.SliceIsSorted(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.SliceIsSorted can be replaced with slices\.IsSortedFunc`
+	// Should be flagged: sort.SliceIsSorted -> slices.IsSorted.
+	_ = sort.SliceIsSorted(s, func(i, j int) bool { return s[i] < s[j] }) // want `sort\.SliceIsSorted can be replaced with slices\.IsSorted$`
 }
 
 func noMatch() {
@@ -0,0 +1,19 @@
+package sorttest
+
+import "sort"
+
+// A slice obtained by a type assertion has no name the comparator could
+// index, so the migration stays report-only.
+func sortAsserted(x any) {
+	sort.Slice(x.([]int), func(i, j int) bool { return x.([]int)[i] < x.([]int)[j] }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+func sortAssertedField(x any) {
+	sort.Slice(x.([]Item), func(i, j int) bool { return x.([]Item)[i].Age < x.([]Item)[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
+
+// Asserting into a variable first migrates like any slice.
+func sortAssertedVar(x any) {
+	s := x.([]Item)
+	sort.Slice(s, func(i, j int) bool { return s[i].Age < s[j].Age }) // want `sort\.Slice can be replaced with slices\.SortFunc`
+}
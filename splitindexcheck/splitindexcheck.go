@@ -0,0 +1,169 @@
+// Package splitindexcheck defines an analyzer that detects strings.Split
+// calls whose result is only used for its first field.
+//
+// # Analyzer splitindexcheck
+//
+// splitindexcheck: detect strings.Split results used only at index 0
+//
+// This analyzer flags a strings.Split whose result is only ever indexed at
+// [0], whether directly or through a variable:
+//
+//	host := strings.Split(addr, ":")[0]
+//
+//	parts := strings.Split(line, "=")
+//	key := parts[0]
+//
+// Split finds every separator and allocates a slice of all the fields, only
+// for the first one to be kept. strings.Cut stops at the first separator and
+// allocates nothing:
+//
+//	host, _, _ := strings.Cut(addr, ":")
+//
+// and strings.SplitN(s, sep, 2) keeps a slice for code that wants one.
+//
+// A variable counts only when it is declared with := from the Split call
+// and every later use in the same block indexes it with the constant 0 to
+// read it; a use of len, a range over it, or a write to an element is not
+// flagged. Splitting on an empty separator, which splits after each UTF-8
+// sequence, is not flagged either. Diagnostics are report-only, since Cut
+// and SplitN change the shape of the code around the call.
+package splitindexcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "splitindexcheck",
+	Doc:      "detect strings.Split results used only at index 0",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IndexExpr)(nil),
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.IndexExpr:
+			// strings.Split(s, sep)[0]
+			if call, ok := ast.Unparen(n.X).(*ast.CallExpr); ok && isSplit(pass, call) && isZero(pass, n.Index) {
+				report(pass, call)
+			}
+			return
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i, stmt := range list {
+			assign, ok := stmt.(*ast.AssignStmt)
+			if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				continue
+			}
+			call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+			if !ok || !isSplit(pass, call) {
+				continue
+			}
+			id, ok := assign.Lhs[0].(*ast.Ident)
+			if !ok {
+				continue
+			}
+			obj := pass.TypesInfo.Defs[id]
+			if obj == nil || !onlyFirstRead(pass, list[i+1:], obj) {
+				continue
+			}
+			report(pass, call)
+		}
+	})
+
+	return nil, nil
+}
+
+func report(pass *analysis.Pass, call *ast.CallExpr) {
+	s, sep := types.ExprString(call.Args[0]), types.ExprString(call.Args[1])
+	pass.Report(analysis.Diagnostic{
+		Pos: call.Pos(),
+		End: call.End(),
+		Message: fmt.Sprintf("only the first field of strings.Split(%s, %s) is used; strings.Cut(%s, %s) or strings.SplitN(%s, %s, 2) stops at the first separator",
+			s, sep, s, sep, s, sep),
+	})
+}
+
+// isSplit reports whether call is strings.Split(s, sep) with a separator
+// that is not the constant "".
+func isSplit(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "strings" || fn.Name() != "Split" || len(call.Args) != 2 {
+		return false
+	}
+	sep := pass.TypesInfo.Types[call.Args[1]].Value
+	return sep == nil || sep.Kind() != constant.String || constant.StringVal(sep) != ""
+}
+
+// onlyFirstRead reports whether obj is used in stmts, and only as obj[0]
+// read as a value.
+func onlyFirstRead(pass *analysis.Pass, stmts []ast.Stmt, obj types.Object) bool {
+	uses, reads := 0, 0
+	// written holds the obj[0] expressions that are assigned to or have
+	// their address taken.
+	written := make(map[ast.Expr]bool)
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					written[ast.Unparen(lhs)] = true
+				}
+			case *ast.IncDecStmt:
+				written[ast.Unparen(n.X)] = true
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					written[ast.Unparen(n.X)] = true
+				}
+			case *ast.IndexExpr:
+				if isObj(pass, n.X, obj) && isZero(pass, n.Index) && !written[n] {
+					reads++
+				}
+			case *ast.Ident:
+				if pass.TypesInfo.Uses[n] == obj {
+					uses++
+				}
+			}
+			return true
+		})
+	}
+	return uses > 0 && uses == reads
+}
+
+// isZero reports whether expr is the constant 0.
+func isZero(pass *analysis.Pass, expr ast.Expr) bool {
+	tv := pass.TypesInfo.Types[expr]
+	return tv.Value != nil && tv.Value.Kind() == constant.Int && constant.Sign(tv.Value) == 0
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	id, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(id) == obj
+}
@@ -0,0 +1,13 @@
+package splitindexcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/splitindexcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSplitIndexCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, splitindexcheck.Analyzer, "splitindextest")
+}
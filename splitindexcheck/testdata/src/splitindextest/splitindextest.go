@@ -0,0 +1,75 @@
+package splitindextest
+
+import "strings"
+
+func host(addr string) string {
+	// Should be flagged: indexed directly.
+	return strings.Split(addr, ":")[0] // want `only the first field of strings\.Split\(addr, ":"\) is used; strings\.Cut\(addr, ":"\) or strings\.SplitN\(addr, ":", 2\) stops at the first separator`
+}
+
+func key(line string) string {
+	// Should be flagged: the variable is only read at [0].
+	parts := strings.Split(line, "=") // want `only the first field of strings\.Split\(line, "="\) is used`
+	k := strings.TrimSpace(parts[0])
+	if k == "" {
+		return parts[0]
+	}
+	return k
+}
+
+func keys(lines []string, sep string) []string {
+	var out []string
+	for _, line := range lines {
+		// Should be flagged: a non-constant separator.
+		fields := strings.Split(line, sep) // want `only the first field of strings\.Split\(line, sep\) is used`
+		out = append(out, fields[0])
+	}
+	return out
+}
+
+func allFields(line string) []string {
+	// Every field is used — should NOT be flagged.
+	parts := strings.Split(line, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+func keyValue(line string) (string, string) {
+	// Second field is used — should NOT be flagged.
+	parts := strings.Split(line, "=")
+	return parts[0], parts[1]
+}
+
+func guarded(line string) string {
+	// len is used — should NOT be flagged.
+	parts := strings.Split(line, "=")
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
+
+func written(line string) []string {
+	// An element is written — should NOT be flagged.
+	parts := strings.Split(line, "/")
+	parts[0] = "root"
+	return nil
+}
+
+func runes(s string) string {
+	// Empty separator splits into UTF-8 sequences — should NOT be flagged.
+	return strings.Split(s, "")[0]
+}
+
+func last(path string) string {
+	// Indexed elsewhere than 0 — should NOT be flagged.
+	return strings.Split(path, "/")[1]
+}
+
+func unused(line string) {
+	// Never used after the split — should NOT be flagged.
+	parts := strings.Split(line, ",")
+	_ = parts
+}
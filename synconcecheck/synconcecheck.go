@@ -0,0 +1,180 @@
+// Package synconcecheck defines an analyzer that detects lazy
+// initialization hand-rolled with a boolean flag and double-checked locking.
+//
+// # Analyzer synconcecheck
+//
+// synconcecheck: detect double-checked locking that sync.Once replaces
+//
+// This analyzer flags the double-checked locking idiom guarding one-time
+// initialization with a boolean flag:
+//
+//	if !initialized {
+//	    mu.Lock()
+//	    if !initialized {
+//	        setup()
+//	        initialized = true
+//	    }
+//	    mu.Unlock()
+//	}
+//
+// The first check reads the flag without holding the lock, which is a data
+// race: the Go memory model does not promise that a goroutine seeing
+// initialized == true also sees what setup wrote. sync.Once does the same
+// job correctly and says what is meant:
+//
+//	once.Do(setup)
+//
+// The mutex must be a sync.Mutex or sync.RWMutex, unlocked either right
+// after the inner if or by a defer right after the Lock call, and the inner
+// if must set the flag to true. Diagnostics are report-only, since moving
+// to sync.Once changes the declarations as well as the call site.
+package synconcecheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "synconcecheck",
+	Doc:      "detect double-checked locking that sync.Once replaces",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.IfStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		outer := n.(*ast.IfStmt)
+		flag := notFlag(pass, outer)
+		if flag == nil {
+			return
+		}
+
+		// mu.Lock(), then either defer mu.Unlock() and the inner if, or the
+		// inner if and mu.Unlock().
+		body := outer.Body.List
+		if len(body) != 3 {
+			return
+		}
+		mu := lockCall(pass, body[0], "Lock")
+		if mu == nil {
+			return
+		}
+		inner, unlock := body[1], body[2]
+		if deferStmt, ok := body[1].(*ast.DeferStmt); ok {
+			inner, unlock = body[2], &ast.ExprStmt{X: deferStmt.Call}
+		}
+		if unlocked := lockCall(pass, unlock, "Unlock"); unlocked == nil || types.ExprString(unlocked) != types.ExprString(mu) {
+			return
+		}
+		innerIf, ok := inner.(*ast.IfStmt)
+		if !ok || innerIf.Else != nil {
+			return
+		}
+		if innerFlag := notFlag(pass, innerIf); innerFlag == nil || types.ExprString(innerFlag) != types.ExprString(flag) {
+			return
+		}
+		if !setsTrue(pass, innerIf.Body, flag) {
+			return
+		}
+
+		name := types.ExprString(flag)
+		pass.Reportf(outer.Pos(),
+			"double-checked locking on %s races with the write under %s; use sync.Once for one-time initialization",
+			name, types.ExprString(mu))
+	})
+
+	return nil, nil
+}
+
+// notFlag returns x if ifStmt has no init statement or else branch and its
+// condition is !x for a boolean variable or field x.
+func notFlag(pass *analysis.Pass, ifStmt *ast.IfStmt) ast.Expr {
+	if ifStmt.Init != nil || ifStmt.Else != nil {
+		return nil
+	}
+	not, ok := ast.Unparen(ifStmt.Cond).(*ast.UnaryExpr)
+	if !ok || not.Op != token.NOT {
+		return nil
+	}
+	x := ast.Unparen(not.X)
+	switch x := x.(type) {
+	case *ast.Ident:
+		if _, ok := pass.TypesInfo.Uses[x].(*types.Var); !ok {
+			return nil
+		}
+	case *ast.SelectorExpr:
+		if _, ok := pass.TypesInfo.Uses[x.Sel].(*types.Var); !ok {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return x
+}
+
+// lockCall returns mu if stmt is the call mu.<method>() on a sync.Mutex or
+// sync.RWMutex.
+func lockCall(pass *analysis.Pass, stmt ast.Stmt, method string) ast.Expr {
+	exprStmt, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return nil
+	}
+	call, ok := exprStmt.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 0 {
+		return nil
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != method {
+		return nil
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync" {
+		return nil
+	}
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return nil
+	}
+	named, ok := types.Unalias(deref(recv.Type())).(*types.Named)
+	if !ok || (named.Obj().Name() != "Mutex" && named.Obj().Name() != "RWMutex") {
+		return nil
+	}
+	return sel.X
+}
+
+// setsTrue reports whether body assigns true to flag at its top level.
+func setsTrue(pass *analysis.Pass, body *ast.BlockStmt, flag ast.Expr) bool {
+	for _, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+		if types.ExprString(assign.Lhs[0]) != types.ExprString(flag) {
+			continue
+		}
+		if ident, ok := ast.Unparen(assign.Rhs[0]).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == types.Universe.Lookup("true") {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
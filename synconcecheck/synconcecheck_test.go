@@ -0,0 +1,13 @@
+package synconcecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/synconcecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestSyncOnceCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, synconcecheck.Analyzer, "synconcetest")
+}
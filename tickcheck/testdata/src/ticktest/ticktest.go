@@ -0,0 +1,94 @@
+package ticktest
+
+import (
+	"fmt"
+	"time"
+	clock "time"
+)
+
+func poll() {
+	c := time.Tick(time.Second) // want `time\.Tick leaks its ticker`
+	for range c {
+		fmt.Println("tick")
+	}
+}
+
+func pollAliased(d time.Duration) {
+	ch := clock.Tick(d) // want `time\.Tick leaks its ticker`
+	<-ch
+}
+
+// Ranging over the call directly — report-only.
+func pollRange() {
+	for range time.Tick(time.Second) { // want `time\.Tick leaks its ticker`
+		fmt.Println("tick")
+	}
+}
+
+// Inside a loop the deferred Stop would pile up — report-only.
+func pollInLoop(n int) {
+	for i := 0; i < n; i++ {
+		c := time.Tick(time.Millisecond) // want `time\.Tick leaks its ticker`
+		<-c
+	}
+}
+
+// ticker is already declared — report-only.
+func pollTickerInScope(ticker string) {
+	c := time.Tick(time.Second) // want `time\.Tick leaks its ticker`
+	<-c
+	_ = ticker
+}
+
+// ticker is declared later in the same block — report-only.
+func pollTickerLater() {
+	c := time.Tick(time.Second) // want `time\.Tick leaks its ticker`
+	<-c
+	ticker := 1
+	_ = ticker
+}
+
+// An outer ticker is used after the block — report-only.
+func pollOuterTicker(ok bool) {
+	var ticker *time.Ticker
+	if ok {
+		c := time.Tick(time.Second) // want `time\.Tick leaks its ticker`
+		<-c
+		ticker = time.NewTicker(time.Second)
+	}
+	_ = ticker
+}
+
+// Assignment to an existing variable — report-only.
+func pollAssign() {
+	var c <-chan time.Time
+	c = time.Tick(time.Second) // want `time\.Tick leaks its ticker`
+	<-c
+}
+
+// Inside a function literal in a loop, the literal owns the defer.
+func pollFuncLit(n int) {
+	for i := 0; i < n; i++ {
+		func() {
+			c := time.Tick(time.Millisecond) // want `time\.Tick leaks its ticker`
+			<-c
+		}()
+	}
+}
+
+// NewTicker is what we want.
+func pollTicker() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	<-ticker.C
+}
+
+type fake struct{}
+
+func (fake) Tick(d time.Duration) <-chan time.Time { return nil }
+
+// A method named Tick is not time.Tick.
+func pollFake(f fake) {
+	c := f.Tick(time.Second)
+	_ = c
+}
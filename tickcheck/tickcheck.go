@@ -0,0 +1,163 @@
+// Package tickcheck defines an analyzer that detects calls to time.Tick,
+// whose ticker can never be stopped.
+//
+// # Analyzer tickcheck
+//
+// tickcheck: detect time.Tick calls that should use time.NewTicker
+//
+// This analyzer flags calls to time.Tick:
+//
+//	c := time.Tick(d)
+//
+// time.Tick returns only the ticker's channel, so nothing can ever call Stop
+// on the ticker and it keeps firing for as long as the channel is reachable.
+// Long-lived code should own the ticker and stop it when done:
+//
+//	ticker := time.NewTicker(d)
+//	defer ticker.Stop()
+//	c := ticker.C
+//
+// The rewrite introduces new statements and a deferred call, so diagnostics
+// are report-only by default. With -fix-tick, a suggested fix performs the
+// rewrite above for a call assigned to a new variable with :=, outside any
+// loop, in a block where the name ticker is neither in scope nor declared
+// later.
+package tickcheck
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "tickcheck",
+	Doc:      "detect time.Tick calls that should use time.NewTicker",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// fixTick enables suggested fixes for flagged calls.
+var fixTick bool
+
+func init() {
+	Analyzer.Flags.BoolVar(&fixTick, "fix-tick", false,
+		"offer fixes rewriting c := time.Tick(d) to a time.NewTicker with a deferred Stop")
+}
+
+const msg = "time.Tick leaks its ticker, which can never be stopped; use time.NewTicker and defer ticker.Stop()"
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !isTimeTick(pass, sel) {
+			return true
+		}
+
+		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+		if fixTick {
+			if edit := buildFix(pass, call, sel, stack); edit != nil {
+				diag.SuggestedFixes = []analysis.SuggestedFix{
+					{Message: "use time.NewTicker with a deferred Stop", TextEdits: []analysis.TextEdit{*edit}},
+				}
+			}
+		}
+		pass.Report(diag)
+		return true
+	})
+
+	return nil, nil
+}
+
+// isTimeTick reports whether sel refers to the time.Tick function.
+func isTimeTick(pass *analysis.Pass, sel *ast.SelectorExpr) bool {
+	fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "time" || fn.Name() != "Tick" {
+		return false
+	}
+	_, isPkg := sel.X.(*ast.Ident)
+	return isPkg
+}
+
+// buildFix returns a TextEdit replacing the statement c := time.Tick(d) with
+// a ticker, a deferred Stop, and c := ticker.C. It returns nil when call is
+// not in that form or when the rewrite would be unsafe.
+func buildFix(pass *analysis.Pass, call *ast.CallExpr, sel *ast.SelectorExpr, stack []ast.Node) *analysis.TextEdit {
+	if len(stack) < 3 || len(call.Args) != 1 {
+		return nil
+	}
+	assign, ok := stack[len(stack)-2].(*ast.AssignStmt)
+	if !ok || assign.Tok != token.DEFINE || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil
+	}
+	if _, ok := stack[len(stack)-3].(*ast.BlockStmt); !ok {
+		return nil
+	}
+	lhs, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok || lhs.Name == "_" || lhs.Name == "ticker" {
+		return nil
+	}
+
+	// The deferred Stop runs when the function returns, so inside a loop it
+	// would pile up a ticker per iteration.
+outer:
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			return nil
+		case *ast.FuncDecl, *ast.FuncLit:
+			break outer
+		}
+	}
+
+	// Look past assign too: a later ticker in the same block would be
+	// redeclared, and a later use of an outer one would be shadowed.
+	if scope := pass.Pkg.Scope().Innermost(assign.Pos()); scope == nil {
+		return nil
+	} else if _, obj := scope.LookupParent("ticker", token.NoPos); obj != nil {
+		return nil
+	}
+
+	tokFile := pass.Fset.File(assign.Pos())
+	src, err := pass.ReadFile(tokFile.Name())
+	if err != nil {
+		return nil
+	}
+	text := func(from, to token.Pos) []byte {
+		return src[tokFile.Offset(from):tokFile.Offset(to)]
+	}
+	lineStart := tokFile.LineStart(tokFile.Line(assign.Pos()))
+	indent := text(lineStart, assign.Pos())
+
+	var buf bytes.Buffer
+	buf.WriteString("ticker := ")
+	buf.Write(text(sel.X.Pos(), sel.X.End()))
+	buf.WriteString(".NewTicker(")
+	buf.Write(text(call.Args[0].Pos(), call.Args[0].End()))
+	buf.WriteString(")\n")
+	buf.Write(indent)
+	buf.WriteString("defer ticker.Stop()\n")
+	buf.Write(indent)
+	buf.WriteString(lhs.Name + " := ticker.C")
+
+	return &analysis.TextEdit{
+		Pos:     assign.Pos(),
+		End:     assign.End(),
+		NewText: buf.Bytes(),
+	}
+}
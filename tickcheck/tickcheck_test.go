@@ -0,0 +1,25 @@
+package tickcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/tickcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTickCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, tickcheck.Analyzer, "ticktest")
+}
+
+func TestTickCheckFix(t *testing.T) {
+	if err := tickcheck.Analyzer.Flags.Set("fix-tick", "true"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = tickcheck.Analyzer.Flags.Set("fix-tick", "false")
+	})
+
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, tickcheck.Analyzer, "ticktest")
+}
@@ -0,0 +1,73 @@
+package tickerstoptest
+
+import (
+	"fmt"
+	"time"
+)
+
+func unstopped() {
+	ticker := time.NewTicker(time.Second) // want `ticker ticker is never stopped; defer ticker\.Stop\(\) after creating it`
+	for range ticker.C {
+		fmt.Println("tick")
+	}
+}
+
+func unstoppedVar(d time.Duration) {
+	var t = time.NewTicker(d) // want `ticker t is never stopped`
+	<-t.C
+	t.Reset(2 * d)
+	<-t.C
+}
+
+func discarded() {
+	for range time.NewTicker(time.Second).C { // want `ticker is discarded after taking its channel, so it can never be stopped`
+		fmt.Println("tick")
+	}
+}
+
+func deferred() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	<-ticker.C
+}
+
+func stoppedDirectly() {
+	ticker := time.NewTicker(time.Second)
+	<-ticker.C
+	ticker.Stop()
+}
+
+// Stopped from a goroutine.
+func stoppedInClosure(done <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	go func() {
+		<-done
+		ticker.Stop()
+	}()
+	<-ticker.C
+}
+
+// Returned to the caller, who owns it.
+func returned() *time.Ticker {
+	ticker := time.NewTicker(time.Second)
+	return ticker
+}
+
+type poller struct {
+	ticker *time.Ticker
+}
+
+// Stored in a struct, stopped elsewhere.
+func stored(p *poller) {
+	t := time.NewTicker(time.Second)
+	p.ticker = t
+}
+
+// A ticker created in a function literal and stopped there.
+func literal() {
+	func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		<-ticker.C
+	}()
+}
@@ -0,0 +1,152 @@
+// Package tickerstopcheck defines an analyzer that detects tickers created
+// with time.NewTicker that are never stopped.
+//
+// # Analyzer tickerstopcheck
+//
+// tickerstopcheck: detect time.NewTicker results that are never stopped
+//
+// This analyzer flags a ticker assigned to a local variable when the
+// function that declares it never calls its Stop method:
+//
+//	ticker := time.NewTicker(time.Second)
+//	for range ticker.C {
+//	    poll()
+//	}
+//
+// A ticker that is not stopped keeps firing for as long as it is reachable.
+// Stop it when the function is done with it:
+//
+//	ticker := time.NewTicker(time.Second)
+//	defer ticker.Stop()
+//
+// Stop calls anywhere in the function count, including inside function
+// literals. A ticker that is used other than through its C field and its
+// Reset and Stop methods (returned, passed to a function, or stored) may be
+// stopped elsewhere, so it is not flagged. Selecting C directly from the
+// call, as in time.NewTicker(d).C, leaves no handle to stop and is flagged
+// too. This is the time.NewTicker counterpart of tickcheck, which flags
+// time.Tick. No auto-fix is provided.
+package tickerstopcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "tickerstopcheck",
+	Doc:      "detect time.NewTicker results that are never stopped",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.WithStack(nodeFilter, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		call := n.(*ast.CallExpr)
+		if !isNewTicker(pass, call) {
+			return true
+		}
+
+		switch parent := stack[len(stack)-2].(type) {
+		case *ast.SelectorExpr:
+			if parent.Sel.Name == "C" {
+				pass.Reportf(call.Pos(), "ticker is discarded after taking its channel, so it can never be stopped; assign it and defer Stop")
+			}
+
+		case *ast.AssignStmt:
+			if parent.Tok != token.DEFINE || len(parent.Lhs) != 1 || len(parent.Rhs) != 1 {
+				return true
+			}
+			checkVar(pass, call, parent.Lhs[0], stack)
+
+		case *ast.ValueSpec:
+			if len(parent.Names) != 1 || len(parent.Values) != 1 {
+				return true
+			}
+			checkVar(pass, call, parent.Names[0], stack)
+		}
+		return true
+	})
+
+	return nil, nil
+}
+
+// checkVar reports call if the variable lhs it initializes is never stopped
+// in the enclosing function and does not escape it.
+func checkVar(pass *analysis.Pass, call *ast.CallExpr, lhs ast.Expr, stack []ast.Node) {
+	ident, ok := lhs.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	obj := pass.TypesInfo.Defs[ident]
+	if obj == nil {
+		return
+	}
+	body := enclosingBody(stack)
+	if body == nil {
+		return
+	}
+
+	stopped, escapes := false, false
+	selected := map[*ast.Ident]bool{}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			x, ok := n.X.(*ast.Ident)
+			if !ok || pass.TypesInfo.Uses[x] != obj {
+				return true
+			}
+			selected[x] = true
+			switch n.Sel.Name {
+			case "Stop":
+				stopped = true
+			case "C", "Reset":
+			default:
+				escapes = true
+			}
+		case *ast.Ident:
+			if pass.TypesInfo.Uses[n] == obj && !selected[n] {
+				escapes = true
+			}
+		}
+		return !stopped && !escapes
+	})
+	if stopped || escapes {
+		return
+	}
+	pass.Reportf(call.Pos(), "ticker %s is never stopped; defer %s.Stop() after creating it", ident.Name, ident.Name)
+}
+
+// enclosingBody returns the body of the innermost function in stack.
+func enclosingBody(stack []ast.Node) *ast.BlockStmt {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch fn := stack[i].(type) {
+		case *ast.FuncDecl:
+			return fn.Body
+		case *ast.FuncLit:
+			return fn.Body
+		}
+	}
+	return nil
+}
+
+// isNewTicker reports whether call is a call to time.NewTicker.
+func isNewTicker(pass *analysis.Pass, call *ast.CallExpr) bool {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Name() == "NewTicker"
+}
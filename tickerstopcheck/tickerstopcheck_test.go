@@ -0,0 +1,13 @@
+package tickerstopcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/tickerstopcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTickerStopCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, tickerstopcheck.Analyzer, "tickerstoptest")
+}
@@ -0,0 +1,51 @@
+package timelayouttest
+
+import "time"
+
+const dateLayout = "2020-01-02"
+
+// Real dates instead of the reference time.
+
+func formatYearTypo(t time.Time) string {
+	return t.Format("2020-01-02") // want `time layout "2020-01-02" contains 2020, which is not part of the reference time Mon Jan 2 15:04:05 MST 2006; the year is written 2006`
+}
+
+func parseYearTypo(s string) (time.Time, error) {
+	return time.Parse(dateLayout, s) // want `time layout "2020-01-02" contains 2020`
+}
+
+func parseInLocationTypo(s string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02 16:04", s, time.UTC) // want `time layout "2006-01-02 16:04" contains 16, which is not part of the reference time Mon Jan 2 15:04:05 MST 2006`
+}
+
+func appendFormatTypo(b []byte, t time.Time) []byte {
+	return t.AppendFormat(b, "15:04:05 +07:00") // want `time layout "15:04:05 \+07:00" contains 07`
+}
+
+func secondTypo(t time.Time) string {
+	return t.Format("15:04:59") // want `time layout "15:04:59" contains 59`
+}
+
+// Valid layouts.
+
+func valid(t time.Time, b []byte) {
+	_ = t.Format("2006-01-02")
+	_ = t.Format("2006-1-2")
+	_ = t.Format("Mon Jan _2 15:04:05.000 -0700 MST 2006")
+	_ = t.Format("02/01/06 3:04PM")
+	_ = t.Format("2006-01-02T15:04:05.999999999Z07:00")
+	_ = t.Format("2006-002 __2")
+	_ = t.Format(time.RFC3339)
+	_ = t.AppendFormat(b, time.Kitchen)
+	_, _ = time.Parse("20060102150405", "20240102030405")
+}
+
+// Runs of zeros format a fixed value on purpose.
+func midnight(t time.Time) string {
+	return t.Format("2006-01-02T00:00:00Z")
+}
+
+// Layouts that are not constants cannot be checked.
+func dynamic(t time.Time, layout string) string {
+	return t.Format(layout)
+}
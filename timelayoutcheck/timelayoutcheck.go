@@ -0,0 +1,158 @@
+// Package timelayoutcheck defines an analyzer that detects time layouts
+// containing digits that are not part of the reference time.
+//
+// # Analyzer timelayoutcheck
+//
+// timelayoutcheck: detect time layouts with digits outside the reference time
+//
+// Layouts passed to time.Parse, time.ParseInLocation, Time.Format, and
+// Time.AppendFormat are written as the reference time
+//
+//	Mon Jan 2 15:04:05 MST 2006
+//
+// Any digits that do not form one of its components are copied to the output
+// or expected in the input verbatim. This analyzer flags constant layouts
+// with such digits, which usually come from writing a real date instead of
+// the reference one:
+//
+//	t.Format("2020-01-02") // year written as 2020, not 2006
+//
+// Digits that happen to spell components are not caught: "2006-01-12" is
+// read as month 1 followed by day 2, just as the time package reads it.
+// Runs of zeros are accepted, since layouts like "2006-01-02T00:00:00Z"
+// format a fixed midnight on purpose. Diagnostics are report-only: the
+// intended layout cannot be known.
+package timelayoutcheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "timelayoutcheck",
+	Doc:      "detect time layouts with digits outside the reference time",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// layoutArgs maps the functions taking a layout to the index of the layout
+// argument.
+var layoutArgs = map[string]int{
+	"time.Parse":               0,
+	"time.ParseInLocation":     0,
+	"(time.Time).Format":       0,
+	"(time.Time).AppendFormat": 1,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok {
+			return
+		}
+		idx, ok := layoutArgs[fn.FullName()]
+		if !ok || idx >= len(call.Args) {
+			return
+		}
+		arg := call.Args[idx]
+		value := pass.TypesInfo.Types[arg].Value
+		if value == nil || value.Kind() != constant.String {
+			return
+		}
+		layout := constant.StringVal(value)
+
+		bad := strayDigits(layout)
+		if bad == "" {
+			return
+		}
+		hint := ""
+		if len(bad) == 4 && (strings.HasPrefix(bad, "19") || strings.HasPrefix(bad, "20")) {
+			hint = "; the year is written 2006"
+		}
+		pass.Reportf(arg.Pos(), "time layout %q contains %s, which is not part of the reference time Mon Jan 2 15:04:05 MST 2006%s",
+			layout, bad, hint)
+	})
+
+	return nil, nil
+}
+
+// strayDigits returns the first run of digits in layout that holds a digit
+// outside every reference time component, or "" if there is none. Runs of
+// only zeros are not returned.
+func strayDigits(layout string) string {
+	stray := make([]bool, len(layout))
+	for i := 0; i < len(layout); {
+		if n := component(layout[i:]); n > 0 {
+			i += n
+			continue
+		}
+		stray[i] = isDigit(layout[i])
+		i++
+	}
+
+	for i := 0; i < len(layout); {
+		if !isDigit(layout[i]) {
+			i++
+			continue
+		}
+		j := i
+		hasStray := false
+		for j < len(layout) && isDigit(layout[j]) {
+			hasStray = hasStray || stray[j]
+			j++
+		}
+		if run := layout[i:j]; hasStray && strings.Trim(run, "0") != "" {
+			return run
+		}
+		i = j
+	}
+	return ""
+}
+
+// component returns the length of the reference time component at the
+// start of s that involves digits, or 0 if there is none. It follows the
+// chunking of the time package, so "2020" starts with the day 2, not a year.
+func component(s string) int {
+	for _, prefix := range []string{
+		"-07:00:00", "Z07:00:00", "-070000", "Z070000",
+		"-07:00", "Z07:00", "-0700", "Z0700", "-07", "Z07",
+		"__2", "_2006", "_2", "2006", "002",
+		"01", "02", "03", "04", "05", "06", "15",
+		"1", "2", "3", "4", "5",
+	} {
+		if strings.HasPrefix(s, prefix) {
+			return len(prefix)
+		}
+	}
+	// A fractional second is a . or , followed by a run of 0s or 9s that
+	// does not continue with another digit.
+	if len(s) >= 2 && (s[0] == '.' || s[0] == ',') && (s[1] == '0' || s[1] == '9') {
+		j := 1
+		for j < len(s) && s[j] == s[1] {
+			j++
+		}
+		if j == len(s) || !isDigit(s[j]) {
+			return j
+		}
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
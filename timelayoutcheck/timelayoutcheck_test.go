@@ -0,0 +1,13 @@
+package timelayoutcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/timelayoutcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTimeLayoutCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, timelayoutcheck.Analyzer, "timelayouttest")
+}
@@ -0,0 +1,61 @@
+package truncatetest
+
+type Node struct {
+	Name     string
+	Children []*Node
+}
+
+type Point struct{ X, Y int }
+
+type Queue struct {
+	items []*Node
+}
+
+func (q *Queue) Reset() {
+	q.items = q.items[:0] // want `q.items = q.items\[:0\] keeps the old elements reachable through the backing array; call clear\(q.items\) first so they can be collected`
+}
+
+func pointers(nodes []*Node) []*Node {
+	nodes = nodes[:0] // want `nodes = nodes\[:0\] keeps the old elements reachable`
+	return nodes
+}
+
+func structsWithPointers(nodes []Node) []Node {
+	nodes = nodes[0:0] // want `nodes = nodes\[:0\] keeps the old elements reachable`
+	return nodes
+}
+
+func interfaces(vals []any) []any {
+	for len(vals) > 0 {
+		vals = vals[:0] // want `vals = vals\[:0\] keeps the old elements reachable`
+	}
+	return vals
+}
+
+// Values without pointers leave nothing to collect.
+func values(points []Point, ids []int, names []string) {
+	points = points[:0]
+	ids = ids[:0]
+	names = names[:0]
+	_, _, _ = points, ids, names
+}
+
+// Cleared first.
+func cleared(nodes []*Node) []*Node {
+	clear(nodes)
+	nodes = nodes[:0]
+	return nodes
+}
+
+// Truncating to a non-zero length, or into another slice, is not flagged.
+func other(nodes, dst []*Node) {
+	nodes = nodes[:1]
+	dst = nodes[:0]
+	nodes = nodes[:0:0]
+	_, _ = nodes, dst
+}
+
+func generic[T any](s []T) []T {
+	s = s[:0]
+	return s
+}
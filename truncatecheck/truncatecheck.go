@@ -0,0 +1,175 @@
+// Package truncatecheck defines an analyzer that detects slices truncated
+// for reuse while their old elements stay reachable.
+//
+// # Analyzer truncatecheck
+//
+// truncatecheck: detect s = s[:0] on slices whose elements hold pointers
+//
+// This analyzer flags truncation to zero length of a slice whose elements
+// contain pointers:
+//
+//	buf = buf[:0]
+//
+// Truncating keeps the capacity for reuse, which is usually the point, but
+// the backing array still holds the old elements until they are
+// overwritten, so whatever they point to cannot be garbage collected.
+// Clearing first drops those references and keeps the capacity:
+//
+//	clear(buf)
+//	buf = buf[:0]
+//
+// Elements hold pointers when they are pointers, slices, maps, channels,
+// functions, or interfaces, or arrays and structs containing them. String
+// elements are not flagged, to keep the noise down for the common case of
+// reusing a slice of short strings. A truncation directly preceded by
+// clear(s) is not flagged. Diagnostics are report-only.
+package truncatecheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "truncatecheck",
+	Doc:      "detect s = s[:0] on slices whose elements hold pointers",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.BlockStmt)(nil),
+		(*ast.CaseClause)(nil),
+		(*ast.CommClause)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+
+		for i, stmt := range list {
+			s, ok := truncation(pass, stmt)
+			if !ok {
+				continue
+			}
+			if i > 0 && clears(pass, list[i-1], s) {
+				continue
+			}
+			name := types.ExprString(s)
+			pass.Reportf(stmt.Pos(),
+				"%s = %s[:0] keeps the old elements reachable through the backing array; call clear(%s) first so they can be collected",
+				name, name, name)
+		}
+	})
+
+	return nil, nil
+}
+
+// truncation matches s = s[:0] where the elements of s hold pointers, and
+// returns s.
+func truncation(pass *analysis.Pass, stmt ast.Stmt) (ast.Expr, bool) {
+	assign, ok := stmt.(*ast.AssignStmt)
+	if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return nil, false
+	}
+	slice, ok := ast.Unparen(assign.Rhs[0]).(*ast.SliceExpr)
+	if !ok || slice.Slice3 || slice.High == nil || !isZero(pass, slice.High) {
+		return nil, false
+	}
+	if slice.Low != nil && !isZero(pass, slice.Low) {
+		return nil, false
+	}
+	if !sameExpr(pass, assign.Lhs[0], slice.X) {
+		return nil, false
+	}
+	t, ok := pass.TypesInfo.TypeOf(slice.X).Underlying().(*types.Slice)
+	if !ok || !hasPointers(t.Elem(), nil) {
+		return nil, false
+	}
+	return slice.X, true
+}
+
+// clears reports whether stmt is clear(s).
+func clears(pass *analysis.Pass, stmt ast.Stmt, s ast.Expr) bool {
+	expr, ok := stmt.(*ast.ExprStmt)
+	if !ok {
+		return false
+	}
+	call, ok := expr.X.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	fun, ok := call.Fun.(*ast.Ident)
+	if !ok || fun.Name != "clear" {
+		return false
+	}
+	if _, ok := pass.TypesInfo.Uses[fun].(*types.Builtin); !ok {
+		return false
+	}
+	return sameExpr(pass, call.Args[0], s)
+}
+
+// hasPointers reports whether values of t contain pointers other than string
+// data. seen guards against recursive types.
+func hasPointers(t types.Type, seen map[types.Type]bool) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		// Type parameters have interface underlying types; their type
+		// arguments are unknown.
+		_, isParam := t.(*types.TypeParam)
+		return !isParam
+	case *types.Array:
+		return hasPointers(u.Elem(), seen)
+	case *types.Struct:
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = make(map[types.Type]bool)
+		}
+		seen[t] = true
+		for i := range u.NumFields() {
+			if hasPointers(u.Field(i).Type(), seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isZero reports whether expr is the constant 0.
+func isZero(pass *analysis.Pass, expr ast.Expr) bool {
+	tv := pass.TypesInfo.Types[expr]
+	return tv.Value != nil && tv.Value.Kind() == constant.Int && constant.Sign(tv.Value) == 0
+}
+
+// sameExpr reports whether a and b are the same identifier or selector
+// chain referring to the same objects.
+func sameExpr(pass *analysis.Pass, a, b ast.Expr) bool {
+	a, b = ast.Unparen(a), ast.Unparen(b)
+	switch a := a.(type) {
+	case *ast.Ident:
+		b, ok := b.(*ast.Ident)
+		return ok && pass.TypesInfo.ObjectOf(a) != nil && pass.TypesInfo.ObjectOf(a) == pass.TypesInfo.ObjectOf(b)
+	case *ast.SelectorExpr:
+		b, ok := b.(*ast.SelectorExpr)
+		return ok && a.Sel.Name == b.Sel.Name && sameExpr(pass, a.X, b.X)
+	}
+	return false
+}
@@ -0,0 +1,13 @@
+package truncatecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/truncatecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestTruncateCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, truncatecheck.Analyzer, "truncatetest")
+}
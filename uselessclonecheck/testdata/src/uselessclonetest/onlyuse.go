@@ -0,0 +1,9 @@
+package uselessclonetest
+
+import "slices"
+
+// Should be flagged without a fix: the call is the only use of slices here.
+func onlyUse() []byte {
+	var b []byte
+	return slices.Clone(b) // want `slices\.Clone of nil b always returns nil; use the argument directly`
+}
@@ -0,0 +1,74 @@
+package uselessclonetest
+
+import (
+	"maps"
+	"slices"
+)
+
+func nilVar() []int {
+	var s []int
+	t := slices.Clone(s) // want `slices\.Clone of nil s always returns nil; use the argument directly`
+	return append(t, 1)
+}
+
+func nilConversion() map[string]int {
+	return maps.Clone(map[string]int(nil)) // want `maps\.Clone of nil always returns nil; use the argument directly`
+}
+
+func nilTypeArgs() ([]string, map[string]bool) {
+	s := slices.Clone[[]string](nil)              // want `slices\.Clone of nil always returns nil; use the argument directly`
+	m := maps.Clone[map[string]bool, string](nil) // want `maps\.Clone of nil always returns nil; use the argument directly`
+	return s, m
+}
+
+func realClone(src []int, m map[string]int) ([]int, map[string]int) {
+	// Real clones — should NOT be flagged.
+	a := slices.Clone(src)
+	b := maps.Clone(m)
+
+	// Assigned later — should NOT be flagged.
+	var c []int
+	if len(src) > 0 {
+		c = src
+	}
+	d := slices.Clone(c)
+
+	// Address taken — should NOT be flagged.
+	var e []int
+	fill(&e)
+	f := slices.Clone(e)
+
+	// Declared with a value — should NOT be flagged.
+	var g = src
+	h := slices.Clone(g)
+
+	return append(append(a, d...), append(f, h...)...), b
+}
+
+func fill(p *[]int) { *p = []int{1} }
+
+type list []int
+
+func (l *list) add(v int) { *l = append(*l, v) }
+
+func (l list) total() int { return len(l) }
+
+func methods() (list, list, int) {
+	// Pointer method call takes &s — should NOT be flagged.
+	var s list
+	s.add(1)
+	t := slices.Clone(s)
+
+	// Method value may be called later — should NOT be flagged.
+	var u list
+	add := u.add
+	add(1)
+	v := slices.Clone(u)
+
+	// Value method call cannot assign — should be flagged.
+	var w list
+	n := w.total()
+	x := slices.Clone(w) // want `slices\.Clone of nil w always returns nil; use the argument directly`
+
+	return append(t, v...), x, n
+}
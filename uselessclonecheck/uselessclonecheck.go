@@ -0,0 +1,222 @@
+// Package uselessclonecheck defines an analyzer that detects slices.Clone
+// and maps.Clone calls on values that are always nil.
+//
+// # Analyzer uselessclonecheck
+//
+// uselessclonecheck: detect slices.Clone or maps.Clone of a nil value
+//
+// This analyzer flags clones whose argument is provably nil: a nil
+// literal, possibly converted, or a local variable declared without a value
+// and never assigned:
+//
+//	var s []int
+//	t := slices.Clone(s)
+//	m := maps.Clone(map[string]int(nil))
+//
+// Cloning nil returns nil, so the call does nothing but suggest a copy that
+// is not there. The fix replaces the call with its argument; a bare nil
+// argument, which needs the explicit type argument to compile, becomes a
+// conversion to that type:
+//
+//	t := s
+//	m := map[string]int(nil)
+//
+// A variable whose address is taken, explicitly or by calling a pointer
+// method on it, or whose method value is taken, counts as assigned. The fix
+// is withheld when removing the call would leave the slices or maps import
+// unused.
+package uselessclonecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "uselessclonecheck",
+	Doc:      "detect slices.Clone or maps.Clone of a nil value",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.CallExpr)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		if len(call.Args) != 1 || call.Ellipsis.IsValid() {
+			return
+		}
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Name() != "Clone" {
+			return
+		}
+		pkg := fn.Pkg().Path()
+		if pkg != "slices" && pkg != "maps" {
+			return
+		}
+		file := importutil.FindFileForPos(pass, call.Pos())
+		if file == nil {
+			return
+		}
+		arg := call.Args[0]
+		if !isNil(pass, arg) && !neverAssigned(pass, file, arg) {
+			return
+		}
+
+		msg := fmt.Sprintf("%s.Clone of %s always returns nil; use the argument directly", pkg, describe(arg))
+		diag := analysis.Diagnostic{Pos: call.Pos(), Message: msg}
+
+		replacement := types.ExprString(arg)
+		if ident, ok := ast.Unparen(arg).(*ast.Ident); ok && ident.Name == "nil" {
+			// Clone[S](nil) becomes S(nil); without a type argument the
+			// call would not compile, so there is nothing else to handle.
+			var typeArg ast.Expr
+			switch fun := call.Fun.(type) {
+			case *ast.IndexExpr:
+				typeArg = fun.Index
+			case *ast.IndexListExpr:
+				typeArg = fun.Indices[0]
+			default:
+				pass.Report(diag)
+				return
+			}
+			replacement = types.ExprString(typeArg) + "(nil)"
+		}
+
+		sel, ok := ast.Unparen(typeFun(call.Fun)).(*ast.SelectorExpr)
+		var pkgName types.Object
+		if ok {
+			pkgName = pass.TypesInfo.Uses[identOf(sel.X)]
+		}
+		if pkgName != nil && importutil.UsedOutside(pass, file, pkgName, call) {
+			diag.SuggestedFixes = []analysis.SuggestedFix{
+				{
+					Message: "remove the " + pkg + ".Clone call",
+					TextEdits: []analysis.TextEdit{
+						{Pos: call.Pos(), End: call.End(), NewText: []byte(replacement)},
+					},
+				},
+			}
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// isNil reports whether expr is nil, possibly parenthesized or converted.
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	switch expr := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		_, ok := pass.TypesInfo.Uses[expr].(*types.Nil)
+		return ok
+	case *ast.CallExpr:
+		return len(expr.Args) == 1 && pass.TypesInfo.Types[expr.Fun].IsType() && isNil(pass, expr.Args[0])
+	}
+	return false
+}
+
+// neverAssigned reports whether expr is a local variable declared with var
+// and no value, and never assigned, addressed, or used as the receiver of a
+// pointer method or a method value in file.
+func neverAssigned(pass *analysis.Pass, file *ast.File, expr ast.Expr) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	obj, ok := pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || obj.Parent() == nil || obj.Parent() == pass.Pkg.Scope() || obj.IsField() {
+		return false
+	}
+
+	declared, assigned := false, false
+	// called holds the method selectors that are called rather than taken
+	// as method values.
+	called := make(map[*ast.SelectorExpr]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok {
+				called[sel] = true
+			}
+		case *ast.SelectorExpr:
+			// A pointer method call takes &obj implicitly, and a method
+			// value may be called anywhere.
+			if sel := pass.TypesInfo.Selections[n]; sel != nil && sel.Kind() == types.MethodVal && isObj(pass, n.X, obj) {
+				_, ptrRecv := sel.Obj().(*types.Func).Signature().Recv().Type().(*types.Pointer)
+				if ptrRecv || !called[n] {
+					assigned = true
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				if pass.TypesInfo.Defs[name] == obj {
+					declared = len(n.Values) == 0
+				}
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isObj(pass, lhs, obj) {
+					assigned = true
+				}
+			}
+		case *ast.RangeStmt:
+			if isObj(pass, n.Key, obj) || isObj(pass, n.Value, obj) {
+				assigned = true
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND && isObj(pass, n.X, obj) {
+				assigned = true
+			}
+		}
+		return !assigned
+	})
+	return declared && !assigned
+}
+
+// describe names the nil argument in the diagnostic.
+func describe(arg ast.Expr) string {
+	if ident, ok := ast.Unparen(arg).(*ast.Ident); ok && ident.Name != "nil" {
+		return "nil " + ident.Name
+	}
+	return "nil"
+}
+
+// typeFun strips explicit type arguments from a called function.
+func typeFun(fun ast.Expr) ast.Expr {
+	switch fun := fun.(type) {
+	case *ast.IndexExpr:
+		return fun.X
+	case *ast.IndexListExpr:
+		return fun.X
+	}
+	return fun
+}
+
+// identOf returns expr as an identifier, or nil.
+func identOf(expr ast.Expr) *ast.Ident {
+	ident, _ := expr.(*ast.Ident)
+	return ident
+}
+
+// isObj reports whether expr is an identifier referring to obj.
+func isObj(pass *analysis.Pass, expr ast.Expr, obj types.Object) bool {
+	if expr == nil {
+		return false
+	}
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.Uses[ident] == obj
+}
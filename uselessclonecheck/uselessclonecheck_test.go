@@ -0,0 +1,13 @@
+package uselessclonecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/uselessclonecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestUselessCloneCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, uselessclonecheck.Analyzer, "uselessclonetest")
+}
@@ -0,0 +1,88 @@
+package valrecvappendtest
+
+type List struct {
+	items []int
+	meta  struct {
+		tags []string
+	}
+	shared *List
+}
+
+func (l List) Add(v int) {
+	l.items = append(l.items, v) // want `append to l\.items is lost when the method returns, since l is a value receiver; use a pointer receiver`
+}
+
+func (l List) Tag(tags ...string) {
+	l.meta.tags = append(l.meta.tags, tags...) // want `append to l\.meta\.tags is lost`
+}
+
+// Inside a function literal the receiver is still the copy.
+func (l List) AddLater(v int) func() {
+	return func() {
+		l.items = append(l.items, v) // want `append to l\.items is lost`
+	}
+}
+
+// Pointer receivers update the caller's value.
+func (l *List) AddPtr(v int) {
+	l.items = append(l.items, v)
+}
+
+// Fields behind a pointer are shared.
+func (l List) AddShared(v int) {
+	l.shared.items = append(l.shared.items, v)
+}
+
+// Returning the receiver hands the updated copy back.
+func (l List) With(v int) List {
+	l.items = append(l.items, v)
+	return l
+}
+
+// Appending to a local is fine.
+func (l List) Extended(v int) []int {
+	items := l.items
+	items = append(items, v)
+	return items
+}
+
+// Only appends to the field itself are flagged.
+func (l List) Replace(other []int, v int) {
+	l.items = append(other, v)
+}
+
+// Reading the field after the append uses the grown slice.
+func (l List) AddAll(v int) []int {
+	l.items = append(l.items, v)
+	return l.items
+}
+
+func (l List) total() int { return len(l.items) }
+
+// Calling a method on the receiver after the append reads it.
+func (l List) CountWith(v int) int {
+	l.items = append(l.items, v)
+	return l.total()
+}
+
+// In a loop, a read before the append sees the previous iteration's append.
+func (l List) Sums(vs []int) []int {
+	var sums []int
+	for _, v := range vs {
+		sums = append(sums, len(l.items))
+		l.items = append(l.items, v)
+	}
+	return sums
+}
+
+// Writing another field afterwards does not read the append.
+func (l List) AddAndTag(v int) {
+	l.items = append(l.items, v) // want `append to l\.items is lost`
+	l.meta.tags = nil
+}
+
+// Appending to another field reads only that field.
+func (l List) AddTagged(v int, tag string) {
+	l.items = append(l.items, v)           // want `append to l\.items is lost`
+	l.meta.tags = append(l.meta.tags, tag) // want `append to l\.meta\.tags is lost`
+}
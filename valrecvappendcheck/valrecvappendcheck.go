@@ -0,0 +1,209 @@
+// Package valrecvappendcheck defines an analyzer that detects appends to a
+// field of a value method receiver, which are lost when the method returns.
+//
+// # Analyzer valrecvappendcheck
+//
+// valrecvappendcheck: detect append to a field of a value receiver
+//
+// This analyzer flags assignments of the form r.f = append(r.f, ...) in a
+// method whose receiver r is a value, not a pointer:
+//
+//	func (l List) Add(v int) {
+//	    l.items = append(l.items, v)
+//	}
+//
+// The receiver is a copy of the caller's value, so the grown slice is stored
+// in the copy and dropped when the method returns. Even when append writes
+// into spare capacity of the shared backing array, the caller's length does
+// not change. Use a pointer receiver:
+//
+//	func (l *List) Add(v int) {
+//	    l.items = append(l.items, v)
+//	}
+//
+// Fields reached through a pointer are shared with the caller and are not
+// flagged, nor are methods that return the receiver, which is how a value
+// type hands back its updated copy. An append is not flagged either when the
+// method reads the field or the receiver as a whole afterwards, such as by
+// returning l.items or calling l.total(). No auto-fix is provided.
+package valrecvappendcheck
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "valrecvappendcheck",
+	Doc:      "detect append to a field of a value receiver",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.FuncDecl)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		fn := n.(*ast.FuncDecl)
+		if fn.Recv == nil || fn.Body == nil || len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 {
+			return
+		}
+		recv := pass.TypesInfo.Defs[fn.Recv.List[0].Names[0]]
+		if recv == nil {
+			return
+		}
+		if _, isPtr := recv.Type().Underlying().(*types.Pointer); isPtr {
+			return
+		}
+		if returnsVar(pass, fn.Body, recv) {
+			return
+		}
+
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			if !ok || assign.Tok != token.ASSIGN || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+				return true
+			}
+			field, ok := assign.Lhs[0].(*ast.SelectorExpr)
+			if !ok || !fieldOf(pass, field, recv) {
+				return true
+			}
+			call, ok := assign.Rhs[0].(*ast.CallExpr)
+			if !ok || len(call.Args) == 0 || !isBuiltinAppend(pass, call) {
+				return true
+			}
+			if types.ExprString(call.Args[0]) != types.ExprString(field) {
+				return true
+			}
+			if readAfter(pass, fn.Body, assign, field, recv) {
+				return true
+			}
+			pass.Reportf(assign.Pos(),
+				"append to %s is lost when the method returns, since %s is a value receiver; use a pointer receiver",
+				types.ExprString(field), recv.Name())
+			return true
+		})
+	})
+
+	return nil, nil
+}
+
+// fieldOf reports whether sel is a chain of field selections rooted at v that
+// never goes through a pointer, so it is stored in v itself.
+func fieldOf(pass *analysis.Pass, sel *ast.SelectorExpr, v types.Object) bool {
+	var expr ast.Expr = sel
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			return pass.TypesInfo.Uses[e] == v
+		case *ast.SelectorExpr:
+			selection, ok := pass.TypesInfo.Selections[e]
+			if !ok || selection.Kind() != types.FieldVal || selection.Indirect() {
+				return false
+			}
+			expr = e.X
+		default:
+			return false
+		}
+	}
+}
+
+// returnsVar reports whether body returns v directly, outside any nested
+// function literal.
+func returnsVar(pass *analysis.Pass, body *ast.BlockStmt, v types.Object) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			for _, result := range n.Results {
+				if ident, ok := ast.Unparen(result).(*ast.Ident); ok && pass.TypesInfo.Uses[ident] == v {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// readAfter reports whether body reads field, or v other than through an
+// unrelated field, after assign or anywhere in a loop enclosing assign.
+// Storing into v or its fields is not a read.
+func readAfter(pass *analysis.Pass, body *ast.BlockStmt, assign *ast.AssignStmt, field *ast.SelectorExpr, v types.Object) bool {
+	from := assign.End()
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.ForStmt, *ast.RangeStmt:
+			if n.Pos() < from && assign.End() <= n.End() {
+				from = n.Pos()
+			}
+		}
+		return true
+	})
+
+	found := false
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if n == assign {
+				return false
+			}
+			for _, lhs := range n.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && pass.TypesInfo.ObjectOf(ident) == v {
+					continue
+				}
+				if sel, ok := lhs.(*ast.SelectorExpr); ok && fieldOf(pass, sel, v) {
+					continue
+				}
+				ast.Inspect(lhs, visit)
+			}
+			for _, rhs := range n.Rhs {
+				ast.Inspect(rhs, visit)
+			}
+			return false
+		case *ast.SelectorExpr:
+			if fieldOf(pass, n, v) {
+				if n.Pos() >= from && overlaps(types.ExprString(n), types.ExprString(field)) {
+					found = true
+				}
+				return false
+			}
+		case *ast.Ident:
+			if n.Pos() >= from && pass.TypesInfo.Uses[n] == v {
+				found = true
+			}
+		}
+		return !found
+	}
+	ast.Inspect(body, visit)
+	return found
+}
+
+// overlaps reports whether the field paths a and b are the same or one
+// contains the other.
+func overlaps(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
+
+// isBuiltinAppend reports whether call is a call to the builtin append.
+func isBuiltinAppend(pass *analysis.Pass, call *ast.CallExpr) bool {
+	ident, ok := call.Fun.(*ast.Ident)
+	if !ok || ident.Name != "append" {
+		return false
+	}
+	_, ok = pass.TypesInfo.ObjectOf(ident).(*types.Builtin)
+	return ok
+}
@@ -0,0 +1,13 @@
+package valrecvappendcheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/valrecvappendcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestValRecvAppendCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, valrecvappendcheck.Analyzer, "valrecvappendtest")
+}
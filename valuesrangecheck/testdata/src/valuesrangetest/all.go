@@ -0,0 +1,34 @@
+package valuesrangetest
+
+import (
+	"maps"
+	"slices"
+)
+
+func all(s []string, m map[string]int) {
+	// Should be flagged: slices.All with index and value.
+	for i, v := range slices.All(s) { // want `range over slices\.All\(s\) can be range over s`
+		use(i)
+		use(v)
+	}
+
+	// Should be flagged: slices.All with index only.
+	for i := range slices.All(s) { // want `range over slices\.All\(s\) can be range over s`
+		use(i)
+	}
+
+	// Should be flagged: maps.All with key and value.
+	for k, v := range maps.All(m) { // want `range over maps\.All\(m\) can be range over m`
+		use(k)
+		use(v)
+	}
+
+	// Should be flagged: no loop variables.
+	for range maps.All(m) { // want `range over maps\.All\(m\) can be range over m`
+		use(nil)
+	}
+
+	// Iterator passed on rather than ranged — should NOT be flagged.
+	_ = maps.Collect(maps.All(m))
+	_ = slices.Collect(slices.Values(s))
+}
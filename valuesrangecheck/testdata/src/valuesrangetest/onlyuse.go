@@ -0,0 +1,11 @@
+package valuesrangetest
+
+import "slices"
+
+// slices is only used in the flagged loop; removing it would leave an
+// unused import, so no fix is offered.
+func onlyUse(s []int) {
+	for v := range slices.Values(s) { // want `range over slices\.Values\(s\) can be range over s with the value as second variable`
+		use(v)
+	}
+}
@@ -0,0 +1,59 @@
+package valuesrangetest
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+)
+
+func use(any) {}
+
+type holder struct{ items []string }
+
+func example(s []string, m map[string]int, h holder) {
+	// Should be flagged: slices.Values with a value variable.
+	for v := range slices.Values(s) { // want `range over slices\.Values\(s\) can be range over s with the value as second variable`
+		use(v)
+	}
+
+	// Should be flagged: maps.Values with a value variable.
+	for v := range maps.Values(m) { // want `range over maps\.Values\(m\) can be range over m with the value as second variable`
+		use(v)
+	}
+
+	// Should be flagged: no loop variables.
+	for range slices.Values(s) { // want `range over slices\.Values\(s\) can be range over s`
+		use(nil)
+	}
+
+	// Should be flagged: field argument, assignment form.
+	var v string
+	for v = range slices.Values(h.items) { // want `range over slices\.Values\(h\.items\) can be range over h\.items with the value as second variable`
+		use(v)
+	}
+}
+
+func values() iter.Seq[string] { return nil }
+
+func noMatch(s []string, m map[string]int) iter.Seq[int] {
+	// Ranging over the collection directly — should NOT be flagged.
+	for _, v := range s {
+		use(v)
+	}
+
+	// Keys, not values — should NOT be flagged.
+	for k := range maps.Keys(m) {
+		use(k)
+	}
+
+	// Not the stdlib constructor — should NOT be flagged.
+	for v := range values() {
+		use(v)
+	}
+
+	// Iterator passed on rather than ranged — should NOT be flagged.
+	_ = slices.Collect(slices.Values(s))
+	_ = strings.Join(s, ",")
+	return maps.Values(m)
+}
@@ -0,0 +1,132 @@
+// Package valuesrangecheck defines an analyzer that detects range loops over
+// slices.Values, slices.All, maps.Values, or maps.All where ranging over the
+// collection directly would do.
+//
+// # Analyzer valuesrangecheck
+//
+// valuesrangecheck: detect range over slices/maps Values and All iterators
+//
+// This analyzer flags for statements that range over an iterator built by
+// slices.Values, slices.All, maps.Values, or maps.All:
+//
+//	for v := range slices.Values(s) {
+//	    use(v)
+//	}
+//	for i, v := range slices.All(s) {
+//	    use(i, v)
+//	}
+//
+// The iterator adds a function call per element and nothing else; ranging
+// over the collection itself yields the same values:
+//
+//	for _, v := range s {
+//	    use(v)
+//	}
+//	for i, v := range s {
+//	    use(i, v)
+//	}
+//
+// The iterator constructors are the right tool when a sequence is passed
+// to a function taking iter.Seq; only their direct use as a range
+// expression is flagged. When the loop is the file's only use of the slices
+// or maps package, the loop is reported without a fix, since removing the
+// call would leave an unused import.
+//
+// Available since Go 1.23.
+package valuesrangecheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/albertocavalcante/go-analyzers/internal/importutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "valuesrangecheck",
+	Doc:      "detect range over slices/maps Values and All iterators",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	nodeFilter := []ast.Node{
+		(*ast.RangeStmt)(nil),
+	}
+
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		rng := n.(*ast.RangeStmt)
+
+		call, ok := ast.Unparen(rng.X).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || call.Ellipsis.IsValid() {
+			return
+		}
+		path, name := pkgFunc(pass, call)
+		if (name != "Values" && name != "All") || (path != "slices" && path != "maps") {
+			return
+		}
+
+		// Values yields the value alone, so its loop variable moves to the
+		// second position. All yields the same pairs as a plain range.
+		moveKey := name == "Values" && rng.Key != nil
+
+		arg := call.Args[0]
+		argStr := types.ExprString(arg)
+		msg := fmt.Sprintf("range over %s.%s(%s) can be range over %s", path, name, argStr, argStr)
+		if moveKey {
+			msg += " with the value as second variable"
+		}
+
+		diag := analysis.Diagnostic{Pos: rng.Pos(), Message: msg}
+
+		// Dropping the call must not leave the slices or maps import unused.
+		file := importutil.FindFileForPos(pass, call.Pos())
+		pkgName := pass.TypesInfo.ObjectOf(call.Fun.(*ast.SelectorExpr).X.(*ast.Ident))
+		if file == nil || !importutil.UsedOutside(pass, file, pkgName, call) {
+			pass.Report(diag)
+			return
+		}
+
+		edits := []analysis.TextEdit{
+			{Pos: call.Pos(), End: arg.Pos()},
+			{Pos: arg.End(), End: call.End()},
+		}
+		if moveKey {
+			edits = append(edits, analysis.TextEdit{Pos: rng.Key.Pos(), End: rng.Key.Pos(), NewText: []byte("_, ")})
+		}
+
+		diag.SuggestedFixes = []analysis.SuggestedFix{
+			{Message: msg, TextEdits: edits},
+		}
+		pass.Report(diag)
+	})
+
+	return nil, nil
+}
+
+// pkgFunc returns the import path and function name of a qualified call
+// like pkg.Func(...), or empty strings if call is not one.
+func pkgFunc(pass *analysis.Pass, call *ast.CallExpr) (path, name string) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", ""
+	}
+
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+
+	pkgName, ok := pass.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok {
+		return "", ""
+	}
+
+	return pkgName.Imported().Path(), sel.Sel.Name
+}
@@ -0,0 +1,13 @@
+package valuesrangecheck_test
+
+import (
+	"testing"
+
+	"github.com/albertocavalcante/go-analyzers/valuesrangecheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestValuesRangeCheck(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, valuesrangecheck.Analyzer, "valuesrangetest")
+}